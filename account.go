@@ -21,66 +21,69 @@
 package onesphere
 
 import (
+	"context"
+	"encoding/json"
+
 	"github.com/HewlettPackard/hpe-onesphere-go/rest"
 )
 
 type Account struct {
-	ID     string `json:"id"`
-	Name   string `json:"name"`
-	URI    string `json:"uri"`
-	Events []struct {
-		NamedUriIdentifier
-		ResourceUri string `json:"resourceUri"`
-		UserId      string `json:"userId"`
-		Created     string `json:"created"`
-		Modified    string `json:"modified"`
-	} `json:"events"`
-	Metrics []struct {
-		Associations []struct {
-			NamedUri
-			Category string `json:"category"`
-		} `json:"associations"`
-		Count       string    `json:"count"`
-		Description string    `json:"description"`
-		Name        string    `json:"name"`
-		Resource    *Resource `json:"resource"`
-		ResourceUri string    `json:"resourceUri"`
-		Start       int       `json:"start"`
-		Total       int       `json:"total"`
-		Units       string    `json:"units"`
-		Values      []struct {
-			End   string `json:"end"`
-			Start string `json:"start"`
-			Value string `json:"value"`
-		} `json:"values"`
-	} `json:"metrics"`
-	Created  string `json:"created"`
-	Modified string `json:"modified"`
+	ID       string          `json:"id"`
+	Name     string          `json:"name"`
+	URI      string          `json:"uri"`
+	Events   []Event         `json:"events"`
+	Metrics  []AccountMetric `json:"metrics"`
+	Created  string          `json:"created"`
+	Modified string          `json:"modified"`
+}
+
+// AccountMetric is a Metric of the account, its values may be fractional
+// such as the cost.total
+type AccountMetric struct {
+	ResourceURI string    `json:"resourceUri"`
+	Resource    *Resource `json:"resource"`
+	Name        string    `json:"name"`
+	Units       string    `json:"units"`
+	Description string    `json:"description"`
+	Values      []struct {
+		Value float64 `json:"value"`
+		Start string  `json:"start"`
+		End   string  `json:"end"`
+	} `json:"values"`
+	Total        int `json:"total"`
+	Start        int `json:"start"`
+	Count        int `json:"count"`
+	Associations []struct {
+		NamedUri
+		Category string `json:"category"`
+	} `json:"associations"`
 }
 
 // GetAccount returns global account information
 // view : "full"
 func (c *Client) GetAccount(view string) (Account, error) {
+	return c.GetAccountCtx(context.Background(), view)
+}
 
+// GetAccountCtx is like GetAccount but uses ctx for the request
+func (c *Client) GetAccountCtx(ctx context.Context, view string) (Account, error) {
 	var (
-		uri     = "/rest/account"
+		uri         = "/rest/account"
+		queryParams = createQuery(&map[string]string{
+			"view": view,
+		})
 		account Account
-		//queryParams = createQuery(&map[string]string{
-		//	"view": view,
-		//})
 	)
 
-	return account, c.notImplementedError(rest.GET, uri, "account")
+	response, err := c.RestAPICallCtx(ctx, rest.GET, uri, queryParams, nil)
+
+	if err != nil {
+		return account, err
+	}
+
+	if err := json.Unmarshal([]byte(response), &account); err != nil {
+		return account, apiResponseError(response, err)
+	}
 
-	//response, err := c.RestAPICall(rest.GET, uri, queryParams, nil)
-	//
-	//if err != nil {
-	//	return account, err
-	//}
-	//
-	//if err := json.Unmarshal([]byte(response), &account); err != nil {
-	//	return account, apiResponseError(response, err)
-	//}
-	//
-	//return account, err
+	return account, err
 }
@@ -1,15 +1,56 @@
 package onesphere
 
 import (
+	"encoding/json"
 	"testing"
 )
 
+// accountWithCost is an account as returned by /rest/account?view=full,
+// its cost.total metric has a fractional value
+const accountWithCost = `{
+  "id": "2",
+  "name": "account",
+  "uri": "/rest/account",
+  "metrics": [{
+    "name": "cost.total",
+    "units": "USD",
+    "resourceUri": "/rest/account",
+    "total": 1,
+    "start": 0,
+    "count": 1,
+    "values": [{"value": 1234.56, "start": "2018-05-01T00:00:00Z", "end": "2018-06-01T00:00:00Z"}]
+  }]
+}`
+
 func TestGetAccount(t *testing.T) {
-	t.Skipf("Not yet implemented.")
 	setup()
 
-	_, err := client.GetAccount("full")
+	account, err := client.GetAccount("full")
 	if err != nil {
 		t.Error(err)
 	}
+	if account.ID == "" {
+		t.Errorf("TestGetAccount Failed to get account: ID is ''")
+	}
+}
+
+func TestGetAccountFractionalMetric(t *testing.T) {
+	setup()
+	if server == nil {
+		t.Skip("the account metrics come from the seeded server")
+	}
+	if err := server.SeedAt("/rest/account", json.RawMessage(accountWithCost)); err != nil {
+		t.Fatal(err)
+	}
+
+	account, err := client.GetAccount("full")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(account.Metrics) != 1 || len(account.Metrics[0].Values) != 1 {
+		t.Fatalf("TestGetAccountFractionalMetric Failed to get the metrics: %+v", account.Metrics)
+	}
+	if got := account.Metrics[0].Values[0].Value; got != 1234.56 {
+		t.Errorf("TestGetAccountFractionalMetric cost.total = %v, want 1234.56", got)
+	}
 }
@@ -0,0 +1,405 @@
+// (C) Copyright 2018 Hewlett Packard Enterprise Development LP.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.  IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package onesphere
+
+import "context"
+
+// API is the set of OneSphere calls implemented by Client, grouped by resource.
+// Depend on API, or on the narrower interfaces, to substitute a fake in unit tests.
+type API interface {
+	AccountAPI
+	AppliancesAPI
+	BillingAccountsAPI
+	CatalogsAPI
+	DeploymentsAPI
+	EventsAPI
+	KeyPairsAPI
+	MembershipsAPI
+	MetricsAPI
+	NetworksAPI
+	OnboardingAPI
+	ProjectsAPI
+	ProvidersAPI
+	RatesAPI
+	RegionsAPI
+	RolesAPI
+	ServersAPI
+	ServicesAPI
+	SessionAPI
+	StatusAPI
+	TagsAPI
+	UsersAPI
+	VirtualMachineProfilesAPI
+	VolumesAPI
+	ZonesAPI
+
+	Disconnect() error
+	Close()
+}
+
+var _ API = (*Client)(nil)
+
+// AccountAPI groups the Account calls
+type AccountAPI interface {
+	GetAccount(view string) (Account, error)
+	GetAccountCtx(ctx context.Context, view string) (Account, error)
+}
+
+// AppliancesAPI groups the Appliance calls
+type AppliancesAPI interface {
+	GetAppliances() (ApplianceList, error)
+	GetAppliancesByNameAndRegion(name string, regionUri string) (ApplianceList, error)
+	GetAppliancesByName(name string) (ApplianceList, error)
+	GetAppliancesByRegion(regionUri string) (ApplianceList, error)
+	GetApplianceByID(id string) (Appliance, error)
+	CreateAppliance(applianceRequest ApplianceRequest) (Appliance, error)
+	UpdateAppliance(applianceId string, updates []*PatchOp) (Appliance, error)
+	DeleteAppliance(applianceId string) error
+}
+
+// BillingAccountsAPI groups the BillingAccount calls
+type BillingAccountsAPI interface {
+	GetBillingAccounts(query, view string) (BillingAccountList, error)
+	GetBillingAccountsCtx(ctx context.Context, query, view string) (BillingAccountList, error)
+	ListBillingAccounts(opts BillingAccountListOptions) (BillingAccountList, error)
+	ListBillingAccountsCtx(ctx context.Context, opts BillingAccountListOptions) (BillingAccountList, error)
+	CreateBillingAccount(apiAccessKey, description, directoryUri, enrollmentNumber, name, providerTypeUri string) (BillingAccount, error)
+	CreateBillingAccountCtx(ctx context.Context, apiAccessKey, description, directoryUri, enrollmentNumber, name, providerTypeUri string) (BillingAccount, error)
+	GetBillingAccount(id string) (BillingAccount, error)
+	GetBillingAccountCtx(ctx context.Context, id string) (BillingAccount, error)
+	DeleteBillingAccount(id string) error
+	DeleteBillingAccountCtx(ctx context.Context, id string) error
+	UpdateBillingAccount(id string, patchPayload []*PatchOp) (BillingAccount, error)
+	UpdateBillingAccountCtx(ctx context.Context, id string, patchPayload []*PatchOp) (BillingAccount, error)
+}
+
+// CatalogsAPI groups the Catalog and CatalogType calls
+type CatalogsAPI interface {
+	GetCatalogs(userQuery, view string) (CatalogList, error)
+	GetCatalogByID(id, view string) (Catalog, error)
+	GetCatalogByIDCtx(ctx context.Context, id, view string) (Catalog, error)
+	CreateCatalog(catalogRequest CatalogRequest) (Catalog, error)
+	UpdateCatalog(catalogId string, updates []*PatchOp) (Catalog, error)
+	DeleteCatalog(catalogId string) error
+	DeleteCatalogCtx(ctx context.Context, catalogId string) error
+	ActionCatalog(catalog Catalog, actionType string) error
+	GetCatalogTypes() (CatalogTypeList, error)
+}
+
+// DeploymentsAPI groups the Deployment calls
+type DeploymentsAPI interface {
+	GetDeployments(query string, userQuery string, sort string) (DeploymentList, error)
+	GetDeploymentsCtx(ctx context.Context, query string, userQuery string, sort string) (DeploymentList, error)
+	ListDeployments(opts DeploymentListOptions) (DeploymentList, error)
+	ListDeploymentsCtx(ctx context.Context, opts DeploymentListOptions) (DeploymentList, error)
+	ForEachDeployment(opts DeploymentListOptions, fn func(Deployment) error) error
+	ForEachDeploymentCtx(ctx context.Context, opts DeploymentListOptions, fn func(Deployment) error) error
+	GetDeploymentByID(id string) (Deployment, error)
+	GetDeploymentByIDCtx(ctx context.Context, id string) (Deployment, error)
+	GetDeploymentsByName(name string) (DeploymentList, error)
+	GetDeploymentsByNameCtx(ctx context.Context, name string) (DeploymentList, error)
+	GetDeploymentByName(name string) (Deployment, error)
+	GetDeploymentByNameCtx(ctx context.Context, name string) (Deployment, error)
+	CreateDeployment(deploymentRequest DeploymentRequest) (Deployment, error)
+	CreateDeploymentCtx(ctx context.Context, deploymentRequest DeploymentRequest) (Deployment, error)
+	UpdateDeployment(deploymentId string, updates []*PatchOp) (Deployment, error)
+	UpdateDeploymentCtx(ctx context.Context, deploymentId string, updates []*PatchOp) (Deployment, error)
+	DeleteDeployment(deploymentId string) error
+	DeleteDeploymentCtx(ctx context.Context, deploymentId string) error
+	ActionDeployment(deployment Deployment, actionType string, force bool) error
+	ActionDeploymentCtx(ctx context.Context, deployment Deployment, actionType string, force bool) error
+	GetDeploymentConsole(deployment Deployment) (string, error)
+	GetDeploymentConsoleCtx(ctx context.Context, deployment Deployment) (string, error)
+	GetDeploymentKubeConfig(deployment Deployment) (string, error)
+	GetDeploymentKubeConfigCtx(ctx context.Context, deployment Deployment) (string, error)
+}
+
+// EventsAPI groups the Event calls
+type EventsAPI interface {
+	GetEvents(resourceUri string) (EventList, error)
+	GetEventsCtx(ctx context.Context, resourceUri string) (EventList, error)
+	ListEvents(opts EventListOptions) (EventList, error)
+	ListEventsCtx(ctx context.Context, opts EventListOptions) (EventList, error)
+	ForEachEvent(opts EventListOptions, fn func(Event) error) error
+	ForEachEventCtx(ctx context.Context, opts EventListOptions, fn func(Event) error) error
+}
+
+// KeyPairsAPI groups the KeyPair calls
+type KeyPairsAPI interface {
+	GetKeyPair(regionUri, projectUri string) (KeyPair, error)
+	GetKeyPairCtx(ctx context.Context, regionUri, projectUri string) (KeyPair, error)
+}
+
+// MembershipsAPI groups the Membership and MembershipRole calls
+type MembershipsAPI interface {
+	GetMemberships(query string) (MembershipList, error)
+	GetMembershipsByProject(projectUri string) (MembershipList, error)
+	GetMembershipsByUser(userUri string) (MembershipList, error)
+	GetMembershipsByUserGroup(userGroupUri string) (MembershipList, error)
+	GetMembershipsByRole(roleUri string) (MembershipList, error)
+	GetMembershipByID(id string) (Membership, error)
+	CreateMembership(membershipRequest MembershipRequest) (Membership, error)
+	DeleteMembershipByID(membershipId string) error
+	GetMembershipRoles() (MembershipRoleList, error)
+	GetMembershipRolesCtx(ctx context.Context) (MembershipRoleList, error)
+	GetMembershipRoleByName(name string) (MembershipRole, error)
+	GetMembershipRoleByNameCtx(ctx context.Context, name string) (MembershipRole, error)
+}
+
+// MetricsAPI groups the Metric calls
+type MetricsAPI interface {
+	GetMetrics(resourceUri, category, groupBy, query, name string, periodStart, period string, periodCount int, view string, start, count int) (MetricList, error)
+	GetMetricsCtx(ctx context.Context, resourceUri, category, groupBy, query, name string, periodStart, period string, periodCount int, view string, start, count int) (MetricList, error)
+	ListMetrics(q MetricsQuery) (MetricList, error)
+	ListMetricsCtx(ctx context.Context, q MetricsQuery) (MetricList, error)
+	ForEachMetric(q MetricsQuery, fn func(Metric) error) error
+	ForEachMetricCtx(ctx context.Context, q MetricsQuery, fn func(Metric) error) error
+}
+
+// NetworksAPI groups the Network calls
+type NetworksAPI interface {
+	GetNetworks(query string) (NetworkList, error)
+	ListNetworks(opts NetworkListOptions) (NetworkList, error)
+	ListNetworksCtx(ctx context.Context, opts NetworkListOptions) (NetworkList, error)
+	ForEachNetwork(opts NetworkListOptions, fn func(Network) error) error
+	ForEachNetworkCtx(ctx context.Context, opts NetworkListOptions, fn func(Network) error) error
+	GetNetworkByID(id string) (Network, error)
+	GetNetworkByZoneURI(zoneUri string) (Network, error)
+	GetNetworkByNameAndZoneURI(name, zoneUri string) (Network, error)
+	UpdateNetwork(networkId string, updates []*PatchOp) (Network, error)
+}
+
+// OnboardingAPI groups the Azure onboarding calls
+type OnboardingAPI interface {
+	GetAzureLoginProperties() (AzureLoginProperties, error)
+	GetAzureLoginPropertiesCtx(ctx context.Context) (AzureLoginProperties, error)
+	GetAzureProviderInfo(directoryUri, location string) (AzureProviderInfo, error)
+	GetAzureProviderInfoCtx(ctx context.Context, directoryUri, location string) (AzureProviderInfo, error)
+	GetAzureSubscriptions(directoryUri, location string) (AzureSubscriptionList, error)
+	GetAzureSubscriptionsCtx(ctx context.Context, directoryUri, location string) (AzureSubscriptionList, error)
+	UpdateAzureSubscription(directoryUri, location, subscriptionId string, patchPayload []*PatchOp) (AzureSubscription, error)
+	UpdateAzureSubscriptionCtx(ctx context.Context, directoryUri, location, subscriptionId string, patchPayload []*PatchOp) (AzureSubscription, error)
+}
+
+// ProjectsAPI groups the Project calls
+type ProjectsAPI interface {
+	GetProjects(userQuery, view string) (ProjectList, error)
+	GetProjectsCtx(ctx context.Context, userQuery, view string) (ProjectList, error)
+	ListProjects(opts ProjectListOptions) (ProjectList, error)
+	ListProjectsCtx(ctx context.Context, opts ProjectListOptions) (ProjectList, error)
+	ForEachProject(opts ProjectListOptions, fn func(Project) error) error
+	ForEachProjectCtx(ctx context.Context, opts ProjectListOptions, fn func(Project) error) error
+	GetProjectByID(id, view string) (Project, error)
+	GetProjectByIDCtx(ctx context.Context, id, view string) (Project, error)
+	GetProjectByName(name string) (Project, error)
+	GetProjectByNameCtx(ctx context.Context, name string) (Project, error)
+	CreateProject(projectRequest ProjectRequest) (Project, error)
+	CreateProjectCtx(ctx context.Context, projectRequest ProjectRequest) (Project, error)
+	UpdateProject(projectId string, updates ProjectRequest) (Project, error)
+	UpdateProjectCtx(ctx context.Context, projectId string, updates ProjectRequest) (Project, error)
+	DeleteProject(projectId string) error
+	DeleteProjectCtx(ctx context.Context, projectId string) error
+}
+
+// ProvidersAPI groups the Provider and ProviderType calls
+type ProvidersAPI interface {
+	GetProviders(query string) (ProviderList, error)
+	GetProviderByID(id, view string, discover bool) (Provider, error)
+	CreateProvider(providerRequest ProviderRequest) (Provider, error)
+	UpdateProvider(providerId string, updates []*PatchOp) (Provider, error)
+	DeleteProvider(providerId string) error
+	GetProviderTypes() (ProviderTypeList, error)
+}
+
+// RatesAPI groups the Rate calls
+type RatesAPI interface {
+	GetRates(resourceUri, effectiveForDate, effectiveDate, metricName string, active bool, start, count int) (RateList, error)
+	GetRatesCtx(ctx context.Context, resourceUri, effectiveForDate, effectiveDate, metricName string, active bool, start, count int) (RateList, error)
+	ListRates(opts RateListOptions) (RateList, error)
+	ListRatesCtx(ctx context.Context, opts RateListOptions) (RateList, error)
+	ForEachRate(opts RateListOptions, fn func(Rate) error) error
+	ForEachRateCtx(ctx context.Context, opts RateListOptions, fn func(Rate) error) error
+	GetRate(rateID string) (Rate, error)
+	GetRateCtx(ctx context.Context, rateID string) (Rate, error)
+}
+
+// RegionsAPI groups the Region and RegionConnection calls
+type RegionsAPI interface {
+	GetRegions(query, view string) (RegionList, error)
+	GetRegionsCtx(ctx context.Context, query, view string) (RegionList, error)
+	ListRegions(opts RegionListOptions) (RegionList, error)
+	ListRegionsCtx(ctx context.Context, opts RegionListOptions) (RegionList, error)
+	ForEachRegion(opts RegionListOptions, fn func(Region) error) error
+	ForEachRegionCtx(ctx context.Context, opts RegionListOptions, fn func(Region) error) error
+	GetRegionByID(id, view string, discover bool) (Region, error)
+	GetRegionByIDCtx(ctx context.Context, id, view string, discover bool) (Region, error)
+	GetRegionByName(name string) (Region, error)
+	GetRegionByNameCtx(ctx context.Context, name string) (Region, error)
+	CreateRegion(regionRequest RegionRequest) (Region, error)
+	CreateRegionCtx(ctx context.Context, regionRequest RegionRequest) (Region, error)
+	UpdateRegion(regionId string, updates []*PatchOp) (Region, error)
+	UpdateRegionCtx(ctx context.Context, regionId string, updates []*PatchOp) (Region, error)
+	DeleteRegion(regionId string) error
+	DeleteRegionCtx(ctx context.Context, regionId string) error
+	GetRegionConnection(regionId string) (RegionConnection, error)
+	GetRegionConnectionCtx(ctx context.Context, regionId string) (RegionConnection, error)
+	CreateRegionConnection(regionId string, regionConnectionRequest RegionConnectionRequest) (RegionConnection, error)
+	CreateRegionConnectionCtx(ctx context.Context, regionId string, regionConnectionRequest RegionConnectionRequest) (RegionConnection, error)
+	DeleteRegionConnection(regionId string) error
+	DeleteRegionConnectionCtx(ctx context.Context, regionId string) error
+	GetRegionConnectorImage(regionId string) (string, error)
+	GetRegionConnectorImageCtx(ctx context.Context, regionId string) (string, error)
+}
+
+// RolesAPI groups the Role calls
+type RolesAPI interface {
+	GetRoles() (RoleList, error)
+	GetRolesCtx(ctx context.Context) (RoleList, error)
+}
+
+// ServersAPI groups the Server calls
+type ServersAPI interface {
+	GetServers(regionUri, applianceUri, zoneUri string) (ServerList, error)
+	GetServersCtx(ctx context.Context, regionUri, applianceUri, zoneUri string) (ServerList, error)
+	ListServers(opts ServerListOptions) (ServerList, error)
+	ListServersCtx(ctx context.Context, opts ServerListOptions) (ServerList, error)
+	CreateServer(server *Server) (Server, error)
+	CreateServerCtx(ctx context.Context, server *Server) (Server, error)
+	DeleteServer(serverID string, force bool) error
+	DeleteServerCtx(ctx context.Context, serverID string, force bool) error
+	GetServer(serverID string) (Server, error)
+	GetServerCtx(ctx context.Context, serverID string) (Server, error)
+	UpdateServer(serverID string, patchPayload []*PatchOp) (Server, error)
+	UpdateServerCtx(ctx context.Context, serverID string, patchPayload []*PatchOp) (Server, error)
+}
+
+// ServicesAPI groups the Service and ServiceType calls
+type ServicesAPI interface {
+	GetServices(query, userQuery string) (ServiceList, error)
+	GetServiceByID(id string) (Service, error)
+	GetServiceByName(name string) (Service, error)
+	GetServiceTypes() (ServiceTypeList, error)
+	GetServiceTypeByID(id string) (ServiceType, error)
+}
+
+// SessionAPI groups the Session calls
+type SessionAPI interface {
+	GetSession(view string) (Session, error)
+	GetSessionCtx(ctx context.Context, view string) (Session, error)
+	GetSessionIdp(userName string) (SessionIdp, error)
+	GetSessionIdpCtx(ctx context.Context, userName string) (SessionIdp, error)
+}
+
+// StatusAPI groups the Status and Versions calls
+type StatusAPI interface {
+	GetStatus() (Status, error)
+	GetStatusCtx(ctx context.Context) (Status, error)
+	GetVersions() (Versions, error)
+	GetVersionsCtx(ctx context.Context) (Versions, error)
+}
+
+// TagsAPI groups the Tag and TagKey calls
+type TagsAPI interface {
+	GetTags(view string) (TagList, error)
+	GetTagByID(id, view string) (Tag, error)
+	CreateTag(tagRequest TagRequest) (Tag, error)
+	DeleteTag(tagId string) error
+	GetTagKeys(view string) (TagKeyList, error)
+	GetTagKeyByID(id, view string) (TagKey, error)
+	CreateTagKey(tagKeyRequest TagKeyRequest) (TagKey, error)
+	DeleteTagKey(tagKeyId string) error
+}
+
+// UsersAPI groups the User calls
+type UsersAPI interface {
+	GetUsers(userQuery string) (UserList, error)
+	ListUsers(opts UserListOptions) (UserList, error)
+	ListUsersCtx(ctx context.Context, opts UserListOptions) (UserList, error)
+	ForEachUser(opts UserListOptions, fn func(User) error) error
+	ForEachUserCtx(ctx context.Context, opts UserListOptions, fn func(User) error) error
+	GetUserByID(id string) (User, error)
+	GetUserByName(name string) (User, error)
+	GetUserByNameCtx(ctx context.Context, name string) (User, error)
+	CreateUser(userRequest UserRequest) (User, error)
+	UpdateUser(userId string, updates UserRequest) (User, error)
+	DeleteUser(userId string) error
+}
+
+// VirtualMachineProfilesAPI groups the VirtualMachineProfile calls
+type VirtualMachineProfilesAPI interface {
+	GetVirtualMachineProfiles(query string) (VirtualMachineProfileList, error)
+	GetVirtualMachineProfilesByServiceURI(serviceURI string) (VirtualMachineProfileList, error)
+	GetVirtualMachineProfilesByZoneURI(zoneURI string) (VirtualMachineProfileList, error)
+	GetVirtualMachineProfilesByServiceAndZoneURI(serviceURI, zoneURI string) (VirtualMachineProfileList, error)
+	GetVirtualMachineProfileByID(id string) (VirtualMachineProfile, error)
+}
+
+// VolumesAPI groups the Volume calls
+type VolumesAPI interface {
+	GetVolumes(query, view string) (VolumeList, error)
+	GetVolumesCtx(ctx context.Context, query, view string) (VolumeList, error)
+	ListVolumes(opts VolumeListOptions) (VolumeList, error)
+	ListVolumesCtx(ctx context.Context, opts VolumeListOptions) (VolumeList, error)
+	CreateVolume(name string, sizeGiB int, zoneUri, projectUri string) (Volume, error)
+	CreateVolumeCtx(ctx context.Context, name string, sizeGiB int, zoneUri, projectUri string) (Volume, error)
+	GetVolume(volumeID string) (Volume, error)
+	GetVolumeCtx(ctx context.Context, volumeID string) (Volume, error)
+	UpdateVolume(volumeID, name string, sizeGiB int) (Volume, error)
+	UpdateVolumeCtx(ctx context.Context, volumeID, name string, sizeGiB int) (Volume, error)
+	DeleteVolume(volumeID string) error
+	DeleteVolumeCtx(ctx context.Context, volumeID string) error
+}
+
+// ZonesAPI groups the Zone, ZoneConnection and ZoneType calls
+type ZonesAPI interface {
+	GetZones(query, regionUri, providerUri, applianceUri, view string) (ZoneList, error)
+	GetZonesCtx(ctx context.Context, query, regionUri, providerUri, applianceUri, view string) (ZoneList, error)
+	ListZones(opts ZoneListOptions) (ZoneList, error)
+	ListZonesCtx(ctx context.Context, opts ZoneListOptions) (ZoneList, error)
+	ForEachZone(opts ZoneListOptions, fn func(Zone) error) error
+	ForEachZoneCtx(ctx context.Context, opts ZoneListOptions, fn func(Zone) error) error
+	GetZoneByID(id string) (Zone, error)
+	GetZoneByIDCtx(ctx context.Context, id string) (Zone, error)
+	GetZoneByName(name string) (Zone, error)
+	GetZoneByNameCtx(ctx context.Context, name string) (Zone, error)
+	GetZoneApplianceImage(id string) (string, error)
+	GetZoneApplianceImageCtx(ctx context.Context, id string) (string, error)
+	GetZoneTaskStatus(id string) (string, error)
+	GetZoneTaskStatusCtx(ctx context.Context, id string) (string, error)
+	GetZoneConnections(id, uuid string) (ConnectionList, error)
+	GetZoneConnectionsCtx(ctx context.Context, id, uuid string) (ConnectionList, error)
+	CreateZone(zoneRequest ZoneRequest) (Zone, error)
+	CreateZoneCtx(ctx context.Context, zoneRequest ZoneRequest) (Zone, error)
+	CreateZoneConnection(id string, connectionRequest ConnectionRequest) (Connection, error)
+	CreateZoneConnectionCtx(ctx context.Context, id string, connectionRequest ConnectionRequest) (Connection, error)
+	UpdateZone(zoneId string, updates []*PatchOp) (Zone, error)
+	UpdateZoneCtx(ctx context.Context, zoneId string, updates []*PatchOp) (Zone, error)
+	UpdateZoneConnection(zoneId, connectionUuid string, updates []*PatchOp) (Connection, error)
+	UpdateZoneConnectionCtx(ctx context.Context, zoneId, connectionUuid string, updates []*PatchOp) (Connection, error)
+	DeleteZone(zoneId string) error
+	DeleteZoneCtx(ctx context.Context, zoneId string) error
+	DeleteZoneConnection(zoneId, connectionUuid string) error
+	DeleteZoneConnectionCtx(ctx context.Context, zoneId, connectionUuid string) error
+	ActionZone(zoneId string, action ZoneAction) error
+	ActionZoneCtx(ctx context.Context, zoneId string, action ZoneAction) error
+	GetZoneTypes() (ZoneTypeList, error)
+	GetZoneTypeResourceProfiles(zoneTypeId string) (ZoneTypeResourceProfileList, error)
+}
@@ -182,10 +182,10 @@ func (c *Client) DeleteAppliance(applianceId string) error {
 
 	var uri = "/rest/appliances/" + applianceId
 
-	response, err := c.RestAPICall(rest.DELETE, uri, nil, nil)
+	_, err := c.RestAPICall(rest.DELETE, uri, nil, nil)
 
 	if err != nil {
-		return apiResponseError(response, err)
+		return err
 	}
 
 	return nil
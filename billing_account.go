@@ -0,0 +1,204 @@
+// (C) Copyright 2018 Hewlett Packard Enterprise Development LP.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.  IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package onesphere
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/HewlettPackard/hpe-onesphere-go/rest"
+)
+
+type BillingAccount struct {
+	ID               string      `json:"id"`
+	Name             string      `json:"name"`
+	URI              string      `json:"uri"`
+	Status           string      `json:"status"`
+	State            string      `json:"state"`
+	ProviderTypeURI  string      `json:"providerTypeUri"`
+	EnrollmentNumber string      `json:"enrollmentNumber"`
+	DirectoryURI     string      `json:"directoryUri"`
+	Providers        []*Provider `json:"providers"`
+	Created          time.Time   `json:"created"`
+	Modified         time.Time   `json:"modified"`
+}
+
+type BillingAccountList struct {
+	Total   int              `json:"total"`
+	Start   int              `json:"start"`
+	Count   int              `json:"count"`
+	Members []BillingAccount `json:"members"`
+}
+
+// GetBillingAccounts returns BillingAccountList with optional query and view
+// example view: "full"
+func (c *Client) GetBillingAccounts(query, view string) (BillingAccountList, error) {
+	return c.GetBillingAccountsCtx(context.Background(), query, view)
+}
+
+// GetBillingAccountsCtx is like GetBillingAccounts but uses ctx for the request
+func (c *Client) GetBillingAccountsCtx(ctx context.Context, query, view string) (BillingAccountList, error) {
+	return c.ListBillingAccountsCtx(ctx, BillingAccountListOptions{
+		Query:       query,
+		ListOptions: ListOptions{View: View(view)},
+	})
+}
+
+// ListBillingAccounts returns the BillingAccountList selected by opts
+func (c *Client) ListBillingAccounts(opts BillingAccountListOptions) (BillingAccountList, error) {
+	return c.ListBillingAccountsCtx(context.Background(), opts)
+}
+
+// ListBillingAccountsCtx is like ListBillingAccounts but uses ctx for the request
+func (c *Client) ListBillingAccountsCtx(ctx context.Context, opts BillingAccountListOptions) (BillingAccountList, error) {
+	var (
+		uri             = "/rest/billing-accounts"
+		queryParams     = encodeParams(opts)
+		billingAccounts BillingAccountList
+	)
+
+	response, err := c.RestAPICallCtx(ctx, rest.GET, uri, queryParams, nil)
+
+	if err != nil {
+		return billingAccounts, err
+	}
+
+	if err := json.Unmarshal([]byte(response), &billingAccounts); err != nil {
+		return billingAccounts, apiResponseError(response, err)
+	}
+
+	return billingAccounts, err
+}
+
+// CreateBillingAccount creates a BillingAccount and returns it
+func (c *Client) CreateBillingAccount(apiAccessKey, description, directoryUri, enrollmentNumber, name, providerTypeUri string) (BillingAccount, error) {
+	return c.CreateBillingAccountCtx(context.Background(), apiAccessKey, description, directoryUri, enrollmentNumber, name, providerTypeUri)
+}
+
+// CreateBillingAccountCtx is like CreateBillingAccount but uses ctx for the request
+func (c *Client) CreateBillingAccountCtx(ctx context.Context, apiAccessKey, description, directoryUri, enrollmentNumber, name, providerTypeUri string) (BillingAccount, error) {
+	var (
+		uri    = "/rest/billing-accounts"
+		values = map[string]string{
+			"apiAccessKey":     apiAccessKey,
+			"description":      description,
+			"directoryUri":     directoryUri,
+			"enrollmentNumber": enrollmentNumber,
+			"name":             name,
+			"providerTypeUri":  providerTypeUri,
+		}
+		billingAccount BillingAccount
+	)
+
+	response, err := c.RestAPICallCtx(ctx, rest.POST, uri, nil, values)
+
+	if err != nil {
+		return billingAccount, err
+	}
+
+	if err := json.Unmarshal([]byte(response), &billingAccount); err != nil {
+		return billingAccount, apiResponseError(response, err)
+	}
+
+	return billingAccount, err
+}
+
+// GetBillingAccount returns a BillingAccount by id
+func (c *Client) GetBillingAccount(id string) (BillingAccount, error) {
+	return c.GetBillingAccountCtx(context.Background(), id)
+}
+
+// GetBillingAccountCtx is like GetBillingAccount but uses ctx for the request
+func (c *Client) GetBillingAccountCtx(ctx context.Context, id string) (BillingAccount, error) {
+	var (
+		uri            = "/rest/billing-accounts/" + id
+		billingAccount BillingAccount
+	)
+
+	if id == "" {
+		return billingAccount, fmt.Errorf("id must not be empty")
+	}
+
+	response, err := c.RestAPICallCtx(ctx, rest.GET, uri, nil, nil)
+
+	if err != nil {
+		return billingAccount, err
+	}
+
+	if err := json.Unmarshal([]byte(response), &billingAccount); err != nil {
+		return billingAccount, apiResponseError(response, err)
+	}
+
+	return billingAccount, err
+}
+
+// DeleteBillingAccount deletes a BillingAccount by id
+func (c *Client) DeleteBillingAccount(id string) error {
+	return c.DeleteBillingAccountCtx(context.Background(), id)
+}
+
+// DeleteBillingAccountCtx is like DeleteBillingAccount but uses ctx for the request
+func (c *Client) DeleteBillingAccountCtx(ctx context.Context, id string) error {
+	if id == "" {
+		return fmt.Errorf("id must not be empty")
+	}
+
+	_, err := c.RestAPICallCtx(ctx, rest.DELETE, "/rest/billing-accounts/"+id, nil, nil)
+
+	return err
+}
+
+// UpdateBillingAccount sends PATCH with Op: "add|replace|remove"
+func (c *Client) UpdateBillingAccount(id string, patchPayload []*PatchOp) (BillingAccount, error) {
+	return c.UpdateBillingAccountCtx(context.Background(), id, patchPayload)
+}
+
+// UpdateBillingAccountCtx is like UpdateBillingAccount but uses ctx for the request
+func (c *Client) UpdateBillingAccountCtx(ctx context.Context, id string, patchPayload []*PatchOp) (BillingAccount, error) {
+	var billingAccount BillingAccount
+
+	validOps := []string{"add", "replace", "remove"}
+	for _, pb := range patchPayload {
+		opIsValid := false
+		for _, validOp := range validOps {
+			if pb.Op == validOp {
+				opIsValid = true
+			}
+		}
+		if !opIsValid {
+			return billingAccount, fmt.Errorf("UpdateBillingAccount received invalid Op in patchBodies.\nReceived Op: %s\nValid Ops: %v\n", pb.Op, validOps)
+		}
+	}
+
+	response, err := c.RestAPICallPatchCtx(ctx, "/rest/billing-accounts/"+id, nil, patchPayload)
+
+	if err != nil {
+		return billingAccount, err
+	}
+
+	if err := json.Unmarshal([]byte(response), &billingAccount); err != nil {
+		return billingAccount, apiResponseError(response, err)
+	}
+
+	return billingAccount, err
+}
@@ -21,6 +21,7 @@
 package onesphere
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/HewlettPackard/hpe-onesphere-go/rest"
@@ -94,6 +95,11 @@ func (c *Client) GetCatalogs(userQuery, view string) (CatalogList, error) {
 // GetCatalogByID returns an Catalog by id
 // example view: "full"
 func (c *Client) GetCatalogByID(id, view string) (Catalog, error) {
+	return c.GetCatalogByIDCtx(context.Background(), id, view)
+}
+
+// GetCatalogByIDCtx is like GetCatalogByID but uses ctx for the request
+func (c *Client) GetCatalogByIDCtx(ctx context.Context, id, view string) (Catalog, error) {
 	var (
 		uri         = "/rest/catalogs/" + id
 		queryParams = createQuery(&map[string]string{
@@ -106,7 +112,7 @@ func (c *Client) GetCatalogByID(id, view string) (Catalog, error) {
 		return catalog, fmt.Errorf("id must not be empty")
 	}
 
-	response, err := c.RestAPICall(rest.GET, uri, queryParams, nil)
+	response, err := c.RestAPICallCtx(ctx, rest.GET, uri, queryParams, nil)
 
 	if err != nil {
 		return catalog, err
@@ -220,11 +226,31 @@ func (c *Client) UpdateCatalog(catalogId string, updates []*PatchOp) (Catalog, e
 }
 
 // DeleteCatalog Deletes Catalog
+// A protected catalog is not deleted and a ProtectedError is returned.
 func (c *Client) DeleteCatalog(catalogId string) error {
-	return c.notImplementedError(rest.DELETE, "/rest/catalogs/"+catalogId, "catalogs")
+	return c.DeleteCatalogCtx(context.Background(), catalogId)
+}
+
+// DeleteCatalogCtx is like DeleteCatalog but uses ctx for the requests
+func (c *Client) DeleteCatalogCtx(ctx context.Context, catalogId string) error {
+	if catalogId == "" {
+		return fmt.Errorf("catalogId must be non-empty")
+	}
+
+	catalog, err := c.GetCatalogByIDCtx(ctx, catalogId, "")
+	if err != nil {
+		return err
+	}
+	if catalog.Protected {
+		return &ProtectedError{Resource: "catalog", ID: catalogId}
+	}
+
+	_, err = c.RestAPICallCtx(ctx, rest.DELETE, "/rest/catalogs/"+catalogId, nil, nil)
+
+	return err
 }
 
-// ActionCatalog Perform an Action on Catalog
+// ActionCatalog Perform an Action on Catalog through /rest/catalogs/{id}/actions
 // example actionType: "refresh"
 func (c *Client) ActionCatalog(catalog Catalog, actionType string) error {
 	if catalog.ID == "" {
@@ -232,16 +258,16 @@ func (c *Client) ActionCatalog(catalog Catalog, actionType string) error {
 	}
 
 	var (
-		uri    = "/rest/deployments/" + catalog.ID + "/actions"
+		uri    = "/rest/catalogs/" + catalog.ID + "/actions"
 		values = createQuery(&map[string]string{
 			"type": actionType,
 		})
 	)
 
-	response, err := c.RestAPICall(rest.POST, uri, nil, values)
+	_, err := c.RestAPICall(rest.POST, uri, nil, values)
 
 	if err != nil {
-		return apiResponseError(response, err)
+		return err
 	}
 
 	return nil
@@ -1,6 +1,12 @@
 package onesphere
 
-import "testing"
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
 
 func TestGetCatalogs(t *testing.T) {
 	setup()
@@ -58,7 +64,16 @@ func TestDeleteCatalog(t *testing.T) {
 	setup()
 
 	if err := client.DeleteCatalog("2"); err != nil {
-		t.Skip(err)
+		t.Error(err)
+	}
+}
+
+func TestDeleteProtectedCatalog(t *testing.T) {
+	server, c := newWaitClient(t, time.Second)
+	server.Seed("/rest/catalogs", Catalog{ID: "c1", Protected: true})
+
+	if err := c.DeleteCatalog("c1"); !IsProtected(err) || err.Error() != "onesphere: catalog c1 is protected" {
+		t.Errorf("TestDeleteProtectedCatalog expected a ProtectedError, got %v", err)
 	}
 }
 
@@ -72,3 +87,24 @@ func TestActionCatalog(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+func TestActionCatalogEndpoint(t *testing.T) {
+	var path, actionType string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Type string `json:"type"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		path, actionType = r.Method+" "+r.URL.Path, body.Type
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer ts.Close()
+
+	c := &Client{Auth: &Auth{HostURL: ts.URL, Token: "token"}}
+	if err := c.ActionCatalog(Catalog{ID: "c1"}, "refresh"); err != nil {
+		t.Fatalf("TestActionCatalogEndpoint ActionCatalog failed: %v", err)
+	}
+	if path != "POST /rest/catalogs/c1/actions" || actionType != "refresh" {
+		t.Errorf("TestActionCatalogEndpoint expected a refresh posted to /rest/catalogs/c1/actions, got %q %q", path, actionType)
+	}
+}
@@ -0,0 +1,137 @@
+// (C) Copyright 2018 Hewlett Packard Enterprise Development LP.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.  IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package onesphere
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"runtime"
+)
+
+// Environment variables read by EnvCredentials
+const (
+	EnvUserName = "ONESPHERE_USER"
+	EnvPassword = "ONESPHERE_PASSWORD"
+	EnvToken    = "ONESPHERE_TOKEN"
+)
+
+// Credentials open a OneSphere session.
+// UserName and Password are used to log in when set, otherwise Token is
+// used as an existing session token.
+type Credentials struct {
+	UserName string `json:"userName"`
+	Password string `json:"password"`
+	Token    string `json:"token"`
+}
+
+// CredentialProvider supplies the Credentials used by ConnectWithCredentials.
+// Credentials is called again each time the session must be re-established.
+type CredentialProvider interface {
+	Credentials(ctx context.Context) (Credentials, error)
+}
+
+// CredentialProviderFunc adapts a function to the CredentialProvider interface
+type CredentialProviderFunc func(ctx context.Context) (Credentials, error)
+
+// Credentials calls f(ctx)
+func (f CredentialProviderFunc) Credentials(ctx context.Context) (Credentials, error) {
+	return f(ctx)
+}
+
+// StaticCredentials always logs in as user with password
+func StaticCredentials(user, password string) CredentialProvider {
+	return CredentialProviderFunc(func(ctx context.Context) (Credentials, error) {
+		return Credentials{UserName: user, Password: password}, nil
+	})
+}
+
+// TokenCredentials uses token as the session token without logging in
+func TokenCredentials(token string) CredentialProvider {
+	return CredentialProviderFunc(func(ctx context.Context) (Credentials, error) {
+		return Credentials{Token: token}, nil
+	})
+}
+
+// EnvCredentials reads ONESPHERE_USER and ONESPHERE_PASSWORD, or ONESPHERE_TOKEN,
+// from the environment each time credentials are needed
+func EnvCredentials() CredentialProvider {
+	return CredentialProviderFunc(func(ctx context.Context) (Credentials, error) {
+		creds := Credentials{
+			UserName: os.Getenv(EnvUserName),
+			Password: os.Getenv(EnvPassword),
+			Token:    os.Getenv(EnvToken),
+		}
+		if creds.UserName == "" && creds.Token == "" {
+			return creds, fmt.Errorf("neither %s nor %s is set", EnvUserName, EnvToken)
+		}
+		return creds, nil
+	})
+}
+
+// FileCredentials reads Credentials from a JSON file each time credentials are needed
+//
+// example file:
+//
+//	{
+//		"userName": "jane@example.com",
+//		"password": "secret"
+//	}
+//
+// The file must not be readable or writable by group or others (e.g. chmod 600),
+// otherwise it is rejected.
+func FileCredentials(path string) CredentialProvider {
+	return CredentialProviderFunc(func(ctx context.Context) (Credentials, error) {
+		var creds Credentials
+
+		if err := checkPrivateFile(path); err != nil {
+			return creds, err
+		}
+
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return creds, err
+		}
+
+		if err := json.Unmarshal(data, &creds); err != nil {
+			return creds, fmt.Errorf("invalid credentials file %s: %w", path, err)
+		}
+
+		return creds, nil
+	})
+}
+
+// checkPrivateFile returns an error when path is accessible by group or others.
+// Windows does not expose these permission bits, so the check is skipped there.
+func checkPrivateFile(path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+
+	if runtime.GOOS != "windows" && info.Mode().Perm()&0077 != 0 {
+		return fmt.Errorf("%s is accessible by group or others (mode %04o), restrict it to its owner with chmod 600", path, info.Mode().Perm())
+	}
+
+	return nil
+}
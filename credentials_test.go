@@ -0,0 +1,109 @@
+package onesphere
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+)
+
+func TestFileCredentialsPermissions(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "credentials.json")
+	if err := ioutil.WriteFile(path, []byte(`{"userName":"jane","password":"secret"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := FileCredentials(path).Credentials(context.Background()); err == nil {
+		t.Errorf("TestFileCredentialsPermissions a world readable file should be rejected")
+	}
+
+	if err := os.Chmod(path, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	creds, err := FileCredentials(path).Credentials(context.Background())
+	if err != nil {
+		t.Fatalf("TestFileCredentialsPermissions unexpected error: %v", err)
+	}
+	if creds.UserName != "jane" || creds.Password != "secret" {
+		t.Errorf("TestFileCredentialsPermissions unexpected credentials: %+v", creds)
+	}
+}
+
+func TestEnvCredentials(t *testing.T) {
+	t.Setenv(EnvUserName, "")
+	t.Setenv(EnvToken, "")
+	if _, err := EnvCredentials().Credentials(context.Background()); err == nil {
+		t.Errorf("TestEnvCredentials should fail when neither user nor token is set")
+	}
+
+	t.Setenv(EnvUserName, "jane")
+	t.Setenv(EnvPassword, "secret")
+	creds, err := EnvCredentials().Credentials(context.Background())
+	if err != nil || creds.UserName != "jane" || creds.Password != "secret" {
+		t.Errorf("TestEnvCredentials unexpected credentials %+v, error %v", creds, err)
+	}
+}
+
+func TestNewClientFromToken(t *testing.T) {
+	var authorization string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		w.Write([]byte(`{"id":"1"}`))
+	}))
+	defer ts.Close()
+
+	c, err := NewClientFromToken(ts.URL, "minted-elsewhere")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.GetZoneByID("1"); err != nil {
+		t.Fatal(err)
+	}
+	if authorization != "minted-elsewhere" {
+		t.Errorf("TestNewClientFromToken expected the token to be sent, got %q", authorization)
+	}
+}
+
+func TestConnectWithCredentialsAsksProviderOnReauthenticate(t *testing.T) {
+	ts, logins, expire := newExpiringSessionServer()
+	defer ts.Close()
+
+	var asked int32
+	provider := CredentialProviderFunc(func(ctx context.Context) (Credentials, error) {
+		atomic.AddInt32(&asked, 1)
+		return Credentials{UserName: "jane", Password: "secret"}, nil
+	})
+
+	c, err := ConnectWithCredentials(ts.URL, provider)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expire()
+
+	if _, err := c.GetZoneByID("1"); err != nil {
+		t.Errorf("TestConnectWithCredentialsAsksProviderOnReauthenticate GetZoneByID failed: %v", err)
+	}
+	if a, l := atomic.LoadInt32(&asked), atomic.LoadInt32(logins); a != 2 || l != 2 {
+		t.Errorf("TestConnectWithCredentialsAsksProviderOnReauthenticate expected 2 provider calls and logins, got %d and %d", a, l)
+	}
+}
+
+func TestTokenCredentialsKeepUnauthorizedError(t *testing.T) {
+	ts, _, expire := newExpiringSessionServer()
+	defer ts.Close()
+
+	c, err := ConnectWithCredentials(ts.URL, TokenCredentials("token-0"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expire()
+
+	if _, err := c.GetZoneByID("1"); !IsUnauthorized(err) {
+		t.Errorf("TestTokenCredentialsKeepUnauthorizedError expected an unauthorized APIError, got %v", err)
+	}
+}
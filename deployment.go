@@ -21,9 +21,11 @@
 package onesphere
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 
+	"github.com/HewlettPackard/hpe-onesphere-go/query"
 	"github.com/HewlettPackard/hpe-onesphere-go/rest"
 	"github.com/HewlettPackard/hpe-onesphere-go/utils"
 )
@@ -100,17 +102,33 @@ type DeploymentList struct {
 // example query: "zoneUri EQ /rest/zones/xxxx"
 // example userQuery: "ubuntu"
 func (c *Client) GetDeployments(query string, userQuery string, sort string) (DeploymentList, error) {
+	return c.GetDeploymentsCtx(context.Background(), query, userQuery, sort)
+}
+
+// GetDeploymentsCtx is like GetDeployments but uses ctx for the request
+func (c *Client) GetDeploymentsCtx(ctx context.Context, query string, userQuery string, sort string) (DeploymentList, error) {
+	return c.ListDeploymentsCtx(ctx, DeploymentListOptions{
+		Query:       query,
+		UserQuery:   userQuery,
+		ListOptions: ListOptions{Sort: sort},
+	})
+}
+
+// ListDeployments returns the DeploymentList selected by opts,
+// a single page unless opts.Count covers every deployment, see ForEachDeployment
+func (c *Client) ListDeployments(opts DeploymentListOptions) (DeploymentList, error) {
+	return c.ListDeploymentsCtx(context.Background(), opts)
+}
+
+// ListDeploymentsCtx is like ListDeployments but uses ctx for the request
+func (c *Client) ListDeploymentsCtx(ctx context.Context, opts DeploymentListOptions) (DeploymentList, error) {
 	var (
 		uri         = "/rest/deployments"
-		queryParams = createQuery(&map[string]string{
-			"query":     query,
-			"userQuery": userQuery,
-			"sort":      sort,
-		})
+		queryParams = encodeParams(opts)
 		deployments DeploymentList
 	)
 
-	response, err := c.RestAPICall(rest.GET, uri, queryParams, nil)
+	response, err := c.RestAPICallCtx(ctx, rest.GET, uri, queryParams, nil)
 
 	if err != nil {
 		return deployments, err
@@ -123,8 +141,39 @@ func (c *Client) GetDeployments(query string, userQuery string, sort string) (De
 	return deployments, nil
 }
 
+// ForEachDeployment calls fn for every Deployment selected by opts, requesting further
+// pages until all deployments have been visited. opts.Start and opts.Count set the
+// first offset and the page size.
+// It stops at the first error returned by fn, return StopIteration to stop without error.
+func (c *Client) ForEachDeployment(opts DeploymentListOptions, fn func(Deployment) error) error {
+	return c.ForEachDeploymentCtx(context.Background(), opts, fn)
+}
+
+// ForEachDeploymentCtx is like ForEachDeployment but uses ctx for the requests
+func (c *Client) ForEachDeploymentCtx(ctx context.Context, opts DeploymentListOptions, fn func(Deployment) error) error {
+	return c.forEachPage(ctx, "/rest/deployments", encodeParams(opts), func(response string) (page, error) {
+		var deployments DeploymentList
+		if err := json.Unmarshal([]byte(response), &deployments); err != nil {
+			return page{}, apiResponseError(response, err)
+		}
+
+		for _, deployment := range deployments.Members {
+			if err := fn(deployment); err != nil {
+				return page{}, err
+			}
+		}
+
+		return page{total: deployments.Total, members: len(deployments.Members), nextPageURI: string(deployments.NextPageURI)}, nil
+	})
+}
+
 // GetDeploymentByID Retrieve Deployment by ID
 func (c *Client) GetDeploymentByID(id string) (Deployment, error) {
+	return c.GetDeploymentByIDCtx(context.Background(), id)
+}
+
+// GetDeploymentByIDCtx is like GetDeploymentByID but uses ctx for the request
+func (c *Client) GetDeploymentByIDCtx(ctx context.Context, id string) (Deployment, error) {
 	var (
 		uri        = "/rest/deployments/" + id
 		deployment Deployment
@@ -134,7 +183,7 @@ func (c *Client) GetDeploymentByID(id string) (Deployment, error) {
 		return deployment, fmt.Errorf("id must not be empty")
 	}
 
-	response, err := c.RestAPICall(rest.GET, uri, nil, nil)
+	response, err := c.RestAPICallCtx(ctx, rest.GET, uri, nil, nil)
 
 	if err != nil {
 		return deployment, err
@@ -148,32 +197,61 @@ func (c *Client) GetDeploymentByID(id string) (Deployment, error) {
 }
 
 func (c *Client) GetDeploymentsByName(name string) (DeploymentList, error) {
-	return c.GetDeployments("", fmt.Sprintf("'%s'", name), "name:asc")
+	return c.GetDeploymentsByNameCtx(context.Background(), name)
+}
+
+// GetDeploymentsByNameCtx is like GetDeploymentsByName but uses ctx for the request
+func (c *Client) GetDeploymentsByNameCtx(ctx context.Context, name string) (DeploymentList, error) {
+	return c.GetDeploymentsCtx(ctx, "", fmt.Sprintf("'%s'", name), "name:asc")
 }
 
-// GetDeploymentByName returns first member of GetDeploymentsByName
+// GetDeploymentByName returns the Deployment named name
+// returns a NameNotFoundError or an AmbiguousNameError unless exactly one deployment has the name
 func (c *Client) GetDeploymentByName(name string) (Deployment, error) {
-	var deployment Deployment
+	return c.GetDeploymentByNameCtx(context.Background(), name)
+}
 
-	deployments, err := c.GetDeploymentsByName(name)
+// GetDeploymentByNameCtx is like GetDeploymentByName but uses ctx for the request
+func (c *Client) GetDeploymentByNameCtx(ctx context.Context, name string) (Deployment, error) {
+	var (
+		deployment Deployment
+		matches    = newNameMatches("deployment", name)
+	)
+
+	if name == "" {
+		return deployment, fmt.Errorf("name must not be empty")
+	}
 
-	for _, d := range deployments.Members {
-		if d.Name == name {
-			deployment = d
+	err := c.ForEachDeploymentCtx(ctx, DeploymentListOptions{Query: query.Eq("name", name).String()}, func(candidate Deployment) error {
+		if matches.add(candidate.Name, candidate.URI) {
+			deployment = candidate
 		}
+		return nil
+	})
+	if err != nil {
+		return Deployment{}, err
 	}
 
-	return deployment, err
+	if err := matches.err(); err != nil {
+		return Deployment{}, err
+	}
+
+	return deployment, nil
 }
 
 // CreateDeployment Creates Deployment and returns updated deployment
 func (c *Client) CreateDeployment(deploymentRequest DeploymentRequest) (Deployment, error) {
+	return c.CreateDeploymentCtx(context.Background(), deploymentRequest)
+}
+
+// CreateDeploymentCtx is like CreateDeployment but uses ctx for the request
+func (c *Client) CreateDeploymentCtx(ctx context.Context, deploymentRequest DeploymentRequest) (Deployment, error) {
 	var (
 		uri        = "/rest/deployments/"
 		deployment Deployment
 	)
 
-	response, err := c.RestAPICall(rest.POST, uri, nil, deploymentRequest)
+	response, err := c.RestAPICallCtx(ctx, rest.POST, uri, nil, deploymentRequest)
 
 	if err != nil {
 		return deployment, err
@@ -188,6 +266,11 @@ func (c *Client) CreateDeployment(deploymentRequest DeploymentRequest) (Deployme
 
 // UpdateDeployment using []*PatchOp returns updated deployment on success
 func (c *Client) UpdateDeployment(deploymentId string, updates []*PatchOp) (Deployment, error) {
+	return c.UpdateDeploymentCtx(context.Background(), deploymentId, updates)
+}
+
+// UpdateDeploymentCtx is like UpdateDeployment but uses ctx for the request
+func (c *Client) UpdateDeploymentCtx(ctx context.Context, deploymentId string, updates []*PatchOp) (Deployment, error) {
 	var (
 		uri               = "/rest/deployments/" + deploymentId
 		updatedDeployment Deployment
@@ -197,7 +280,7 @@ func (c *Client) UpdateDeployment(deploymentId string, updates []*PatchOp) (Depl
 		return updatedDeployment, fmt.Errorf("Deployment must have a non-empty ID")
 	}
 
-	response, err := c.RestAPICallPatch(uri, nil, updates)
+	response, err := c.RestAPICallPatchCtx(ctx, uri, nil, updates)
 
 	if err != nil {
 		return updatedDeployment, err
@@ -212,16 +295,21 @@ func (c *Client) UpdateDeployment(deploymentId string, updates []*PatchOp) (Depl
 
 // DeleteDeployment Deletes Deployment
 func (c *Client) DeleteDeployment(deploymentId string) error {
+	return c.DeleteDeploymentCtx(context.Background(), deploymentId)
+}
+
+// DeleteDeploymentCtx is like DeleteDeployment but uses ctx for the request
+func (c *Client) DeleteDeploymentCtx(ctx context.Context, deploymentId string) error {
 	if deploymentId == "" {
 		return fmt.Errorf("deploymentId must be non-empty")
 	}
 
 	var uri = "/rest/deployments/" + deploymentId
 
-	response, err := c.RestAPICall(rest.DELETE, uri, nil, nil)
+	_, err := c.RestAPICallCtx(ctx, rest.DELETE, uri, nil, nil)
 
 	if err != nil {
-		return apiResponseError(response, err)
+		return err
 	}
 
 	return nil
@@ -230,6 +318,11 @@ func (c *Client) DeleteDeployment(deploymentId string) error {
 // ActionDeployment Perform an Action on Deployment
 // example actionType: "restart"
 func (c *Client) ActionDeployment(deployment Deployment, actionType string, force bool) error {
+	return c.ActionDeploymentCtx(context.Background(), deployment, actionType, force)
+}
+
+// ActionDeploymentCtx is like ActionDeployment but uses ctx for the request
+func (c *Client) ActionDeploymentCtx(ctx context.Context, deployment Deployment, actionType string, force bool) error {
 	if deployment.ID == "" {
 		return fmt.Errorf("Deployment must have a non-empty ID")
 	}
@@ -248,10 +341,10 @@ func (c *Client) ActionDeployment(deployment Deployment, actionType string, forc
 		"type":  actionType,
 	})
 
-	response, err := c.RestAPICall(rest.POST, uri, nil, values)
+	_, err := c.RestAPICallCtx(ctx, rest.POST, uri, nil, values)
 
 	if err != nil {
-		return apiResponseError(response, err)
+		return err
 	}
 
 	return nil
@@ -259,16 +352,21 @@ func (c *Client) ActionDeployment(deployment Deployment, actionType string, forc
 
 // GetDeploymentConsole returns a Deployment console url
 func (c *Client) GetDeploymentConsole(deployment Deployment) (string, error) {
+	return c.GetDeploymentConsoleCtx(context.Background(), deployment)
+}
+
+// GetDeploymentConsoleCtx is like GetDeploymentConsole but uses ctx for the request
+func (c *Client) GetDeploymentConsoleCtx(ctx context.Context, deployment Deployment) (string, error) {
 	if deployment.ID == "" {
 		return "", fmt.Errorf("Deployment must have a non-empty ID")
 	}
 
 	var uri = "/rest/deployments/" + deployment.ID + "/console"
 
-	consoleUrl, err := c.RestAPICall(rest.POST, uri, nil, nil)
+	consoleUrl, err := c.RestAPICallCtx(ctx, rest.POST, uri, nil, nil)
 
 	if err != nil {
-		return consoleUrl, apiResponseError(consoleUrl, err)
+		return consoleUrl, err
 	}
 
 	return consoleUrl, nil
@@ -276,16 +374,21 @@ func (c *Client) GetDeploymentConsole(deployment Deployment) (string, error) {
 
 // GetDeploymentKubeConfig returns the kubeconfig of the deployment
 func (c *Client) GetDeploymentKubeConfig(deployment Deployment) (string, error) {
+	return c.GetDeploymentKubeConfigCtx(context.Background(), deployment)
+}
+
+// GetDeploymentKubeConfigCtx is like GetDeploymentKubeConfig but uses ctx for the request
+func (c *Client) GetDeploymentKubeConfigCtx(ctx context.Context, deployment Deployment) (string, error) {
 	if deployment.ID == "" {
 		return "", fmt.Errorf("Deployment must have a non-empty ID")
 	}
 
 	var uri = "/rest/deployments/" + deployment.ID + "/kubeconfig"
 
-	kubeConfig, err := c.RestAPICall(rest.GET, uri, nil, nil)
+	kubeConfig, err := c.RestAPICallCtx(ctx, rest.GET, uri, nil, nil)
 
 	if err != nil {
-		return kubeConfig, apiResponseError(kubeConfig, err)
+		return kubeConfig, err
 	}
 
 	return kubeConfig, nil
@@ -66,11 +66,10 @@ func TestGetDeploymentsByName(t *testing.T) {
 func TestGetDeploymentByName(t *testing.T) {
 	setup()
 
-	name := "ubuntu"
-
-	if _, err := client.GetDeploymentByName(name); err != nil {
-		t.Errorf("name: \"%s\" Error: %s\n", name, err)
-	}
+	checkByName(t, "TestGetDeploymentByName", "ubuntu", func(name string) (string, error) {
+		deployment, err := client.GetDeploymentByName(name)
+		return deployment.Name, err
+	}, "/rest/deployments", Deployment{ID: "3", Name: "ubuntu"})
 }
 
 func TestCreateDeployment(t *testing.T) {
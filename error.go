@@ -20,6 +20,16 @@
 
 package onesphere
 
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/HewlettPackard/hpe-onesphere-go/redact"
+)
+
 type Error struct {
 	Message            string   `json:"message"`
 	Details            string   `json:"details"`
@@ -30,3 +40,194 @@ type Error struct {
 	Data               string   `json:"data"`
 	CanForce           bool     `json:"canForce"`
 }
+
+// ErrorBody is an alias of Error so that it can be embedded in APIError
+// without clashing with the Error method of the error interface.
+type ErrorBody = Error
+
+// APIError is returned by every call that receives a non-2xx response.
+// The embedded ErrorBody holds the error payload decoded from the response.
+type APIError struct {
+	ErrorBody
+	StatusCode int
+	Method     string
+	Path       string
+}
+
+var (
+	// ErrUnauthorized matches an APIError with status 401 using errors.Is
+	ErrUnauthorized = errors.New("onesphere: unauthorized")
+	// ErrForbidden matches an APIError with status 403 using errors.Is
+	ErrForbidden = errors.New("onesphere: forbidden")
+	// ErrNotFound matches an APIError with status 404 using errors.Is
+	ErrNotFound = errors.New("onesphere: not found")
+	// ErrConflict matches an APIError with status 409 using errors.Is
+	ErrConflict = errors.New("onesphere: conflict")
+	// ErrAmbiguousName matches an AmbiguousNameError using errors.Is
+	ErrAmbiguousName = errors.New("onesphere: ambiguous name")
+	// ErrOperationFailed matches an OperationFailedError using errors.Is
+	ErrOperationFailed = errors.New("onesphere: operation failed")
+	// ErrProtected matches a ProtectedError using errors.Is
+	ErrProtected = errors.New("onesphere: protected")
+)
+
+var statusErrors = map[int]error{
+	http.StatusUnauthorized: ErrUnauthorized,
+	http.StatusForbidden:    ErrForbidden,
+	http.StatusNotFound:     ErrNotFound,
+	http.StatusConflict:     ErrConflict,
+}
+
+// newAPIError builds an APIError from a non-2xx response body.
+// Bodies that are not a OneSphere error payload are kept as the Message.
+// Secrets echoed by the server are redacted.
+func newAPIError(method, path string, statusCode int, body []byte) *APIError {
+	apiErr := &APIError{
+		StatusCode: statusCode,
+		Method:     method,
+		Path:       redact.String(path),
+	}
+
+	// a field with an unexpected type must not discard the rest of the payload
+	json.Unmarshal(body, &apiErr.ErrorBody)
+	apiErr.Message = redact.String(apiErr.Message)
+	apiErr.Details = redact.String(apiErr.Details)
+	apiErr.NestedErrors = redact.String(apiErr.NestedErrors)
+	apiErr.Data = redact.String(apiErr.Data)
+
+	if apiErr.Message == "" {
+		apiErr.Message = strings.TrimSpace(string(redact.JSON(body)))
+	}
+	if apiErr.Message == "" {
+		apiErr.Message = http.StatusText(statusCode)
+	}
+
+	return apiErr
+}
+
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("onesphere: %s %s returned %d: %s", e.Method, e.Path, e.StatusCode, e.Message)
+	if e.Details != "" {
+		msg += " (" + e.Details + ")"
+	}
+	return msg
+}
+
+// Is reports whether the status code of e matches one of the sentinel errors
+func (e *APIError) Is(target error) bool {
+	statusErr, ok := statusErrors[e.StatusCode]
+	return ok && statusErr == target
+}
+
+// NameNotFoundError is returned by the ByName lookups when no resource has the name.
+// It matches ErrNotFound using errors.Is.
+type NameNotFoundError struct {
+	Resource string
+	Name     string
+}
+
+func (e *NameNotFoundError) Error() string {
+	return fmt.Sprintf("onesphere: no %s named %q", e.Resource, e.Name)
+}
+
+// Is reports whether target is ErrNotFound
+func (e *NameNotFoundError) Is(target error) bool {
+	return target == ErrNotFound
+}
+
+// AmbiguousNameError is returned by the ByName lookups when several resources
+// have the name, URIs lists them so that the caller can pick one by id.
+// It matches ErrAmbiguousName using errors.Is.
+type AmbiguousNameError struct {
+	Resource string
+	Name     string
+	URIs     []string
+}
+
+func (e *AmbiguousNameError) Error() string {
+	return fmt.Sprintf("onesphere: %d %ss named %q: %s", len(e.URIs), e.Resource, e.Name, strings.Join(e.URIs, ", "))
+}
+
+// Is reports whether target is ErrAmbiguousName
+func (e *AmbiguousNameError) Is(target error) bool {
+	return target == ErrAmbiguousName
+}
+
+// OperationFailedError is returned by the WaitFor calls when the awaited
+// resource reports an error or a failed state, Err holds the reported error.
+// It matches ErrOperationFailed using errors.Is.
+type OperationFailedError struct {
+	Resource string
+	ID       string
+	State    string
+	Err      *Error
+}
+
+func (e *OperationFailedError) Error() string {
+	msg := fmt.Sprintf("onesphere: %s %s failed", e.Resource, e.ID)
+	if e.State != "" {
+		msg += " in state " + e.State
+	}
+	if e.Err != nil && e.Err.Message != "" {
+		msg += ": " + e.Err.Message
+	}
+	return msg
+}
+
+// Is reports whether target is ErrOperationFailed
+func (e *OperationFailedError) Is(target error) bool {
+	return target == ErrOperationFailed
+}
+
+// ProtectedError is returned by the Delete calls for a protected project or
+// catalog, which OneSphere does not let anyone delete.
+// It matches ErrProtected using errors.Is.
+type ProtectedError struct {
+	Resource string
+	ID       string
+}
+
+func (e *ProtectedError) Error() string {
+	return fmt.Sprintf("onesphere: %s %s is protected", e.Resource, e.ID)
+}
+
+// Is reports whether target is ErrProtected
+func (e *ProtectedError) Is(target error) bool {
+	return target == ErrProtected
+}
+
+// IsUnauthorized reports whether err is an APIError with status 401
+func IsUnauthorized(err error) bool {
+	return errors.Is(err, ErrUnauthorized)
+}
+
+// IsForbidden reports whether err is an APIError with status 403
+func IsForbidden(err error) bool {
+	return errors.Is(err, ErrForbidden)
+}
+
+// IsNotFound reports whether err is an APIError with status 404
+// or a NameNotFoundError
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound)
+}
+
+// IsConflict reports whether err is an APIError with status 409
+func IsConflict(err error) bool {
+	return errors.Is(err, ErrConflict)
+}
+
+// IsAmbiguousName reports whether err is an AmbiguousNameError
+func IsAmbiguousName(err error) bool {
+	return errors.Is(err, ErrAmbiguousName)
+}
+
+// IsOperationFailed reports whether err is an OperationFailedError
+func IsOperationFailed(err error) bool {
+	return errors.Is(err, ErrOperationFailed)
+}
+
+// IsProtected reports whether err is a ProtectedError
+func IsProtected(err error) bool {
+	return errors.Is(err, ErrProtected)
+}
@@ -0,0 +1,76 @@
+package onesphere
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestAPIErrorFromResponse(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"message":"Zone not found","details":"zone 123 does not exist","errorCode":"NotFound","canForce":false}`))
+	}))
+	defer ts.Close()
+
+	c := &Client{Auth: &Auth{HostURL: ts.URL}}
+
+	_, err := c.GetZoneByID("123")
+	if err == nil {
+		t.Fatal("TestAPIErrorFromResponse expected an error for a 404 response")
+	}
+
+	if !IsNotFound(err) {
+		t.Errorf("TestAPIErrorFromResponse IsNotFound should be true for %v", err)
+	}
+	if IsConflict(err) || IsForbidden(err) {
+		t.Errorf("TestAPIErrorFromResponse only IsNotFound should match %v", err)
+	}
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("TestAPIErrorFromResponse error should be an *APIError, got %T", err)
+	}
+	if apiErr.StatusCode != http.StatusNotFound || apiErr.Method != "GET" || apiErr.Path != "/rest/zones/123" {
+		t.Errorf("TestAPIErrorFromResponse unexpected request info: %+v", apiErr)
+	}
+	if apiErr.Message != "Zone not found" || apiErr.ErrorCode != "NotFound" {
+		t.Errorf("TestAPIErrorFromResponse unexpected error payload: %+v", apiErr.ErrorBody)
+	}
+}
+
+func TestAPIErrorWithPlainBody(t *testing.T) {
+	apiErr := newAPIError("DELETE", "/rest/projects/1", http.StatusConflict, []byte("project is in use"))
+
+	if apiErr.Message != "project is in use" {
+		t.Errorf("TestAPIErrorWithPlainBody Message should fall back to the raw body, got %q", apiErr.Message)
+	}
+	if !IsConflict(apiErr) {
+		t.Errorf("TestAPIErrorWithPlainBody IsConflict should be true for status 409")
+	}
+
+	apiErr = newAPIError("GET", "/rest/status", http.StatusForbidden, nil)
+	if apiErr.Message != http.StatusText(http.StatusForbidden) || !IsForbidden(apiErr) {
+		t.Errorf("TestAPIErrorWithPlainBody unexpected error for empty 403 body: %v", apiErr)
+	}
+}
+
+func TestAPIErrorRedactsSecrets(t *testing.T) {
+	apiErr := newAPIError("POST", "/rest/providers", http.StatusBadRequest,
+		[]byte(`{"message":"invalid provider","details":"rejected {\"accessKey\":\"AKIA\",\"secretKey\":\"s3cr3t\"}"}`))
+	if msg := apiErr.Error(); strings.Contains(msg, "AKIA") || strings.Contains(msg, "s3cr3t") {
+		t.Errorf("TestAPIErrorRedactsSecrets secrets should be redacted from %q", msg)
+	}
+
+	apiErr = newAPIError("POST", "/rest/session", http.StatusBadGateway, []byte(`<html>password=hunter2</html>`))
+	if strings.Contains(apiErr.Message, "hunter2") {
+		t.Errorf("TestAPIErrorRedactsSecrets secrets should be redacted from the raw body %q", apiErr.Message)
+	}
+
+	err := apiResponseError(`{"token":"abc","userUri":`, errors.New("unexpected EOF"))
+	if strings.Contains(err.Error(), "abc") {
+		t.Errorf("TestAPIErrorRedactsSecrets secrets should be redacted from %v", err)
+	}
+}
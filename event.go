@@ -0,0 +1,117 @@
+// (C) Copyright 2018 Hewlett Packard Enterprise Development LP.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.  IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package onesphere
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/HewlettPackard/hpe-onesphere-go/rest"
+)
+
+// Event records a change made to a resource and the user who made it
+type Event struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	URI         string    `json:"uri"`
+	ResourceURI string    `json:"resourceUri"`
+	UserID      string    `json:"userId"`
+	Created     time.Time `json:"created"`
+	Modified    time.Time `json:"modified"`
+}
+
+type EventList struct {
+	Total       int     `json:"total"`
+	Start       int     `json:"start"`
+	Count       int     `json:"count"`
+	NextPageURI string  `json:"nextPageUri,omitempty"`
+	Members     []Event `json:"members"`
+}
+
+// EventListOptions selects the events returned by ListEvents
+type EventListOptions struct {
+	ResourceURI string `param:"resourceUri"`
+	// Since and Until select the events created at or after Since and before Until
+	Since time.Time `param:"startTime"`
+	Until time.Time `param:"endTime"`
+	ListOptions
+}
+
+// GetEvents returns the EventList of resourceUri
+func (c *Client) GetEvents(resourceUri string) (EventList, error) {
+	return c.GetEventsCtx(context.Background(), resourceUri)
+}
+
+// GetEventsCtx is like GetEvents but uses ctx for the request
+func (c *Client) GetEventsCtx(ctx context.Context, resourceUri string) (EventList, error) {
+	return c.ListEventsCtx(ctx, EventListOptions{ResourceURI: resourceUri})
+}
+
+// ListEvents returns the EventList selected by opts
+func (c *Client) ListEvents(opts EventListOptions) (EventList, error) {
+	return c.ListEventsCtx(context.Background(), opts)
+}
+
+// ListEventsCtx is like ListEvents but uses ctx for the request
+func (c *Client) ListEventsCtx(ctx context.Context, opts EventListOptions) (EventList, error) {
+	var (
+		uri         = "/rest/events"
+		queryParams = encodeParams(opts)
+		events      EventList
+	)
+
+	response, err := c.RestAPICallCtx(ctx, rest.GET, uri, queryParams, nil)
+
+	if err != nil {
+		return events, err
+	}
+
+	if err := json.Unmarshal([]byte(response), &events); err != nil {
+		return events, apiResponseError(response, err)
+	}
+
+	return events, err
+}
+
+// ForEachEvent calls fn for every Event selected by opts across all pages,
+// see ForEachDeployment for paging and how fn stops the iteration
+func (c *Client) ForEachEvent(opts EventListOptions, fn func(Event) error) error {
+	return c.ForEachEventCtx(context.Background(), opts, fn)
+}
+
+// ForEachEventCtx is like ForEachEvent but uses ctx for the requests
+func (c *Client) ForEachEventCtx(ctx context.Context, opts EventListOptions, fn func(Event) error) error {
+	return c.forEachPage(ctx, "/rest/events", encodeParams(opts), func(response string) (page, error) {
+		var events EventList
+		if err := json.Unmarshal([]byte(response), &events); err != nil {
+			return page{}, apiResponseError(response, err)
+		}
+
+		for _, event := range events.Members {
+			if err := fn(event); err != nil {
+				return page{}, err
+			}
+		}
+
+		return page{total: events.Total, members: len(events.Members), nextPageURI: events.NextPageURI}, nil
+	})
+}
@@ -0,0 +1,40 @@
+package onesphere
+
+import (
+	"testing"
+	"time"
+)
+
+func TestListEvents(t *testing.T) {
+	server, c := newWaitClient(t, time.Second)
+	day := func(d int) time.Time { return time.Date(2018, 5, d, 10, 0, 0, 0, time.UTC) }
+	for i, resourceURI := range []string{"/rest/projects/p1", "/rest/projects/p1", "/rest/zones/z1", "/rest/projects/p1"} {
+		server.Seed("/rest/events", Event{Name: "updated", ResourceURI: resourceURI, UserID: "u1", Created: day(i + 1)})
+	}
+
+	events, err := c.GetEvents("/rest/projects/p1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if events.Total != 3 || events.Members[0].UserID != "u1" || !events.Members[0].Created.Equal(day(1)) {
+		t.Errorf("TestListEvents expected the 3 events of the project, got %+v", events)
+	}
+
+	events, err = c.ListEvents(EventListOptions{
+		ResourceURI: "/rest/projects/p1",
+		Since:       day(2),
+		Until:       day(4),
+	})
+	if err != nil || events.Total != 1 || !events.Members[0].Created.Equal(day(2)) {
+		t.Errorf("TestListEvents expected the event of day 2, got %+v, %v", events, err)
+	}
+
+	var visited []time.Time
+	err = c.ForEachEvent(EventListOptions{ListOptions: ListOptions{Sort: "created:desc", Count: 1}}, func(event Event) error {
+		visited = append(visited, event.Created)
+		return nil
+	})
+	if err != nil || len(visited) != 4 || !visited[0].Equal(day(4)) {
+		t.Errorf("TestListEvents ForEachEvent expected 4 events newest first, got %v, %v", visited, err)
+	}
+}
@@ -0,0 +1,47 @@
+package onesphere
+
+import (
+	"github.com/HewlettPackard/hpe-onesphere-go/onespheretest"
+)
+
+// seedFixtures resets server to the resources the tests expect, most of them with id "2"
+func seedFixtures(server *onespheretest.Server) {
+	server.Reset()
+
+	must := func(err error) {
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	must(server.SeedAt("/rest/account", Account{ID: "2", Name: "account", Events: []Event{{ID: "2", ResourceURI: "/rest/projects/2", UserID: "2"}}}))
+	must(server.Seed("/rest/appliances", Appliance{ID: "1", Name: "appliance-1"}, Appliance{ID: "2", Name: "appliance-2"}))
+	must(server.Seed("/rest/billing-accounts", BillingAccount{ID: "2", Name: "billing"}))
+	must(server.Seed("/rest/catalog-types", CatalogType{ID: "docker-registry", Name: "Docker Registry"}))
+	must(server.Seed("/rest/catalogs", Catalog{ID: "2", Name: "catalog"}))
+	must(server.Seed("/rest/deployments", Deployment{ID: "2", Name: "ubuntu", ZoneURI: "/rest/zones/2"}))
+	must(server.SeedAt("/rest/deployments/2/console", "https://console.example.com/deployments/2"))
+	must(server.SeedAt("/rest/deployments/2/kubeconfig", "apiVersion: v1"))
+	must(server.Seed("/rest/membership-roles", MembershipRole{ID: "2", Name: "project-member"}))
+	must(server.Seed("/rest/memberships", Membership{ID: "2", ProjectURI: "/rest/projects/2", UserURI: "/rest/users/2"}))
+	must(server.Seed("/rest/networks", Network{ID: "2", Name: "network", ZoneURI: "/rest/zones/2"}))
+	must(server.SeedAt("/rest/onboarding/azure/properties", AzureLoginProperties{AuthHost: "login.example.com", ClientID: "client"}))
+	must(server.Seed("/rest/projects", Project{ID: "2", Name: "project"}))
+	must(server.Seed("/rest/provider-types", ProviderType{ID: "aws", Name: "AWS"}))
+	must(server.Seed("/rest/providers", Provider{ID: "2", Name: "provider"}))
+	must(server.Seed("/rest/regions", Region{ID: "2", Name: "region"}))
+	must(server.SeedAt("/rest/regions/2/connection", RegionConnection{Name: "connection"}))
+	must(server.Seed("/rest/roles", Role{ID: "2", Name: "administrator"}))
+	must(server.Seed("/rest/service-types", ServiceType{ID: "2", Name: "virtual-machine"}))
+	must(server.Seed("/rest/services", Service{ID: "2", Name: "service"}))
+	must(server.Seed("/rest/tag-keys", TagKey{ID: "2", Name: "environment"}))
+	must(server.Seed("/rest/tags", Tag{ID: "2", Name: "production"}))
+	must(server.Seed("/rest/users", User{ID: "2", Name: "user", Email: "user@example.com"}))
+	must(server.Seed("/rest/virtual-machine-profiles", VirtualMachineProfile{ID: "2", Name: "small"}))
+	must(server.Seed("/rest/zone-types", ZoneType{ID: "2", Name: "kvm"}))
+	must(server.SeedAt("/rest/zone-types/2/resource-profiles", ZoneTypeResourceProfileList{}))
+	must(server.Seed("/rest/zones", Zone{ID: "2", Name: "zone"}))
+	must(server.SeedAt("/rest/zones/2/appliance-image", "https://images.example.com/appliance.ova"))
+	must(server.SeedAt("/rest/zones/2/task-status", "Completed"))
+	must(server.Seed("/rest/zones/2/connections", Connection{ID: "2222", Name: "connection"}))
+}
@@ -0,0 +1,104 @@
+// (C) Copyright 2018 Hewlett Packard Enterprise Development LP.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.  IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+// Package jsondoc holds the helpers shared by the fakes to query and update
+// resources kept as decoded JSON documents.
+package jsondoc
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Lookup returns the query field values of doc, nested fields use dots
+func Lookup(doc map[string]interface{}) func(field string) (string, bool) {
+	return func(field string) (string, bool) {
+		var v interface{} = doc
+		for _, key := range strings.Split(field, ".") {
+			m, ok := v.(map[string]interface{})
+			if !ok {
+				return "", false
+			}
+			if v, ok = m[key]; !ok || v == nil {
+				return "", false
+			}
+		}
+		switch v := v.(type) {
+		case string:
+			return v, true
+		case float64:
+			return strconv.FormatFloat(v, 'f', -1, 64), true
+		case bool:
+			return strconv.FormatBool(v), true
+		}
+		return "", false
+	}
+}
+
+// ContainsText reports whether a string field of doc contains text, ignoring case,
+// the way OneSphere matches the userQuery parameter
+func ContainsText(doc map[string]interface{}, text string) bool {
+	if text == "" {
+		return true
+	}
+	text = strings.ToLower(text)
+	for _, v := range doc {
+		if s, ok := v.(string); ok && strings.Contains(strings.ToLower(s), text) {
+			return true
+		}
+	}
+	return false
+}
+
+// Sort sorts docs by a "field" or "field:desc" sort parameter
+func Sort(docs []map[string]interface{}, by string) {
+	field, order := by, "asc"
+	if i := strings.LastIndex(by, ":"); i >= 0 {
+		field, order = by[:i], strings.ToLower(by[i+1:])
+	}
+	sort.SliceStable(docs, func(i, j int) bool {
+		a, _ := Lookup(docs[i])(field)
+		b, _ := Lookup(docs[j])(field)
+		if order == "desc" {
+			return a > b
+		}
+		return a < b
+	})
+}
+
+// IsEmpty reports whether v is the zero value of its JSON type
+func IsEmpty(v interface{}) bool {
+	switch v := v.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case float64:
+		return v == 0
+	case bool:
+		return !v
+	case []interface{}:
+		return len(v) == 0
+	case map[string]interface{}:
+		return len(v) == 0
+	}
+	return false
+}
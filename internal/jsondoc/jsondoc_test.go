@@ -0,0 +1,28 @@
+package jsondoc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPatch(t *testing.T) {
+	doc := map[string]interface{}{
+		"name":  "zone",
+		"tags":  []interface{}{"a", "c"},
+		"a/b~c": "escaped",
+	}
+
+	patched, err := Patch(doc, "add", "/tags/1", "b")
+	assert.NoError(t, err)
+	patched, err = Patch(patched, "add", "/tags/-", "d")
+	assert.NoError(t, err)
+	patched, err = Patch(patched, "remove", "/a~1b~0c", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"name": "zone", "tags": []interface{}{"a", "b", "c", "d"}}, patched)
+
+	_, err = Patch(patched, "replace", "/tags/9", "x")
+	assert.Error(t, err)
+	_, err = Patch(patched, "move", "/name", "x")
+	assert.Error(t, err)
+}
@@ -0,0 +1,121 @@
+// (C) Copyright 2018 Hewlett Packard Enterprise Development LP.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.  IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package jsondoc
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// splitPointer splits a JSON Pointer such as "/location/ipAddress" into its unescaped tokens
+func splitPointer(pointer string) []string {
+	if pointer == "" || pointer == "/" {
+		return nil
+	}
+	tokens := strings.Split(strings.TrimPrefix(pointer, "/"), "/")
+	for i, token := range tokens {
+		tokens[i] = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+	}
+	return tokens
+}
+
+// Patch applies a JSON Patch add, replace or remove operation at the JSON
+// Pointer path in doc and returns the updated document, doc may be modified
+func Patch(doc interface{}, op, path string, value interface{}) (interface{}, error) {
+	return patch(doc, op, splitPointer(path), value)
+}
+
+func patch(doc interface{}, op string, path []string, value interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		switch op {
+		case "add", "replace":
+			return value, nil
+		}
+		return nil, fmt.Errorf("op %q cannot be applied to the whole resource", op)
+	}
+
+	key, rest := path[0], path[1:]
+	switch v := doc.(type) {
+	case map[string]interface{}:
+		child, ok := v[key]
+		if len(rest) > 0 {
+			if !ok {
+				return nil, fmt.Errorf("path element %q does not exist", key)
+			}
+			updated, err := patch(child, op, rest, value)
+			if err != nil {
+				return nil, err
+			}
+			v[key] = updated
+			return v, nil
+		}
+		switch op {
+		case "add":
+			v[key] = value
+		case "replace":
+			if !ok {
+				return nil, fmt.Errorf("path element %q does not exist", key)
+			}
+			v[key] = value
+		case "remove":
+			if !ok {
+				return nil, fmt.Errorf("path element %q does not exist", key)
+			}
+			delete(v, key)
+		default:
+			return nil, fmt.Errorf("op %q is not supported", op)
+		}
+		return v, nil
+
+	case []interface{}:
+		if key == "-" && len(rest) == 0 && op == "add" {
+			return append(v, value), nil
+		}
+		i, err := strconv.Atoi(key)
+		if err != nil || i < 0 || i > len(v) || (i == len(v) && (op != "add" || len(rest) > 0)) {
+			return nil, fmt.Errorf("index %q is out of range", key)
+		}
+		if len(rest) > 0 {
+			updated, err := patch(v[i], op, rest, value)
+			if err != nil {
+				return nil, err
+			}
+			v[i] = updated
+			return v, nil
+		}
+		switch op {
+		case "add":
+			v = append(v, nil)
+			copy(v[i+1:], v[i:])
+			v[i] = value
+		case "replace":
+			v[i] = value
+		case "remove":
+			v = append(v[:i], v[i+1:]...)
+		default:
+			return nil, fmt.Errorf("op %q is not supported", op)
+		}
+		return v, nil
+	}
+
+	return nil, fmt.Errorf("path element %q does not exist", key)
+}
@@ -0,0 +1,63 @@
+// (C) Copyright 2018 Hewlett Packard Enterprise Development LP.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.  IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package onesphere
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/HewlettPackard/hpe-onesphere-go/rest"
+)
+
+type KeyPair struct {
+	RegionURI  string `json:"regionUri"`
+	ProjectURI string `json:"projectUri"`
+	PrivateKey string `json:"privateKey"`
+}
+
+// GetKeyPair returns the KeyPair used to log in to the instances of projectUri in regionUri
+func (c *Client) GetKeyPair(regionUri, projectUri string) (KeyPair, error) {
+	return c.GetKeyPairCtx(context.Background(), regionUri, projectUri)
+}
+
+// GetKeyPairCtx is like GetKeyPair but uses ctx for the request
+func (c *Client) GetKeyPairCtx(ctx context.Context, regionUri, projectUri string) (KeyPair, error) {
+	var (
+		uri         = "/rest/keypairs"
+		queryParams = map[string]string{
+			"regionUri":  regionUri,
+			"projectUri": projectUri,
+		}
+		keyPair KeyPair
+	)
+
+	response, err := c.RestAPICallCtx(ctx, rest.GET, uri, queryParams, nil)
+
+	if err != nil {
+		return keyPair, err
+	}
+
+	if err := json.Unmarshal([]byte(response), &keyPair); err != nil {
+		return keyPair, apiResponseError(response, err)
+	}
+
+	return keyPair, err
+}
@@ -0,0 +1,181 @@
+// (C) Copyright 2018 Hewlett Packard Enterprise Development LP.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.  IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package onesphere
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// View selects how much of each member a list call returns
+type View string
+
+const (
+	ViewDefault View = ""
+	// ViewFull expands the URIs of related resources into objects
+	ViewFull View = "full"
+)
+
+// ListOptions are the view, sort and paging parameters shared by the list calls.
+// Zero values are not sent, so the server defaults apply.
+type ListOptions struct {
+	View View `param:"view"`
+	// Sort orders the members, e.g. "name:asc"
+	Sort string `param:"sort"`
+	// Start is the offset of the first member returned
+	Start int `param:"start"`
+	// Count limits the number of members returned
+	Count int `param:"count"`
+}
+
+type ZoneListOptions struct {
+	// Query filters zones, see the query package
+	Query        string `param:"query"`
+	RegionURI    string `param:"regionUri"`
+	ProviderURI  string `param:"providerUri"`
+	ApplianceURI string `param:"applianceUri"`
+	ListOptions
+}
+
+type DeploymentListOptions struct {
+	Query string `param:"query"`
+	// UserQuery matches deployments by free text, e.g. "ubuntu"
+	UserQuery string `param:"userQuery"`
+	ListOptions
+}
+
+type ProjectListOptions struct {
+	UserQuery string `param:"userQuery"`
+	ListOptions
+}
+
+type RegionListOptions struct {
+	Query string `param:"query"`
+	ListOptions
+}
+
+type NetworkListOptions struct {
+	Query string `param:"query"`
+	ListOptions
+}
+
+type UserListOptions struct {
+	UserQuery string `param:"userQuery"`
+	ListOptions
+}
+
+type VolumeListOptions struct {
+	Query string `param:"query"`
+	ListOptions
+}
+
+type ServerListOptions struct {
+	RegionURI    string `param:"regionUri"`
+	ApplianceURI string `param:"applianceUri"`
+	ZoneURI      string `param:"zoneUri"`
+	ListOptions
+}
+
+type BillingAccountListOptions struct {
+	Query string `param:"query"`
+	ListOptions
+}
+
+// Period is the length of the periods metrics are aggregated over
+type Period string
+
+const (
+	PeriodHour  Period = "hour"
+	PeriodDay   Period = "day"
+	PeriodMonth Period = "month"
+)
+
+// MetricsQuery selects the metrics returned by ListMetrics
+type MetricsQuery struct {
+	ResourceURI string `param:"resourceUri"`
+	// Category of the resources, e.g. "providers"
+	Category string `param:"category"`
+	GroupBy  string `param:"groupBy"`
+	Query    string `param:"query"`
+	// Name of the metric, e.g. "cost.total"
+	Name        string    `param:"nameArray"`
+	PeriodStart time.Time `param:"periodStart"`
+	Period      Period    `param:"period"`
+	PeriodCount int       `param:"periodCount"`
+	ListOptions
+}
+
+type RateListOptions struct {
+	ResourceURI      string    `param:"resourceUri"`
+	EffectiveForDate time.Time `param:"effectiveForDate"`
+	EffectiveDate    time.Time `param:"effectiveDate"`
+	MetricName       string    `param:"metricName"`
+	// Active is only sent when set
+	Active *bool `param:"active"`
+	ListOptions
+}
+
+// encodeParams returns the query parameters of an options struct.
+// Fields are named by their param tag, zero values are omitted and
+// embedded structs such as ListOptions are flattened.
+func encodeParams(opts interface{}) map[string]string {
+	params := map[string]string{}
+	addParams(params, reflect.ValueOf(opts))
+	return params
+}
+
+func addParams(params map[string]string, v reflect.Value) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field, value := t.Field(i), v.Field(i)
+
+		if field.Anonymous && value.Kind() == reflect.Struct {
+			addParams(params, value)
+			continue
+		}
+
+		name := field.Tag.Get("param")
+		if name == "" || value.IsZero() {
+			continue
+		}
+		if value.Kind() == reflect.Ptr {
+			value = value.Elem()
+		}
+
+		switch x := value.Interface().(type) {
+		case time.Time:
+			params[name] = x.Format(time.RFC3339)
+		default:
+			switch value.Kind() {
+			case reflect.String:
+				params[name] = value.String()
+			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+				params[name] = strconv.FormatInt(value.Int(), 10)
+			case reflect.Bool:
+				params[name] = strconv.FormatBool(value.Bool())
+			default:
+				panic(fmt.Sprintf("onesphere: unsupported param type %s for %s", value.Type(), field.Name))
+			}
+		}
+	}
+}
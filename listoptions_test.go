@@ -0,0 +1,84 @@
+package onesphere
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestEncodeParams(t *testing.T) {
+	active := false
+	params := encodeParams(RateListOptions{
+		ResourceURI:      "/rest/projects/1",
+		EffectiveForDate: time.Date(2018, 5, 1, 0, 0, 0, 0, time.UTC),
+		Active:           &active,
+		ListOptions:      ListOptions{View: ViewFull, Sort: "name:asc", Count: 50},
+	})
+
+	expected := map[string]string{
+		"resourceUri":      "/rest/projects/1",
+		"effectiveForDate": "2018-05-01T00:00:00Z",
+		"active":           "false",
+		"view":             "full",
+		"sort":             "name:asc",
+		"count":            "50",
+	}
+	if !reflect.DeepEqual(params, expected) {
+		t.Errorf("TestEncodeParams expected %v, got %v", expected, params)
+	}
+}
+
+func TestGetZonesSendsNamedParams(t *testing.T) {
+	var received url.Values
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.URL.Query()
+		w.Write([]byte(`{"total":0,"members":[]}`))
+	}))
+	defer ts.Close()
+
+	c := &Client{Auth: &Auth{HostURL: ts.URL, Token: "token"}}
+
+	if _, err := c.GetZones("name EQ zone", "/rest/regions/1", "", "", "full"); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := url.Values{
+		"query":     {"name EQ zone"},
+		"regionUri": {"/rest/regions/1"},
+		"view":      {"full"},
+	}
+	if !reflect.DeepEqual(received, expected) {
+		t.Errorf("TestGetZonesSendsNamedParams expected %v, got %v", expected, received)
+	}
+}
+
+func TestGetMetricsAndRatesPassDatesThrough(t *testing.T) {
+	var received url.Values
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.URL.Query()
+		w.Write([]byte(`{"total":0,"members":[]}`))
+	}))
+	defer ts.Close()
+
+	c := &Client{Auth: &Auth{HostURL: ts.URL, Token: "token"}}
+
+	if _, err := c.GetMetrics("", "providers", "", "", "", "2018-05", "month", 2, "", 0, 0); err != nil {
+		t.Fatalf("TestGetMetricsAndRatesPassDatesThrough GetMetrics failed: %v", err)
+	}
+	if got := received.Get("periodStart"); got != "2018-05" {
+		t.Errorf("TestGetMetricsAndRatesPassDatesThrough periodStart should be sent as given, got %q", got)
+	}
+
+	if _, err := c.GetRates("", "2018-01-01T00:00:00.000Z", "today", "", true, 0, 0); err != nil {
+		t.Fatalf("TestGetMetricsAndRatesPassDatesThrough GetRates failed: %v", err)
+	}
+	if got := received.Get("effectiveForDate"); got != "2018-01-01T00:00:00.000Z" {
+		t.Errorf("TestGetMetricsAndRatesPassDatesThrough effectiveForDate should be sent as given, got %q", got)
+	}
+	if got := received.Get("effectiveDate"); got != "today" {
+		t.Errorf("TestGetMetricsAndRatesPassDatesThrough effectiveDate should be sent as given, got %q", got)
+	}
+}
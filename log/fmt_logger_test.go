@@ -1,6 +1,9 @@
 package log
 
 import (
+	"bytes"
+	"io/ioutil"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -89,3 +92,87 @@ CAlTWS3abehlCERn6Ze9IfZBtpI=
 		assert.Equal(t, tc.expected, stripSecrets(tc.input))
 	}
 }
+
+func TestFmtLoggerLevelsAndWriters(t *testing.T) {
+	var out, errs bytes.Buffer
+	l := &FmtLogger{}
+	l.SetOutWriter(&out)
+	l.SetErrWriter(&errs)
+
+	l.Debug("hidden")
+	l.Infof("zone %s ready\n", "z1")
+	l.Warn("slow")
+	l.Errorf("failed %d times", 3)
+	assert.NotContains(t, out.String(), "hidden")
+	assert.Regexp(t, `^\S+ INFO zone z1 ready\n$`, out.String())
+	assert.Regexp(t, `^\S+ WARN slow\n\S+ ERROR failed 3 times\n$`, errs.String())
+
+	l.SetDebug(true)
+	l.Debug("shown")
+	assert.Contains(t, out.String(), "DEBUG shown")
+
+	l.SetLevel(LevelError)
+	l.Warn("dropped")
+	assert.NotContains(t, errs.String(), "dropped")
+}
+
+func TestFmtLoggerHistory(t *testing.T) {
+	l := &FmtLogger{}
+	l.SetOutWriter(ioutil.Discard)
+	l.SetHistorySize(3)
+
+	for i := 1; i <= 5; i++ {
+		l.Infof("entry %d", i)
+	}
+	history := l.History()
+	if assert.Len(t, history, 3) {
+		assert.True(t, strings.HasSuffix(history[0], "entry 3"))
+		assert.True(t, strings.HasSuffix(history[2], "entry 5"))
+	}
+
+	l.SetHistorySize(2)
+	history = l.History()
+	if assert.Len(t, history, 2) {
+		assert.True(t, strings.HasSuffix(history[0], "entry 4"))
+	}
+
+	l.SetHistorySize(-1)
+	l.Info("not kept")
+	assert.Empty(t, l.History())
+}
+
+func TestFmtLoggerWith(t *testing.T) {
+	var out bytes.Buffer
+	l := &FmtLogger{}
+	l.SetOutWriter(&out)
+
+	zone := l.With("zone", "z1").With("state", "Creating zone", "odd")
+	zone.Info("polling")
+	assert.Contains(t, out.String(), `INFO polling zone=z1 state="Creating zone" odd=(MISSING)`)
+	assert.Len(t, l.History(), 1, "derived loggers share the history")
+}
+
+// plainLogger only implements Logger, like the loggers written before FieldLogger
+type plainLogger struct{ Logger }
+
+func TestWith(t *testing.T) {
+	var out bytes.Buffer
+	l := &FmtLogger{}
+	l.SetOutWriter(&out)
+
+	With(l, "zone", "z1").Info("polling")
+	assert.Contains(t, out.String(), "INFO polling zone=z1")
+
+	plain := plainLogger{l}
+	assert.Equal(t, Logger(plain), With(plain, "zone", "z1"), "a Logger without fields is returned as is")
+}
+
+func TestFmtLoggerRedactsSecrets(t *testing.T) {
+	var out bytes.Buffer
+	l := &FmtLogger{}
+	l.SetOutWriter(&out)
+
+	l.With("user", "bob", "password", "pw").Infof("POST /rest/session %s", `{"userName":"bob","password":"pw"}`)
+	assert.Contains(t, out.String(), `INFO POST /rest/session {"userName":"bob","password":"<REDACTED>"} user=bob password=<REDACTED>`)
+	assert.NotContains(t, strings.Join(l.History(), "\n"), "pw")
+}
@@ -2,6 +2,7 @@ package log
 
 import "io"
 
+// Logger receives the diagnostics of the clients
 type Logger interface {
 	SetDebug(debug bool)
 
@@ -22,3 +23,39 @@ type Logger interface {
 
 	History() []string
 }
+
+// FieldLogger is a Logger able to add key/value fields to its entries.
+// It is kept apart from Logger so that existing Logger implementations
+// remain valid, use the With function to add fields to any Logger.
+type FieldLogger interface {
+	Logger
+
+	// With returns a FieldLogger adding the key/value pairs to every entry
+	With(keyvals ...interface{}) FieldLogger
+}
+
+// With returns l adding the key/value pairs to every entry when it is a
+// FieldLogger, otherwise l itself
+func With(l Logger, keyvals ...interface{}) Logger {
+	if fl, ok := l.(FieldLogger); ok {
+		return fl.With(keyvals...)
+	}
+	return l
+}
+
+// NopLogger discards everything, it is the default Logger of the clients
+type NopLogger struct{}
+
+func (NopLogger) SetDebug(debug bool)                          {}
+func (NopLogger) SetOutWriter(io.Writer)                       {}
+func (NopLogger) SetErrWriter(io.Writer)                       {}
+func (NopLogger) Debug(args ...interface{})                    {}
+func (NopLogger) Debugf(fmtString string, args ...interface{}) {}
+func (NopLogger) Error(args ...interface{})                    {}
+func (NopLogger) Errorf(fmtString string, args ...interface{}) {}
+func (NopLogger) Info(args ...interface{})                     {}
+func (NopLogger) Infof(fmtString string, args ...interface{})  {}
+func (NopLogger) Warn(args ...interface{})                     {}
+func (NopLogger) Warnf(fmtString string, args ...interface{})  {}
+func (NopLogger) History() []string                            { return nil }
+func (l NopLogger) With(keyvals ...interface{}) FieldLogger    { return l }
@@ -0,0 +1,105 @@
+// (C) Copyright 2018 Hewlett Packard Enterprise Development LP.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.  IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+//go:build go1.21
+// +build go1.21
+
+package log
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+
+	"github.com/HewlettPackard/hpe-onesphere-go/redact"
+)
+
+// SlogLogger is a Logger writing to a slog.Logger, whose handler decides
+// which levels are kept and where the entries go
+type SlogLogger struct {
+	logger *slog.Logger
+}
+
+var _ FieldLogger = (*SlogLogger)(nil)
+
+// NewSlogLogger returns a Logger writing to logger, slog.Default() when nil
+func NewSlogLogger(logger *slog.Logger) *SlogLogger {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &SlogLogger{logger: logger}
+}
+
+func (l *SlogLogger) log(level slog.Level, msg string) {
+	l.logger.Log(context.Background(), level, redact.String(msg))
+}
+
+func (l *SlogLogger) Debug(args ...interface{}) {
+	l.log(slog.LevelDebug, fmt.Sprint(args...))
+}
+
+func (l *SlogLogger) Debugf(fmtString string, args ...interface{}) {
+	l.log(slog.LevelDebug, fmt.Sprintf(fmtString, args...))
+}
+
+func (l *SlogLogger) Error(args ...interface{}) {
+	l.log(slog.LevelError, fmt.Sprint(args...))
+}
+
+func (l *SlogLogger) Errorf(fmtString string, args ...interface{}) {
+	l.log(slog.LevelError, fmt.Sprintf(fmtString, args...))
+}
+
+func (l *SlogLogger) Info(args ...interface{}) {
+	l.log(slog.LevelInfo, fmt.Sprint(args...))
+}
+
+func (l *SlogLogger) Infof(fmtString string, args ...interface{}) {
+	l.log(slog.LevelInfo, fmt.Sprintf(fmtString, args...))
+}
+
+func (l *SlogLogger) Warn(args ...interface{}) {
+	l.log(slog.LevelWarn, fmt.Sprint(args...))
+}
+
+func (l *SlogLogger) Warnf(fmtString string, args ...interface{}) {
+	l.log(slog.LevelWarn, fmt.Sprintf(fmtString, args...))
+}
+
+// SetDebug does nothing, the level is set on the slog handler
+func (l *SlogLogger) SetDebug(enable bool) {}
+
+// SetOutWriter does nothing, the output is set on the slog handler
+func (l *SlogLogger) SetOutWriter(out io.Writer) {}
+
+// SetErrWriter does nothing, the output is set on the slog handler
+func (l *SlogLogger) SetErrWriter(err io.Writer) {}
+
+// History returns nil, slog keeps no history
+func (l *SlogLogger) History() []string {
+	return nil
+}
+
+// With returns a SlogLogger adding the key/value pairs as attributes, the
+// values of secret keys are redacted
+func (l *SlogLogger) With(keyvals ...interface{}) FieldLogger {
+	return &SlogLogger{logger: l.logger.With(redact.KeyValues(keyvals)...)}
+}
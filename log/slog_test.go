@@ -0,0 +1,32 @@
+//go:build go1.21
+// +build go1.21
+
+package log
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlogLogger(t *testing.T) {
+	var out bytes.Buffer
+	handler := slog.NewTextHandler(&out, &slog.HandlerOptions{
+		Level: slog.LevelInfo,
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	})
+
+	var l FieldLogger = NewSlogLogger(slog.New(handler))
+	l.Debug("hidden")
+	l.With("zone", "z1").Warnf("zone %s is %s", "z1", "slow")
+
+	assert.Equal(t, "level=WARN msg=\"zone z1 is slow\" zone=z1\n", out.String())
+	assert.Nil(t, l.History())
+}
@@ -0,0 +1,53 @@
+// (C) Copyright 2018 Hewlett Packard Enterprise Development LP.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.  IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package onesphere
+
+// nameMatches collects the resources whose name is exactly name while the
+// candidates of a ByName lookup are listed
+type nameMatches struct {
+	resource string
+	name     string
+	uris     []string
+}
+
+func newNameMatches(resource, name string) *nameMatches {
+	return &nameMatches{resource: resource, name: name}
+}
+
+// add records the candidate and reports whether its name matches
+func (m *nameMatches) add(name, uri string) bool {
+	if name != m.name {
+		return false
+	}
+	m.uris = append(m.uris, uri)
+	return true
+}
+
+// err returns a NameNotFoundError or an AmbiguousNameError unless exactly one candidate matched
+func (m *nameMatches) err() error {
+	switch len(m.uris) {
+	case 0:
+		return &NameNotFoundError{Resource: m.resource, Name: m.name}
+	case 1:
+		return nil
+	}
+	return &AmbiguousNameError{Resource: m.resource, Name: m.name, URIs: m.uris}
+}
@@ -0,0 +1,105 @@
+package onesphere
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestLookupZoneByName(t *testing.T) {
+	zones := []Zone{
+		{ID: "z1", Name: "prod", URI: "/rest/zones/z1"},
+		{ID: "z2", Name: "dev", URI: "/rest/zones/z2"},
+		{ID: "z3", Name: "dev", URI: "/rest/zones/z3"},
+		{ID: "z4", Name: "production", URI: "/rest/zones/z4"},
+	}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/zones" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		// a loose filter, the client must still compare names exactly
+		q := r.URL.Query().Get("query")
+		list := ZoneList{}
+		for _, zone := range zones {
+			if strings.Contains(q, zone.Name) {
+				list.Members = append(list.Members, zone)
+			}
+		}
+		list.Total = len(list.Members)
+		json.NewEncoder(w).Encode(list)
+	}))
+	defer ts.Close()
+
+	c := &Client{Auth: &Auth{HostURL: ts.URL, Token: "token"}}
+
+	zone, err := c.GetZoneByName("prod")
+	if err != nil || zone.ID != "z1" {
+		t.Errorf("TestLookupZoneByName expected zone z1, got %+v, %v", zone, err)
+	}
+
+	_, err = c.GetZoneByName("test")
+	var notFound *NameNotFoundError
+	if !IsNotFound(err) || !errors.As(err, &notFound) || notFound.Name != "test" {
+		t.Errorf("TestLookupZoneByName expected a not found error, got %v", err)
+	}
+
+	_, err = c.GetZoneByName("dev")
+	var ambiguous *AmbiguousNameError
+	if !IsAmbiguousName(err) || !errors.As(err, &ambiguous) {
+		t.Fatalf("TestLookupZoneByName expected an ambiguous name error, got %v", err)
+	}
+	if strings.Join(ambiguous.URIs, " ") != "/rest/zones/z2 /rest/zones/z3" {
+		t.Errorf("TestLookupZoneByName unexpected candidates: %v", ambiguous.URIs)
+	}
+}
+
+// checkByName looks up the seeded name, which must be found, and a missing
+// name, which must not. Against the fake server duplicate is then seeded in
+// collection and the seeded name must become ambiguous.
+func checkByName(t *testing.T, test, name string, lookup func(name string) (string, error), collection string, duplicate interface{}) {
+	found, err := lookup(name)
+	if err != nil {
+		t.Fatalf("%s lookup of %q failed: %v", test, name, err)
+	}
+	if found != name {
+		t.Errorf("%s lookup of %q returned %q", test, name, found)
+	}
+
+	if _, err := lookup("missing-" + name); !errors.Is(err, ErrNotFound) {
+		t.Errorf("%s expected ErrNotFound for a missing name, got %v", test, err)
+	}
+
+	if server == nil {
+		return
+	}
+	if err := server.Seed(collection, duplicate); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := lookup(name); !errors.Is(err, ErrAmbiguousName) {
+		t.Errorf("%s expected ErrAmbiguousName for a duplicate name, got %v", test, err)
+	}
+}
+
+func TestLookupRegionByNameAcrossPages(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// the server sends one region per page whatever the requested count
+		start := r.URL.Query().Get("start")
+		id := "r1"
+		if start != "0" {
+			id = "r2"
+		}
+		json.NewEncoder(w).Encode(RegionList{Total: 2, Members: []Region{{ID: id, Name: "dup", URI: "/rest/regions/" + id}}})
+	}))
+	defer ts.Close()
+
+	c := &Client{Auth: &Auth{HostURL: ts.URL, Token: "token"}}
+
+	if _, err := c.GetRegionByNameCtx(context.Background(), "dup"); !IsAmbiguousName(err) {
+		t.Errorf("TestLookupRegionByNameAcrossPages expected an ambiguous name error, got %v", err)
+	}
+}
@@ -23,6 +23,7 @@ package onesphere
 import (
 	"encoding/json"
 	"fmt"
+	"github.com/HewlettPackard/hpe-onesphere-go/query"
 	"github.com/HewlettPackard/hpe-onesphere-go/rest"
 )
 
@@ -74,7 +75,7 @@ func (c *Client) GetMembershipsByProject(projectUri string) (MembershipList, err
 	if projectUri == "" {
 		return MembershipList{}, fmt.Errorf("projectUri must be a non-empty value")
 	}
-	return c.GetMemberships("projectUri EQ " + projectUri)
+	return c.GetMemberships(query.Eq("projectUri", projectUri).String())
 }
 
 // GetMembershipByUser filters Memberships by userUri
@@ -82,7 +83,7 @@ func (c *Client) GetMembershipsByUser(userUri string) (MembershipList, error) {
 	if userUri == "" {
 		return MembershipList{}, fmt.Errorf("userUri must be a non-empty value")
 	}
-	return c.GetMemberships("userUri EQ " + userUri)
+	return c.GetMemberships(query.Eq("userUri", userUri).String())
 }
 
 // GetMembershipByUserGroup filters Memberships by userGroupUri
@@ -90,7 +91,7 @@ func (c *Client) GetMembershipsByUserGroup(userGroupUri string) (MembershipList,
 	if userGroupUri == "" {
 		return MembershipList{}, fmt.Errorf("userGroupUri must be a non-empty value")
 	}
-	return c.GetMemberships("userGroupUri EQ " + userGroupUri)
+	return c.GetMemberships(query.Eq("userGroupUri", userGroupUri).String())
 }
 
 // GetMembershipByRole filters Memberships by roleUri
@@ -98,7 +99,7 @@ func (c *Client) GetMembershipsByRole(roleUri string) (MembershipList, error) {
 	if roleUri == "" {
 		return MembershipList{}, fmt.Errorf("roleUri must be a non-empty value")
 	}
-	return c.GetMemberships("roleUri EQ " + roleUri)
+	return c.GetMemberships(query.Eq("roleUri", roleUri).String())
 }
 
 // GetMembershipByID returns a Membership by ID
@@ -151,10 +152,10 @@ func (c *Client) DeleteMembershipByID(membershipId string) error {
 
 	var uri = "/rest/memberships/" + membershipId
 
-	response, err := c.RestAPICall(rest.DELETE, uri, nil, nil)
+	_, err := c.RestAPICall(rest.DELETE, uri, nil, nil)
 
 	if err != nil {
-		return apiResponseError(response, err)
+		return err
 	}
 
 	return nil
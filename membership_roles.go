@@ -21,6 +21,7 @@
 package onesphere
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/HewlettPackard/hpe-onesphere-go/rest"
@@ -35,12 +36,17 @@ type MembershipRoleList struct {
 
 // GetMembershipRoles returns a list of all MembershipRoles
 func (c *Client) GetMembershipRoles() (MembershipRoleList, error) {
+	return c.GetMembershipRolesCtx(context.Background())
+}
+
+// GetMembershipRolesCtx is like GetMembershipRoles but uses ctx for the request
+func (c *Client) GetMembershipRolesCtx(ctx context.Context) (MembershipRoleList, error) {
 	var (
 		uri             = "/rest/membership-roles"
 		membershipRoles MembershipRoleList
 	)
 
-	response, err := c.RestAPICall(rest.GET, uri, nil, nil)
+	response, err := c.RestAPICallCtx(ctx, rest.GET, uri, nil, nil)
 
 	if err != nil {
 		return membershipRoles, err
@@ -53,24 +59,38 @@ func (c *Client) GetMembershipRoles() (MembershipRoleList, error) {
 	return membershipRoles, err
 }
 
-// GetMembershipRoleByName returns a list of all MembershipRole filtered by name
+// GetMembershipRoleByName returns the MembershipRole named name.
+// The membership-roles endpoint takes no filter, the few roles are all read
+// and compared with name.
 func (c *Client) GetMembershipRoleByName(name string) (MembershipRole, error) {
-	var membershipRole MembershipRole
+	return c.GetMembershipRoleByNameCtx(context.Background(), name)
+}
+
+// GetMembershipRoleByNameCtx is like GetMembershipRoleByName but uses ctx for the request
+func (c *Client) GetMembershipRoleByNameCtx(ctx context.Context, name string) (MembershipRole, error) {
+	var (
+		membershipRole MembershipRole
+		matches        = newNameMatches("membership role", name)
+	)
 
 	if name == "" {
 		return membershipRole, fmt.Errorf("name must not be empty")
 	}
 
-	membershipRoles, err := c.GetMembershipRoles()
+	membershipRoles, err := c.GetMembershipRolesCtx(ctx)
+	if err != nil {
+		return MembershipRole{}, err
+	}
 
-	if len(membershipRoles.Members) > 0 {
-		for i := 0; i < len(membershipRoles.Members); i++ {
-			if membershipRoles.Members[i].Name == name {
-				membershipRole = membershipRoles.Members[i]
-				return membershipRole, err
-			}
+	for _, candidate := range membershipRoles.Members {
+		if matches.add(candidate.Name, candidate.URI) {
+			membershipRole = candidate
 		}
 	}
 
-	return membershipRole, err
+	if err := matches.err(); err != nil {
+		return MembershipRole{}, err
+	}
+
+	return membershipRole, nil
 }
@@ -14,8 +14,8 @@ func TestGetMembershipRoles(t *testing.T) {
 func TestGetMembershipRoleByName(t *testing.T) {
 	setup()
 
-	_, err := client.GetMembershipRoleByName("2")
-	if err != nil {
-		t.Error(err)
-	}
+	checkByName(t, "TestGetMembershipRoleByName", "project-member", func(name string) (string, error) {
+		membershipRole, err := client.GetMembershipRoleByName(name)
+		return membershipRole.Name, err
+	}, "/rest/membership-roles", MembershipRole{ID: "3", Name: "project-member"})
 }
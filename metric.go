@@ -20,7 +20,13 @@
 
 package onesphere
 
-import "time"
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/HewlettPackard/hpe-onesphere-go/rest"
+)
 
 type Metric struct {
 	ResourceURI string    `json:"resourceUri"`
@@ -41,3 +47,100 @@ type Metric struct {
 		Category string `json:"category"`
 	} `json:"associations"`
 }
+
+type MetricList struct {
+	Total   int      `json:"total"`
+	Start   int      `json:"start"`
+	Count   int      `json:"count"`
+	Members []Metric `json:"members"`
+}
+
+// GetMetrics returns MetricList for resourceUri
+// example category: "providers"
+// example period: "month"
+func (c *Client) GetMetrics(
+	resourceUri, category, groupBy, query, name string,
+	periodStart, period string,
+	periodCount int,
+	view string,
+	start, count int) (MetricList, error) {
+	return c.GetMetricsCtx(context.Background(), resourceUri, category, groupBy, query, name, periodStart, period, periodCount, view, start, count)
+}
+
+// GetMetricsCtx is like GetMetrics but uses ctx for the request
+func (c *Client) GetMetricsCtx(ctx context.Context,
+	resourceUri, category, groupBy, query, name string,
+	periodStart, period string,
+	periodCount int,
+	view string,
+	start, count int) (MetricList, error) {
+	queryParams := encodeParams(MetricsQuery{
+		ResourceURI: resourceUri,
+		Category:    category,
+		GroupBy:     groupBy,
+		Query:       query,
+		Name:        name,
+		Period:      Period(period),
+		PeriodCount: periodCount,
+		ListOptions: ListOptions{View: View(view), Start: start, Count: count},
+	})
+	// the date is sent as given, like it always was
+	if periodStart != "" {
+		queryParams["periodStart"] = periodStart
+	}
+
+	return c.listMetrics(ctx, queryParams)
+}
+
+// ListMetrics returns the MetricList selected by q
+func (c *Client) ListMetrics(q MetricsQuery) (MetricList, error) {
+	return c.ListMetricsCtx(context.Background(), q)
+}
+
+// ListMetricsCtx is like ListMetrics but uses ctx for the request
+func (c *Client) ListMetricsCtx(ctx context.Context, q MetricsQuery) (MetricList, error) {
+	return c.listMetrics(ctx, encodeParams(q))
+}
+
+func (c *Client) listMetrics(ctx context.Context, queryParams map[string]string) (MetricList, error) {
+	var (
+		uri     = "/rest/metrics"
+		metrics MetricList
+	)
+
+	response, err := c.RestAPICallCtx(ctx, rest.GET, uri, queryParams, nil)
+
+	if err != nil {
+		return metrics, err
+	}
+
+	if err := json.Unmarshal([]byte(response), &metrics); err != nil {
+		return metrics, apiResponseError(response, err)
+	}
+
+	return metrics, err
+}
+
+// ForEachMetric calls fn for every Metric selected by q across all pages,
+// see ForEachDeployment for paging and how fn stops the iteration
+func (c *Client) ForEachMetric(q MetricsQuery, fn func(Metric) error) error {
+	return c.ForEachMetricCtx(context.Background(), q, fn)
+}
+
+// ForEachMetricCtx is like ForEachMetric but uses ctx for the requests
+func (c *Client) ForEachMetricCtx(ctx context.Context, q MetricsQuery, fn func(Metric) error) error {
+	return c.forEachPage(ctx, "/rest/metrics", encodeParams(q), func(response string) (page, error) {
+		var metrics MetricList
+		if err := json.Unmarshal([]byte(response), &metrics); err != nil {
+			return page{}, apiResponseError(response, err)
+		}
+
+		for _, metric := range metrics.Members {
+			if err := fn(metric); err != nil {
+				return page{}, err
+			}
+		}
+
+		return page{total: metrics.Total, members: len(metrics.Members)}, nil
+	})
+}
@@ -21,8 +21,11 @@
 package onesphere
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"github.com/HewlettPackard/hpe-onesphere-go/query"
+	"github.com/HewlettPackard/hpe-onesphere-go/redact"
 	"github.com/HewlettPackard/hpe-onesphere-go/rest"
 )
 
@@ -59,15 +62,23 @@ type NetworkList struct {
 // leave query blank to get all networks
 // example query: "zoneUri EQ /rest/zones/xxxx"
 func (c *Client) GetNetworks(query string) (NetworkList, error) {
+	return c.ListNetworks(NetworkListOptions{Query: query})
+}
+
+// ListNetworks returns the NetworkList selected by opts
+func (c *Client) ListNetworks(opts NetworkListOptions) (NetworkList, error) {
+	return c.ListNetworksCtx(context.Background(), opts)
+}
+
+// ListNetworksCtx is like ListNetworks but uses ctx for the request
+func (c *Client) ListNetworksCtx(ctx context.Context, opts NetworkListOptions) (NetworkList, error) {
 	var (
 		uri         = "/rest/networks"
-		queryParams = createQuery(&map[string]string{
-			"query": query,
-		})
-		networks NetworkList
+		queryParams = encodeParams(opts)
+		networks    NetworkList
 	)
 
-	response, err := c.RestAPICall(rest.GET, uri, queryParams, nil)
+	response, err := c.RestAPICallCtx(ctx, rest.GET, uri, queryParams, nil)
 
 	if err != nil {
 		return networks, err
@@ -80,6 +91,30 @@ func (c *Client) GetNetworks(query string) (NetworkList, error) {
 	return networks, err
 }
 
+// ForEachNetwork calls fn for every Network selected by opts across all pages,
+// see ForEachDeployment for paging and how fn stops the iteration
+func (c *Client) ForEachNetwork(opts NetworkListOptions, fn func(Network) error) error {
+	return c.ForEachNetworkCtx(context.Background(), opts, fn)
+}
+
+// ForEachNetworkCtx is like ForEachNetwork but uses ctx for the requests
+func (c *Client) ForEachNetworkCtx(ctx context.Context, opts NetworkListOptions, fn func(Network) error) error {
+	return c.forEachPage(ctx, "/rest/networks", encodeParams(opts), func(response string) (page, error) {
+		var networks NetworkList
+		if err := json.Unmarshal([]byte(response), &networks); err != nil {
+			return page{}, apiResponseError(response, err)
+		}
+
+		for _, network := range networks.Members {
+			if err := fn(network); err != nil {
+				return page{}, err
+			}
+		}
+
+		return page{total: networks.Total, members: len(networks.Members)}, nil
+	})
+}
+
 // GetNetworkByID returns an Network by id
 func (c *Client) GetNetworkByID(id string) (Network, error) {
 	var (
@@ -112,7 +147,7 @@ func (c *Client) GetNetworkByZoneURI(zoneUri string) (Network, error) {
 		return network, fmt.Errorf("zoneUri must not be empty")
 	}
 
-	networks, err := c.GetNetworks("zoneUri EQ " + zoneUri)
+	networks, err := c.GetNetworks(query.Eq("zoneUri", zoneUri).String())
 
 	if len(networks.Members) == 0 {
 		return network, err
@@ -134,7 +169,7 @@ func (c *Client) GetNetworkByNameAndZoneURI(name, zoneUri string) (Network, erro
 		return network, fmt.Errorf("name must not be empty")
 	}
 
-	networks, err := c.GetNetworks("zoneUri EQ " + zoneUri)
+	networks, err := c.GetNetworks(query.Eq("zoneUri", zoneUri).String())
 
 	if len(networks.Members) > 0 {
 		for i := 0; i < len(networks.Members); i++ {
@@ -190,7 +225,7 @@ func (c *Client) UpdateNetwork(networkId string, updates []*PatchOp) (Network, e
 		return updatedNetwork, err
 	}
 
-	fmt.Printf("DEBUG +%v", response)
+	c.log().Debugf("UpdateNetwork %s returned %s", networkId, redact.JSON([]byte(response)))
 	if err := json.Unmarshal([]byte(response), &updatedNetwork); err != nil {
 		return updatedNetwork, apiResponseError(response, err)
 	}
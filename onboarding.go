@@ -0,0 +1,189 @@
+// (C) Copyright 2018 Hewlett Packard Enterprise Development LP.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.  IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package onesphere
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/HewlettPackard/hpe-onesphere-go/rest"
+)
+
+// AzureLoginProperties are the OAuth2 parameters used to sign in to Azure
+type AzureLoginProperties struct {
+	AuthHost     string `json:"authHost"`
+	ClientID     string `json:"clientId"`
+	Resource     string `json:"resource"`
+	AuthType     string `json:"authType"`
+	ResponseType string `json:"responseType"`
+	ResponseMode string `json:"responseMode"`
+	Prompt       string `json:"prompt"`
+}
+
+// AzureProviderInfo describes the Azure Active Directory tenant of directoryUri
+type AzureProviderInfo struct {
+	DirectoryURI string `json:"directoryUri"`
+	TenantID     string `json:"tenantId"`
+	UniqueName   string `json:"uniqueName"`
+	FamilyName   string `json:"familyName"`
+	GivenName    string `json:"givenName"`
+}
+
+type AzureSubscription struct {
+	ID             string `json:"id"`
+	SubscriptionID string `json:"subscriptionId"`
+	DisplayName    string `json:"displayName"`
+	State          string `json:"state"`
+	ProviderURI    string `json:"providerUri"`
+}
+
+type AzureSubscriptionList struct {
+	Total   int                 `json:"total"`
+	Members []AzureSubscription `json:"members"`
+}
+
+// GetAzureLoginProperties returns the properties needed to sign in to Azure
+func (c *Client) GetAzureLoginProperties() (AzureLoginProperties, error) {
+	return c.GetAzureLoginPropertiesCtx(context.Background())
+}
+
+// GetAzureLoginPropertiesCtx is like GetAzureLoginProperties but uses ctx for the request
+func (c *Client) GetAzureLoginPropertiesCtx(ctx context.Context) (AzureLoginProperties, error) {
+	var properties AzureLoginProperties
+
+	response, err := c.RestAPICallCtx(ctx, rest.GET, "/rest/onboarding/azure/properties", nil, nil)
+
+	if err != nil {
+		return properties, err
+	}
+
+	if err := json.Unmarshal([]byte(response), &properties); err != nil {
+		return properties, apiResponseError(response, err)
+	}
+
+	return properties, err
+}
+
+// GetAzureProviderInfo returns the AzureProviderInfo of directoryUri
+func (c *Client) GetAzureProviderInfo(directoryUri, location string) (AzureProviderInfo, error) {
+	return c.GetAzureProviderInfoCtx(context.Background(), directoryUri, location)
+}
+
+// GetAzureProviderInfoCtx is like GetAzureProviderInfo but uses ctx for the request
+func (c *Client) GetAzureProviderInfoCtx(ctx context.Context, directoryUri, location string) (AzureProviderInfo, error) {
+	var (
+		uri         = "/rest/onboarding/azure/provider-info"
+		queryParams = map[string]string{
+			"directoryUri": directoryUri,
+			"location":     location,
+		}
+		info AzureProviderInfo
+	)
+
+	response, err := c.RestAPICallCtx(ctx, rest.GET, uri, queryParams, nil)
+
+	if err != nil {
+		return info, err
+	}
+
+	if err := json.Unmarshal([]byte(response), &info); err != nil {
+		return info, apiResponseError(response, err)
+	}
+
+	return info, err
+}
+
+// GetAzureSubscriptions returns the subscriptions of directoryUri
+func (c *Client) GetAzureSubscriptions(directoryUri, location string) (AzureSubscriptionList, error) {
+	return c.GetAzureSubscriptionsCtx(context.Background(), directoryUri, location)
+}
+
+// GetAzureSubscriptionsCtx is like GetAzureSubscriptions but uses ctx for the request
+func (c *Client) GetAzureSubscriptionsCtx(ctx context.Context, directoryUri, location string) (AzureSubscriptionList, error) {
+	var (
+		uri         = "/rest/onboarding/azure/subscriptions"
+		queryParams = map[string]string{
+			"directoryUri": directoryUri,
+			"location":     location,
+		}
+		subscriptions AzureSubscriptionList
+	)
+
+	response, err := c.RestAPICallCtx(ctx, rest.GET, uri, queryParams, nil)
+
+	if err != nil {
+		return subscriptions, err
+	}
+
+	if err := json.Unmarshal([]byte(response), &subscriptions); err != nil {
+		return subscriptions, apiResponseError(response, err)
+	}
+
+	return subscriptions, err
+}
+
+// UpdateAzureSubscription allowed Ops in patchPayload: "add|replace"
+func (c *Client) UpdateAzureSubscription(directoryUri, location, subscriptionId string, patchPayload []*PatchOp) (AzureSubscription, error) {
+	return c.UpdateAzureSubscriptionCtx(context.Background(), directoryUri, location, subscriptionId, patchPayload)
+}
+
+// UpdateAzureSubscriptionCtx is like UpdateAzureSubscription but uses ctx for the request
+func (c *Client) UpdateAzureSubscriptionCtx(ctx context.Context, directoryUri, location, subscriptionId string, patchPayload []*PatchOp) (AzureSubscription, error) {
+	var subscription AzureSubscription
+
+	allowedOps := []string{"add", "replace"}
+
+	for _, pb := range patchPayload {
+		opIsValid := false
+
+		for _, allowedOp := range allowedOps {
+			if pb.Op == allowedOp {
+				opIsValid = true
+			}
+		}
+
+		if !opIsValid {
+			return subscription, fmt.Errorf("UpdateAzureSubscription received invalid Op for update.\nReceived Op: %s\nValid Ops: %v\n", pb.Op, allowedOps)
+		}
+	}
+
+	var (
+		uri         = "/rest/onboarding/azure/subscriptions/" + subscriptionId
+		queryParams = map[string]string{
+			"directoryUri": directoryUri,
+			"location":     location,
+		}
+		values = map[string][]*PatchOp{"items": patchPayload}
+	)
+
+	response, err := c.RestAPICallCtx(ctx, rest.PATCH, uri, queryParams, values)
+
+	if err != nil {
+		return subscription, err
+	}
+
+	if err := json.Unmarshal([]byte(response), &subscription); err != nil {
+		return subscription, apiResponseError(response, err)
+	}
+
+	return subscription, err
+}
@@ -21,22 +21,40 @@
 package onesphere
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
-	"io"
-	"io/ioutil"
 	"net/http"
-	"strconv"
-	"strings"
+	"sync"
+	"time"
 
+	"github.com/HewlettPackard/hpe-onesphere-go/log"
+	"github.com/HewlettPackard/hpe-onesphere-go/redact"
 	"github.com/HewlettPackard/hpe-onesphere-go/rest"
 )
 
 // Client contains all the methods needed to interact with the OneSphere API
 // use Connect() to return a *Client
 type Client struct {
+	// Auth.Token is replaced when an expired session is re-established,
+	// it must not be modified while calls are in flight
 	Auth *Auth
+
+	authMu      sync.RWMutex
+	credentials CredentialProvider
+	tokenCache  TokenCache
+	// cachedUser is the user whose token is kept in tokenCache
+	cachedUser string
+
+	httpClient    *http.Client
+	ownsTransport bool
+	// transport sends the requests with the headers, query and logger of the options
+	transport   *rest.Client
+	retryPolicy *RetryPolicy
+	waitPolicy  *WaitPolicy
+	timeout     time.Duration
+	userAgent   string
+	logger      log.Logger
 }
 
 // Auth contains the Token and HostURL of the OneSphere API connection
@@ -64,87 +82,63 @@ type AddressWithType struct {
 	AddressType string `json:"addressType"`
 }
 
-func closer(r io.Closer, funcName string) {
-	err := r.Close()
-	if err != nil {
-		fmt.Printf("Error closing response body reader in %s\n%v\n", funcName, err)
-	}
+// Connect provides an interface to make calls to the OneSphere API
+// opts configure the underlying HTTP client, see NewClient
+//
+// The credentials are kept by the Client so that an expired session is
+// re-established transparently when the API answers 401 Unauthorized.
+func Connect(hostURL, user, password string, opts ...Option) (*Client, error) {
+	return ConnectWithCredentials(hostURL, StaticCredentials(user, password), opts...)
 }
 
-// Connect provides an interface to make calls to the OneSphere API
-func Connect(hostURL, user, password string) (*Client, error) {
-	fullUrl := hostURL + "/rest/session"
-	values := map[string]string{"userName": user, "password": password}
-	jsonValue, err := json.Marshal(values)
-	req, err := http.NewRequest("POST", fullUrl, bytes.NewBuffer(jsonValue))
+// ConnectWithCredentials logs in with the Credentials supplied by provider.
+// provider is asked again whenever the session has to be re-established.
+func ConnectWithCredentials(hostURL string, provider CredentialProvider, opts ...Option) (*Client, error) {
+	c, err := NewClient(hostURL, opts...)
 	if err != nil {
 		return nil, err
 	}
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("Content-Type", "application/json")
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	token, err := c.login(context.Background(), provider)
 	if err != nil {
 		return nil, err
 	}
-	defer closer(resp.Body, fmt.Sprintf("onesphere.Connect(%s,%s,#masked password#)", hostURL, user))
 
-	body, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
+	c.Auth.Token = token
+	c.credentials = provider
+
+	return c, nil
+}
+
+// NewClientFromToken returns a Client using an existing session token,
+// such as one created by another tool. The Client cannot log in again
+// once the token expires, use ConnectWithCredentials for that.
+func NewClientFromToken(hostURL, token string, opts ...Option) (*Client, error) {
+	if token == "" {
+		return nil, fmt.Errorf("token must not be empty")
 	}
 
-	//bodyStr := string(body)
-	var dat map[string]string
-	err = json.Unmarshal(body, &dat)
+	c, err := NewClient(hostURL, opts...)
 	if err != nil {
 		return nil, err
 	}
 
-	return &Client{
-		Auth: &Auth{
-			HostURL: hostURL,
-			Token:   dat["token"],
-		},
-	}, nil
+	c.Auth.Token = token
 
+	return c, nil
 }
 
 func (c *Client) callHTTPRequest(method, path string, params map[string]string, values interface{}) (string, error) {
-	jsonValue, err := json.Marshal(values)
-	if err != nil {
-		return "", err
-	}
-	req, err := http.NewRequest(method, c.buildURL(path), bytes.NewBuffer(jsonValue))
-	if err != nil {
-		return "", err
-	}
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", c.Auth.Token)
-
-	if params != nil && len(params) > 0 {
-		q := req.URL.Query()
-		for key, value := range params {
-			q.Add(key, value)
-		}
-		req.URL.RawQuery = q.Encode()
-	}
+	return c.callHTTPRequestCtx(context.Background(), method, path, params, values)
+}
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		return "", err
-	}
-	defer closer(resp.Body, fmt.Sprintf("onesphere.callHTTPRequest(%s,%s,%v,%v)", method, path, params, values))
+func (c *Client) callHTTPRequestCtx(ctx context.Context, method, path string, params map[string]string, values interface{}) (string, error) {
+	return c.doRequest(ctx, method, jsonHeaders, path, params, values)
+}
 
-	bodyBytes, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return "", err
-	}
-	bodyStr := string(bodyBytes)
-	return bodyStr, nil
+var jsonHeaders = map[string]string{
+	"Accept":       "application/json",
+	"Content-Type": "application/json",
 }
 
 // createQuery returns a map for passing to Client.RestAPICall
@@ -160,133 +154,197 @@ func createQuery(params *map[string]string) map[string]string {
 
 // apiResponseError returns a more helpful error including the response payload
 func apiResponseError(response string, err error) error {
-	return fmt.Errorf("Unmarshal Error:\n\tRaw Response: %v\n\tError: %v", response, err)
+	return fmt.Errorf("Unmarshal Error:\n\tRaw Response: %v\n\tError: %w", string(redact.JSON([]byte(response))), err)
 }
 
+// isSuccessStatus reports whether code is in the 2xx range
+func isSuccessStatus(code int) bool {
+	return code >= 200 && code < 300
+}
+
+// RestAPICallCustomHeaders sends the request and returns the response body.
+// A non-2xx response returns the body along with an *APIError.
 func (c *Client) RestAPICallCustomHeaders(method rest.Method, customHeaders map[string]string, path string, queryParams map[string]string, values interface{}) (string, error) {
+	return c.RestAPICallCustomHeadersCtx(context.Background(), method, customHeaders, path, queryParams, values)
+}
+
+// RestAPICallCustomHeadersCtx is RestAPICallCustomHeaders bound to ctx.
+// The request is aborted when ctx is cancelled or its deadline expires.
+func (c *Client) RestAPICallCustomHeadersCtx(ctx context.Context, method rest.Method, customHeaders map[string]string, path string, queryParams map[string]string, values interface{}) (string, error) {
+	return c.doRequest(ctx, method.String(), customHeaders, path, queryParams, values)
+}
+
+func (c *Client) doRequest(ctx context.Context, method string, customHeaders map[string]string, path string, queryParams map[string]string, values interface{}) (string, error) {
 
 	jsonValue, err := json.Marshal(values)
 	if err != nil {
 		return "", err
 	}
-	req, err := http.NewRequest(method.String(), c.buildURL(path), bytes.NewBuffer(jsonValue))
+
+	token := c.token()
+	resp, err := c.sendWithRetry(ctx, method, customHeaders, path, queryParams, jsonValue, token)
+
+	// the session expired, log in again once and replay the request
+	if err == nil && resp.StatusCode == http.StatusUnauthorized && token != "" && c.canReauthenticate() {
+		newToken, err := c.reauthenticate(ctx, token)
+		if err != nil {
+			return "", err
+		}
+		if newToken != token {
+			resp, err = c.sendWithRetry(ctx, method, customHeaders, path, queryParams, jsonValue, newToken)
+			if err != nil {
+				return "", err
+			}
+		}
+	}
+
 	if err != nil {
 		return "", err
 	}
 
-	req.Header.Set("Authorization", c.Auth.Token)
-	for key, value := range customHeaders {
-		req.Header.Set(key, value)
+	bodyStr := string(resp.Body)
 
+	if !isSuccessStatus(resp.StatusCode) {
+		return bodyStr, newAPIError(method, path, resp.StatusCode, resp.Body)
 	}
 
-	if queryParams != nil && len(queryParams) > 0 {
-		q := req.URL.Query()
-		for key, value := range queryParams {
-			q.Add(key, value)
+	return bodyStr, nil
+}
+
+// sendWithRetry sends the request, retrying transient failures according to the RetryPolicy
+func (c *Client) sendWithRetry(ctx context.Context, method string, customHeaders map[string]string, path string, queryParams map[string]string, jsonValue []byte, token string) (*rest.Response, error) {
+	for attempt := 1; ; attempt++ {
+		resp, err := c.send(ctx, method, customHeaders, path, queryParams, jsonValue, token)
+
+		wait, retry := c.retryPolicy.backoff(ctx, method, attempt, resp, err)
+		if !retry {
+			return resp, err
+		}
+
+		if err := sleepContext(ctx, wait); err != nil {
+			return nil, err
 		}
-		req.URL.RawQuery = q.Encode()
 	}
+}
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		return "", err
+// send performs a single attempt of a request
+func (c *Client) send(ctx context.Context, method string, customHeaders map[string]string, path string, queryParams map[string]string, jsonValue []byte, token string) (*rest.Response, error) {
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
 	}
-	defer closer(resp.Body, fmt.Sprintf("onesphere.RestAPICall(%v,%s,%v,%v)", method, path, queryParams, values))
 
-	bodyBytes, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return "", err
+	header := make(map[string]string, len(customHeaders)+2)
+	if token != "" {
+		header["Authorization"] = token
 	}
-	bodyStr := string(bodyBytes)
-	return bodyStr, nil
+	if c.userAgent != "" {
+		header["User-Agent"] = c.userAgent
+	}
+	for key, value := range customHeaders {
+		header[key] = value
+	}
+
+	return c.restClient().Do(ctx, rest.Request{
+		Method: method,
+		Path:   path,
+		Header: header,
+		Query:  queryParams,
+		Body:   jsonValue,
+	})
 }
 
 func (c *Client) RestAPICall(method rest.Method, path string, queryParams map[string]string, values interface{}) (string, error) {
-	return c.RestAPICallCustomHeaders(method, map[string]string{
+	return c.RestAPICallCtx(context.Background(), method, path, queryParams, values)
+}
+
+func (c *Client) RestAPICallCtx(ctx context.Context, method rest.Method, path string, queryParams map[string]string, values interface{}) (string, error) {
+	return c.RestAPICallCustomHeadersCtx(ctx, method, map[string]string{
 		"Accept":       "application/json",
 		"Content-Type": "application/json",
 	}, path, queryParams, values)
 }
 
 func (c *Client) RestAPICallPatch(path string, queryParams map[string]string, values interface{}) (string, error) {
-	return c.RestAPICallCustomHeaders(rest.PATCH, map[string]string{
+	return c.RestAPICallPatchCtx(context.Background(), path, queryParams, values)
+}
+
+func (c *Client) RestAPICallPatchCtx(ctx context.Context, path string, queryParams map[string]string, values interface{}) (string, error) {
+	return c.RestAPICallCustomHeadersCtx(ctx, rest.PATCH, map[string]string{
 		"Accept":       "application/json",
 		"Content-Type": "application/json-patch+json",
 	}, path, queryParams, values)
 }
 
-func (c *Client) buildURL(path string) string {
-	return c.Auth.HostURL + path
-}
-
-func (c *Client) notImplementedError(method rest.Method, endpoint, path string) error {
-	return fmt.Errorf("%s %s is not yet implemented.\nSee: %s/docs/api/endpoint?&path=%%2F%s", method, endpoint, c.Auth.HostURL, path)
+// client returns the http.Client configured by NewClient.
+// A Client built as a struct literal falls back to http.DefaultClient.
+func (c *Client) client() *http.Client {
+	if c.httpClient != nil {
+		return c.httpClient
+	}
+	return http.DefaultClient
 }
 
-func (c *Client) Disconnect() {
-	_, err := c.callHTTPRequest("DELETE", "/rest/session", nil, nil)
-	if err != nil {
-		fmt.Printf("Error logging out of OneSphere api in onesphere.Disconnect()\n%v\n", err)
+// log returns the Logger set with WithLogger, a NopLogger by default
+func (c *Client) log() log.Logger {
+	if c.logger != nil {
+		return c.logger
 	}
+	return log.NopLogger{}
 }
 
-// Billing Accounts APIs
-
-func (c *Client) GetBillingAccounts(query, view string) (string, error) {
-	params := map[string]string{}
-	if strings.TrimSpace(query) != "" {
-		params["query"] = query
+// restClient returns the rest.Client sending the requests to Auth.HostURL
+func (c *Client) restClient() *rest.Client {
+	transport := rest.Client{HTTPClient: c.client()}
+	if c.transport != nil {
+		transport = *c.transport
 	}
-	if strings.TrimSpace(view) != "" {
-		params["view"] = view
-	}
-	return c.callHTTPRequest("GET", "/rest/billing-accounts", params, nil)
+	transport.Endpoint = c.Auth.HostURL
+	return &transport
 }
 
-func (c *Client) CreateBillingAccount(apiAccessKey, description, directoryUri, enrollmentNumber, name, providerTypeUri string) (string, error) {
-	values := map[string]string{
-		"apiAccessKey":     apiAccessKey,
-		"description":      description,
-		"directoryUri":     directoryUri,
-		"enrollmentNumber": enrollmentNumber,
-		"name":             name,
-		"providerTypeUri":  providerTypeUri,
+// Disconnect ends the session, the Client no longer logs in again afterwards.
+// The session is forgotten locally even when logging out fails, including the
+// token stored by WithTokenCache since the server no longer accepts it. Tools
+// reusing the session on their next run call Close instead.
+func (c *Client) Disconnect() error {
+	_, err := c.callHTTPRequest("DELETE", "/rest/session", nil, nil)
+	if err != nil {
+		err = fmt.Errorf("logging out: %w", err)
 	}
-	return c.callHTTPRequest("POST", "/rest/billing-accounts", nil, values)
-}
 
-func (c *Client) GetBillingAccount(id string) (string, error) {
-	return c.callHTTPRequest("GET", "/rest/billing-accounts/"+id, nil, nil)
-}
+	c.authMu.Lock()
+	if c.tokenCache != nil && c.cachedUser != "" {
+		if cacheErr := c.tokenCache.Delete(c.Auth.HostURL, c.cachedUser); cacheErr != nil && err == nil {
+			err = fmt.Errorf("removing the cached session: %w", cacheErr)
+		}
+	}
+	c.Auth.Token = ""
+	c.credentials = nil
+	c.authMu.Unlock()
 
-func (c *Client) DeleteBillingAccount(id string) (string, error) {
-	return c.callHTTPRequest("DELETE", "/rest/billing-accounts/"+id, nil, nil)
+	return err
 }
 
-// UpdateBillingAccount sends PATCH with Op: "add|replace|remove"
-func (c *Client) UpdateBillingAccount(id string, patchPayload []*PatchOp) (string, error) {
-	validOps := []string{"add", "replace", "remove"}
-	for _, pb := range patchPayload {
-		opIsValid := false
-		for _, validOp := range validOps {
-			if pb.Op == validOp {
-				opIsValid = true
-			}
-		}
-		if !opIsValid {
-			return "", fmt.Errorf("UpdateBillingAccount received invalid Op in patchBodies.\nReceived Op: %s\nValid Ops: %v\n", pb.Op, validOps)
-		}
+// Close releases the idle connections held by the transport created by NewClient.
+// A client supplied with WithHTTPClient is left to its owner.
+// Close does not end the session, use Disconnect for that.
+func (c *Client) Close() {
+	if c.ownsTransport {
+		c.httpClient.CloseIdleConnections()
 	}
-
-	return c.callHTTPRequest("PATCH", "/rest/billing-accounts/"+id, nil, patchPayload)
 }
 
 // Connect App APIs
 
 // GetConnectApp allowed operating systems: ["windows", "mac"]
 func (c *Client) GetConnectApp(os string) (string, error) {
+	return c.GetConnectAppCtx(context.Background(), os)
+}
+
+// GetConnectAppCtx is like GetConnectApp but uses ctx for the request
+func (c *Client) GetConnectAppCtx(ctx context.Context, os string) (string, error) {
 	validOperatingSystems := []string{
 		"windows",
 		"mac",
@@ -302,239 +360,27 @@ func (c *Client) GetConnectApp(os string) (string, error) {
 	}
 
 	params := map[string]string{"os": os}
-	return c.callHTTPRequest("GET", "/rest/connect-app", params, nil)
-}
-
-// Events APIs
-
-func (c *Client) GetEvents(resourceUri string) (string, error) {
-	// params := map[string]string{"resourceUri": resourceUri}
-	// return c.callHTTPRequest("GET", "/rest/events", params, nil)
-	return "", c.notImplementedError(rest.GET, "/rest/events", "events")
-}
-
-// Keypairs APIs
-
-func (c *Client) GetKeyPair(regionUri, projectUri string) (string, error) {
-	params := map[string]string{"regionUri": regionUri, "projectUri": projectUri}
-	return c.callHTTPRequest("GET", "/rest/keypairs", params, nil)
-}
-
-// Metrics APIs
-
-func (c *Client) GetMetrics(
-	resourceUri, category, groupBy, query, name string,
-	periodStart, period string,
-	periodCount int,
-	view string,
-	start, count int) (string, error) {
-	params := map[string]string{
-		"resourceUri": resourceUri,
-		"category":    category,
-		"groupBy":     groupBy,
-		"query":       query,
-		"nameArray":   name,
-		"periodStart": periodStart,
-		"period":      period,
-		"periodCount": strconv.Itoa(periodCount),
-		"view":        view,
-		"start":       strconv.Itoa(start),
-		"count":       strconv.Itoa(count)}
-	return c.callHTTPRequest("GET", "/rest/metrics", params, nil)
-}
-
-// Onboarding APIs
-
-func (c *Client) GetAzureLoginProperties() (string, error) {
-	return c.callHTTPRequest("GET", "/rest/onboarding/azure/properties", nil, nil)
-}
-
-func (c *Client) GetAzureProviderInfo(directoryUri, location string) (string, error) {
-	params := map[string]string{"directoryUri": directoryUri, "location": location}
-	return c.callHTTPRequest("GET", "/rest/onboarding/azure/provider-info", params, nil)
-}
-
-func (c *Client) GetAzureSubscriptions(directoryUri, location string) (string, error) {
-	params := map[string]string{"directoryUri": directoryUri, "location": location}
-	return c.callHTTPRequest("GET", "/rest/onboarding/azure/subscriptions", params, nil)
-}
-
-/* UpdateAzureSubscription allowed Ops in patchPayload:
-  - add
-	- replace
-*/
-func (c *Client) UpdateAzureSubscription(directoryUri, location, subscriptionId string, patchPayload []*PatchOp) (string, error) {
-	allowedOps := []string{"add", "replace"}
-
-	for _, pb := range patchPayload {
-		opIsValid := false
-
-		for _, allowedOp := range allowedOps {
-			if pb.Op == allowedOp {
-				opIsValid = true
-			}
-		}
-
-		if !opIsValid {
-			return "", fmt.Errorf("UpdateAzureSubscription received invalid Op for update.\nReceived Op: %s\nValid Ops: %v\n", pb.Op, allowedOps)
-		}
-	}
-
-	params := map[string]string{"directoryUri": directoryUri, "location": location}
-	values := map[string][]*PatchOp{"items": patchPayload}
-	return c.callHTTPRequest("PATCH", "/rest/onboarding/azure/subscriptions/"+subscriptionId, params, values)
+	return c.callHTTPRequestCtx(ctx, "GET", "/rest/connect-app", params, nil)
 }
 
 // Password Reset APIs
 
 func (c *Client) ResetSingleUsePassword(email string) (string, error) {
-	values := map[string]string{"email": email}
-	return c.callHTTPRequest("POST", "/rest/password-reset", nil, values)
-}
-
-func (c *Client) ChangePassword(password, token string) (string, error) {
-	values := map[string]string{"password": password, "token": token}
-	return c.callHTTPRequest("POST", "/rest/password-reset/change", nil, values)
-}
-
-// Rates APIs
-
-func (c *Client) GetRates(resourceUri, effectiveForDate, effectiveDate, metricName string,
-	active bool, start, count int) (string, error) {
-	params := map[string]string{
-		"resourceUri":      resourceUri,
-		"effectiveForDate": effectiveForDate,
-		"effectiveDate":    effectiveDate,
-		"metricName":       metricName,
-		"active":           strconv.FormatBool(active),
-		"start":            strconv.Itoa(start),
-		"count":            strconv.Itoa(count)}
-	return c.callHTTPRequest("GET", "/rest/rates", params, nil)
-}
-
-func (c *Client) GetRate(rateID string) (string, error) {
-	return c.callHTTPRequest("GET", "/rest/rates/"+rateID, nil, nil)
-}
-
-// Roles APIs
-
-func (c *Client) GetRoles() (string, error) {
-	return c.callHTTPRequest("GET", "/rest/roles", nil, nil)
-}
-
-// Servers APIs
-
-func (c *Client) GetServers(regionUri, applianceUri, zoneUri string) (string, error) {
-	params := map[string]string{}
-	if regionUri != "" {
-		params["regionUri"] = regionUri
-	}
-	if applianceUri != "" {
-		params["applianceUri"] = applianceUri
-	}
-	if zoneUri != "" {
-		params["zoneUri"] = zoneUri
-	}
-	return c.callHTTPRequest("GET", "/rest/servers", params, nil)
-}
-
-func (c *Client) CreateServer(server *Server) (string, error) {
-	values := map[string]*Server{
-		"server": server,
-	}
-	return c.callHTTPRequest("POST", "/rest/servers", nil, values)
-}
-
-func (c *Client) DeleteServer(serverID string, force bool) (string, error) {
-	params := map[string]string{}
-	if force {
-		params["force"] = "true"
-	}
-	return c.callHTTPRequest("DELETE", "/rest/servers/"+serverID, params, nil)
+	return c.ResetSingleUsePasswordCtx(context.Background(), email)
 }
 
-func (c *Client) GetServer(serverID string) (string, error) {
-	return c.callHTTPRequest("GET", "/rest/servers/"+serverID, nil, nil)
-}
-
-/* UpdateServer allowed Ops in patchPayload:
-- replace
-- remove
-*/
-func (c *Client) UpdateServer(serverID string, patchPayload []*PatchOp) (string, error) {
-	allowedOps := []string{"replace", "remove"}
-
-	for _, pb := range patchPayload {
-		opIsValid := false
-
-		for _, allowedOp := range allowedOps {
-			if pb.Op == allowedOp {
-				opIsValid = true
-			}
-		}
-
-		if !opIsValid {
-			return "", fmt.Errorf("UpdateServer received invalid Op for update.\nReceived Op: %s\nValid Ops: %v\n", pb.Op, allowedOps)
-		}
-	}
-
-	values := map[string][]*PatchOp{"body": patchPayload}
-	return c.callHTTPRequest("PATCH", "/rest/servers/"+serverID, nil, values)
-}
-
-// Session APIs
-
-// view: "full"
-func (c *Client) GetSession(view string) (string, error) {
-	params := map[string]string{"view": view}
-	return c.callHTTPRequest("GET", "/rest/session", params, nil)
-}
-
-func (c *Client) GetSessionIdp(userName string) (string, error) {
-	// params := map[string]string{"userName": userName}
-	// return c.callHTTPRequest("GET", "/rest/session/idp", params, nil)
-	return "", c.notImplementedError(rest.GET, "/rest/account", "account")
-}
-
-// GetStatus calls the /rest/status endpoint
-func (c *Client) GetStatus() (string, error) {
-	return c.callHTTPRequest("GET", "/rest/status", nil, nil)
-}
-
-// Versions APIs
-
-func (c *Client) GetVersions() (string, error) {
-	return c.callHTTPRequest("GET", "/rest/about/versions", nil, nil)
-}
-
-// Volumes APIs
-
-// view: "full"
-func (c *Client) GetVolumes(query, view string) (string, error) {
-	params := map[string]string{"query": query, "view": view}
-	return c.callHTTPRequest("GET", "/rest/volumes", params, nil)
-}
-
-func (c *Client) CreateVolume(name string, sizeGiB int, zoneUri, projectUri string) (string, error) {
-	values := map[string]interface{}{
-		"name":       name,
-		"sizeGiB":    strconv.Itoa(sizeGiB),
-		"zoneUri":    zoneUri,
-		"projectUri": projectUri}
-	return c.callHTTPRequest("POST", "/rest/volumes", nil, values)
-}
-
-func (c *Client) GetVolume(volumeID string) (string, error) {
-	return c.callHTTPRequest("GET", "/rest/volumes/"+volumeID, nil, nil)
+// ResetSingleUsePasswordCtx is like ResetSingleUsePassword but uses ctx for the request
+func (c *Client) ResetSingleUsePasswordCtx(ctx context.Context, email string) (string, error) {
+	values := map[string]string{"email": email}
+	return c.callHTTPRequestCtx(ctx, "POST", "/rest/password-reset", nil, values)
 }
 
-func (c *Client) UpdateVolume(volumeID, name string, sizeGiB int) (string, error) {
-	values := map[string]interface{}{
-		"name":    name,
-		"sizeGiB": strconv.Itoa(sizeGiB)}
-	return c.callHTTPRequest("PUT", "/rest/volumes/"+volumeID, nil, values)
+func (c *Client) ChangePassword(password, token string) (string, error) {
+	return c.ChangePasswordCtx(context.Background(), password, token)
 }
 
-func (c *Client) DeleteVolume(volumeID string) (string, error) {
-	return c.callHTTPRequest("DELETE", "/rest/volumes/"+volumeID, nil, nil)
+// ChangePasswordCtx is like ChangePassword but uses ctx for the request
+func (c *Client) ChangePasswordCtx(ctx context.Context, password, token string) (string, error) {
+	values := map[string]string{"password": password, "token": token}
+	return c.callHTTPRequestCtx(ctx, "POST", "/rest/password-reset/change", nil, values)
 }
@@ -1,17 +1,20 @@
 package onesphere
 
 import (
-	"encoding/json"
 	"flag"
 	"fmt"
 	"os"
-	"reflect"
 	"testing"
+
+	"github.com/HewlettPackard/hpe-onesphere-go/onespheretest"
 )
 
 var config *onesphereConfig
 var client *Client
 
+// server is the fake OneSphere used when no live host is set
+var server *onespheretest.Server
+
 type onesphereConfig struct {
 	HostURL  string
 	User     string
@@ -26,6 +29,8 @@ func setConfig(configPtr *string, flagName string, defaultVal string, help strin
 	}
 }
 
+// setup connects the shared client, to the fake server unless a live host is set,
+// and restores the fixtures of the fake server before every test
 func setup() {
 	if client == nil {
 		config = &onesphereConfig{}
@@ -35,8 +40,15 @@ func setup() {
 		setConfig(&config.Password, "password", "", "Specify the OneSphere password to authenticate with.")
 		flag.Parse()
 
-		if config.HostURL == "" || config.User == "" || config.Password == "" {
-			fmt.Printf("You must set host and credentials to connect to live api.\nSee the README for details.\n")
+		if config.HostURL == "" {
+			server = onespheretest.NewServer()
+			config.HostURL = server.URL
+			config.User = onespheretest.DefaultUser
+			config.Password = onespheretest.DefaultPassword
+		}
+
+		if config.User == "" || config.Password == "" {
+			fmt.Printf("You must set credentials to connect to live api.\nSee the README for details.\n")
 			os.Exit(1)
 		}
 
@@ -46,81 +58,22 @@ func setup() {
 			os.Exit(1)
 		}
 	}
-}
-
-func comparePayload(t *testing.T, testName string, expectedStr string, actualStr string) error {
-	var expected interface{}
-	var actual interface{}
-
-	var err error
-	err = json.Unmarshal([]byte(expectedStr), &expected)
-	if err != nil {
-		return fmt.Errorf("Error marshalling 'expectedStr' :: %s\nError message: %v", expectedStr, err.Error())
-	}
-	err = json.Unmarshal([]byte(actualStr), &actual)
-	if err != nil {
-		return fmt.Errorf("Error marshalling 'actualStr' :: %s\nError message: %v", actualStr, err.Error())
-	}
 
-	if !reflect.DeepEqual(expected, actual) {
-		t.Errorf("%s actual payload does not match expected payload\n", testName)
-		t.Logf("%s actual payload: %s\n", testName, actualStr)
-		t.Logf("%s expected payload: %s\n", testName, expectedStr)
+	if server != nil {
+		seedFixtures(server)
 	}
-	return nil
-}
-
-// @TODO check value types
-// @TODO check kv recursively
-func compareFields(t *testing.T, testName string, expectedStr string, actualStr string) error {
-	var expected map[string]interface{}
-	var actual map[string]interface{}
-
-	var err error
-	err = json.Unmarshal([]byte(expectedStr), &expected)
-	if err != nil {
-		return fmt.Errorf("Error marshalling 'expectedStr' :: %s\nError message: %v", expectedStr, err.Error())
-	}
-	err = json.Unmarshal([]byte(actualStr), &actual)
-	if err != nil {
-		return fmt.Errorf("Error marshalling 'actualStr' :: %s\nError message: %v", actualStr, err.Error())
-	}
-
-	matches := len(actual) == len(expected)
-
-	if matches {
-		for k := range actual {
-			if _, ok := expected[k]; !ok {
-				matches = false
-				break
-			}
-		}
-	}
-
-	if !matches {
-		actualKeys := make([]string, 0, len(actual))
-		expectedKeys := make([]string, 0, len(expected))
-
-		for k := range actual {
-			actualKeys = append(actualKeys, k)
-		}
-		for k := range expected {
-			expectedKeys = append(expectedKeys, k)
-		}
-
-		t.Errorf("%s actual payload shape does not match expected payload shape\n", testName)
-		t.Logf("%s actual keys: %+v\n", testName, actualKeys)
-		t.Logf("%s expected keys: %+v\n", testName, expectedKeys)
-
-	}
-
-	return nil
 }
 
 func TestMain(m *testing.M) {
 	setup()
 	retCode := m.Run()
-	client.Disconnect()
+	if err := client.Disconnect(); err != nil {
+		fmt.Printf("Failed to Disconnect(): %v\n", err)
+		retCode = 1
+	}
+	if server != nil {
+		server.Close()
+	}
 	os.Exit(retCode)
 }
 
@@ -147,212 +100,103 @@ func TestToken(t *testing.T) {
 }
 
 func TestGetVersions(t *testing.T) {
-	actual, err := client.GetVersions()
+	versions, err := client.GetVersions()
 	if err != nil {
 		t.Errorf("TestGetVersions Error: %v\n", err)
 	}
 
-	expected := `{
-		"versions": [
-			"v1"
-		]
-	}`
-	compareErr := compareFields(t, "onesphere.Client.GetVersions", expected, actual)
-	if compareErr != nil {
-		t.Errorf("TestGetVersions Error: %s\n", compareErr)
+	if len(versions.Versions) == 0 {
+		t.Errorf("TestGetVersions expected at least one version, got %+v\n", versions)
 	}
 
 }
 
 func TestGetBillingAccounts(t *testing.T) {
-	actual, err := client.GetBillingAccounts("", "full")
+	billingAccounts, err := client.GetBillingAccounts("", "full")
 	if err != nil {
 		t.Errorf("TestGetBillingAccounts Error: %v\n", err)
 	}
 
-	expected := `{
-    "total": 0,
-    "start": 0,
-    "count": 0,
-    "members": [
-        {
-            "id": "abc",
-            "name": "",
-            "uri": "/rest/billing-accounts/abc",
-            "status": "",
-            "state": "",
-            "providerTypeUri": "/rest/provider-types/a",
-            "enrollmentNumber": "",
-            "directoryUri": "",
-            "created": "",
-            "modified": "",
-            "providers": [
-                {
-                    "id": "bcd",
-                    "name": "",
-                    "uri": "/rest/providers/bcd",
-                    "providerTypeUri": "/rest/provider-types/a",
-                    "status": "",
-                    "state": "",
-                    "projectUris": [
-                        "/rest/projects/cde"
-                    ],
-                    "billingAccountUri": "/rest/billing-accounts/bcd",
-                    "subscriptionId": "",
-                    "directoryUri": "",
-                    "tenantId": "",
-                    "uniqueName": "",
-                    "familyName": "",
-                    "givenName": "",
-                    "created": "",
-                    "modified": ""
-                }
-            ]
-        }
-    ]
-	}`
-	compareErr := compareFields(t, "onesphere.Client.GetBillingAccounts", expected, actual)
-	if compareErr != nil {
-		t.Errorf("TestGetBillingAccounts Error: %s\n", compareErr)
+	for _, billingAccount := range billingAccounts.Members {
+		if billingAccount.ID == "" || billingAccount.URI == "" {
+			t.Errorf("TestGetBillingAccounts billing account without id or uri: %+v\n", billingAccount)
+		}
 	}
 
 }
 
 func TestGetBillingAccount(t *testing.T) {
-
-	var billingAccounts struct {
-		Total   int `json:"total"`
-		Start   int `json:"start"`
-		Count   int `json:"count"`
-		Members []struct {
-			Id               string        `json:"id"`
-			Name             string        `json:"name"`
-			Uri              string        `json:"uri"`
-			Status           string        `json:"status"`
-			State            string        `json:"state"`
-			ProviderTypeUri  string        `json:"providerTypeUri"`
-			EnrollmentNumber string        `json:"enrollmentNumber"`
-			DirectoryUri     string        `json:"directoryUri"`
-			Created          string        `json:"created"`
-			Modified         string        `json:"modified"`
-			Providers        []interface{} `json:"providers"`
-		} `json:"members"`
-	}
-	if jsonRes, err := client.GetBillingAccounts("", "full"); err != nil {
+	billingAccounts, err := client.GetBillingAccounts("", "full")
+	if err != nil {
 		t.Errorf("TestGetBillingAccount Error: %s\n", err)
-	} else {
-		if jsonErr := json.Unmarshal([]byte(jsonRes), &billingAccounts); jsonErr != nil {
-			t.Errorf("TestGetBillingAccount Unmarshal Payload Error: %s\n", jsonErr)
-		}
+		return
+	}
+	if len(billingAccounts.Members) == 0 {
+		t.Skip("TestGetBillingAccount Could not find any Billing Accounts")
 	}
 
-	actual, err := client.GetBillingAccount(billingAccounts.Members[0].Id)
+	billingAccount, err := client.GetBillingAccount(billingAccounts.Members[0].ID)
 	if err != nil {
 		t.Errorf("TestGetBillingAccount Error: %v\n", err)
 	}
 
-	expected := `{
-		"id": "abc",
-		"name": "",
-		"uri": "/rest/billing-accounts/abc",
-		"status": "",
-		"state": "",
-		"providerTypeUri": "/rest/provider-types/a",
-		"enrollmentNumber": "",
-		"directoryUri": "",
-		"created": "",
-		"modified": ""
-	}`
-	compareErr := compareFields(t, "onesphere.Client.GetBillingAccount", expected, actual)
-	if compareErr != nil {
-		t.Errorf("TestGetBillingAccount Error: %s\n", compareErr)
+	if billingAccount.ID != billingAccounts.Members[0].ID {
+		t.Errorf("TestGetBillingAccount expected id %s, got %s\n", billingAccounts.Members[0].ID, billingAccount.ID)
 	}
 
 }
 
 func TestGetAzureLoginProperties(t *testing.T) {
-	actual, err := client.GetAzureLoginProperties()
+	properties, err := client.GetAzureLoginProperties()
 	if err != nil {
 		t.Errorf("TestGetAzureLoginProperties Error: %v\n", err)
 	}
 
-	expected := `{
-    "authHost": "https://host",
-    "clientId": "aaaaaaaa-bbbb-cccc-dddd-eeeeeeeeeeee",
-    "resource": "https://resource",
-    "authType": "oauth2",
-    "responseType": "code",
-    "responseMode": "query",
-    "prompt": "consent"
-	}`
-	compareErr := compareFields(t, "onesphere.Client.GetAzureLoginProperties", expected, actual)
-	if compareErr != nil {
-		t.Errorf("TestGetAzureLoginProperties Error: %s\n", compareErr)
+	if properties.AuthHost == "" || properties.ClientID == "" {
+		t.Errorf("TestGetAzureLoginProperties missing authHost or clientId: %+v\n", properties)
 	}
 
 }
 
 func TestGetRoles(t *testing.T) {
-	actual, err := client.GetRoles()
+	roles, err := client.GetRoles()
 	if err != nil {
 		t.Errorf("TestGetRoles Error: %v\n", err)
 	}
 
-	expected := `{
-    "total": 0,
-    "start": 0,
-    "count": 0,
-    "members": [
-        {
-            "id": "123",
-            "name": "",
-            "displayName": "",
-            "uri": "/rest/roles/123"
-        }
-    ]
-	}`
-	compareErr := compareFields(t, "onesphere.Client.GetRoles", expected, actual)
-	if compareErr != nil {
-		t.Errorf("TestGetRoles Error: %s\n", compareErr)
+	if len(roles.Members) == 0 {
+		t.Errorf("TestGetRoles expected at least one role\n")
+	}
+	for _, role := range roles.Members {
+		if role.ID == "" || role.URI == "" {
+			t.Errorf("TestGetRoles role without id or uri: %+v\n", role)
+		}
 	}
 
 }
 
 func TestGetSessionFull(t *testing.T) {
-	actual, err := client.GetSession("full")
+	session, err := client.GetSession("full")
 	if err != nil {
 		t.Errorf("TestGetSessionFull Error: %v\n", err)
 	}
 
-	expected := `{
-		"token":"",
-		"userUri":"/rest/users/1234",
-		"user":
-		  { "id":"1234",
-				"email":"",
-				"name":"",
-				"uri":"/rest/users/1234",
-				"role":"",
-				"isLocal":true
-			}
-		}`
-	compareErr := compareFields(t, "onesphere.Client.GetSessionFull(\"full\")", expected, actual)
-	if compareErr != nil {
-		t.Errorf("TestGetSessionFull Error: %s\n", compareErr)
+	if session.UserURI == "" || session.User == nil {
+		t.Errorf("TestGetSessionFull expected userUri and user with view full: %+v\n", session)
+	} else if session.User.URI != session.UserURI {
+		t.Errorf("TestGetSessionFull user uri %s does not match userUri %s\n", session.User.URI, session.UserURI)
 	}
 
 }
 
 func TestGetStatus(t *testing.T) {
-	actual, err := client.GetStatus()
+	status, err := client.GetStatus()
 	if err != nil {
 		t.Errorf("TestGetStatus Error: %v\n", err)
 	}
 
-	compareErr := comparePayload(t, "onesphere.Client.GetStatus()", `{"service":"OK","database":""}`, actual)
-	if compareErr != nil {
-		t.Errorf("TestGetStatus Error: %s\n", compareErr)
+	if status.Service != "OK" {
+		t.Errorf("TestGetStatus expected service OK, got %+v\n", status)
 	}
 
 }
-
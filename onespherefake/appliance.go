@@ -0,0 +1,98 @@
+// (C) Copyright 2018 Hewlett Packard Enterprise Development LP.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.  IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package onespherefake
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/HewlettPackard/hpe-onesphere-go"
+)
+
+func (c *Client) GetAppliances() (onesphere.ApplianceList, error) {
+	if err := c.call(context.Background(), "GetAppliances"); err != nil {
+		return onesphere.ApplianceList{}, err
+	}
+	return c.GetAppliancesByNameAndRegion("", "")
+}
+
+func (c *Client) GetAppliancesByNameAndRegion(name string, regionUri string) (onesphere.ApplianceList, error) {
+	var appliances onesphere.ApplianceList
+	if err := c.call(context.Background(), "GetAppliancesByNameAndRegion"); err != nil {
+		return appliances, err
+	}
+	err := c.list("/rest/appliances", filter{fields: map[string]string{"name": name, "regionUri": regionUri}}, &appliances)
+	return appliances, err
+}
+
+func (c *Client) GetAppliancesByName(name string) (onesphere.ApplianceList, error) {
+	if err := c.call(context.Background(), "GetAppliancesByName"); err != nil {
+		return onesphere.ApplianceList{}, err
+	}
+	return c.GetAppliancesByNameAndRegion(name, "")
+}
+
+func (c *Client) GetAppliancesByRegion(regionUri string) (onesphere.ApplianceList, error) {
+	if err := c.call(context.Background(), "GetAppliancesByRegion"); err != nil {
+		return onesphere.ApplianceList{}, err
+	}
+	return c.GetAppliancesByNameAndRegion("", regionUri)
+}
+
+func (c *Client) GetApplianceByID(id string) (onesphere.Appliance, error) {
+	var appliance onesphere.Appliance
+	if id == "" {
+		return appliance, fmt.Errorf("id must not be empty")
+	}
+	if err := c.call(context.Background(), "GetApplianceByID"); err != nil {
+		return appliance, err
+	}
+	err := c.get("/rest/appliances/"+id, &appliance)
+	return appliance, err
+}
+
+func (c *Client) CreateAppliance(applianceRequest onesphere.ApplianceRequest) (onesphere.Appliance, error) {
+	var appliance onesphere.Appliance
+	if err := c.call(context.Background(), "CreateAppliance"); err != nil {
+		return appliance, err
+	}
+	err := c.create("/rest/appliances", applianceRequest, &appliance)
+	return appliance, err
+}
+
+func (c *Client) UpdateAppliance(applianceId string, updates []*onesphere.PatchOp) (onesphere.Appliance, error) {
+	var appliance onesphere.Appliance
+	if applianceId == "" {
+		return appliance, fmt.Errorf("Appliance must have a non-empty ID")
+	}
+	if err := c.call(context.Background(), "UpdateAppliance"); err != nil {
+		return appliance, err
+	}
+	err := c.patch("/rest/appliances/"+applianceId, updates, &appliance)
+	return appliance, err
+}
+
+func (c *Client) DeleteAppliance(applianceId string) error {
+	if err := c.call(context.Background(), "DeleteAppliance"); err != nil {
+		return err
+	}
+	return c.remove("/rest/appliances/" + applianceId)
+}
@@ -0,0 +1,123 @@
+// (C) Copyright 2018 Hewlett Packard Enterprise Development LP.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.  IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package onespherefake
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/HewlettPackard/hpe-onesphere-go"
+)
+
+func (c *Client) GetBillingAccounts(query, view string) (onesphere.BillingAccountList, error) {
+	return c.GetBillingAccountsCtx(context.Background(), query, view)
+}
+
+func (c *Client) GetBillingAccountsCtx(ctx context.Context, query, view string) (onesphere.BillingAccountList, error) {
+	if err := c.call(ctx, "GetBillingAccounts"); err != nil {
+		return onesphere.BillingAccountList{}, err
+	}
+	return c.ListBillingAccountsCtx(ctx, onesphere.BillingAccountListOptions{
+		Query:       query,
+		ListOptions: onesphere.ListOptions{View: onesphere.View(view)},
+	})
+}
+
+func (c *Client) ListBillingAccounts(opts onesphere.BillingAccountListOptions) (onesphere.BillingAccountList, error) {
+	return c.ListBillingAccountsCtx(context.Background(), opts)
+}
+
+func (c *Client) ListBillingAccountsCtx(ctx context.Context, opts onesphere.BillingAccountListOptions) (onesphere.BillingAccountList, error) {
+	var billingAccounts onesphere.BillingAccountList
+	if err := c.call(ctx, "ListBillingAccounts"); err != nil {
+		return billingAccounts, err
+	}
+	err := c.list("/rest/billing-accounts", filter{query: opts.Query, opts: opts.ListOptions}, &billingAccounts)
+	return billingAccounts, err
+}
+
+func (c *Client) CreateBillingAccount(apiAccessKey, description, directoryUri, enrollmentNumber, name, providerTypeUri string) (onesphere.BillingAccount, error) {
+	return c.CreateBillingAccountCtx(context.Background(), apiAccessKey, description, directoryUri, enrollmentNumber, name, providerTypeUri)
+}
+
+// CreateBillingAccountCtx does not keep the write only apiAccessKey
+func (c *Client) CreateBillingAccountCtx(ctx context.Context, apiAccessKey, description, directoryUri, enrollmentNumber, name, providerTypeUri string) (onesphere.BillingAccount, error) {
+	var billingAccount onesphere.BillingAccount
+	if err := c.call(ctx, "CreateBillingAccount"); err != nil {
+		return billingAccount, err
+	}
+	err := c.create("/rest/billing-accounts", map[string]string{
+		"description":      description,
+		"directoryUri":     directoryUri,
+		"enrollmentNumber": enrollmentNumber,
+		"name":             name,
+		"providerTypeUri":  providerTypeUri,
+	}, &billingAccount)
+	return billingAccount, err
+}
+
+func (c *Client) GetBillingAccount(id string) (onesphere.BillingAccount, error) {
+	return c.GetBillingAccountCtx(context.Background(), id)
+}
+
+func (c *Client) GetBillingAccountCtx(ctx context.Context, id string) (onesphere.BillingAccount, error) {
+	var billingAccount onesphere.BillingAccount
+	if id == "" {
+		return billingAccount, fmt.Errorf("id must not be empty")
+	}
+	if err := c.call(ctx, "GetBillingAccount"); err != nil {
+		return billingAccount, err
+	}
+	err := c.get("/rest/billing-accounts/"+id, &billingAccount)
+	return billingAccount, err
+}
+
+func (c *Client) DeleteBillingAccount(id string) error {
+	return c.DeleteBillingAccountCtx(context.Background(), id)
+}
+
+func (c *Client) DeleteBillingAccountCtx(ctx context.Context, id string) error {
+	if id == "" {
+		return fmt.Errorf("id must not be empty")
+	}
+	if err := c.call(ctx, "DeleteBillingAccount"); err != nil {
+		return err
+	}
+	return c.remove("/rest/billing-accounts/" + id)
+}
+
+func (c *Client) UpdateBillingAccount(id string, patchPayload []*onesphere.PatchOp) (onesphere.BillingAccount, error) {
+	return c.UpdateBillingAccountCtx(context.Background(), id, patchPayload)
+}
+
+func (c *Client) UpdateBillingAccountCtx(ctx context.Context, id string, patchPayload []*onesphere.PatchOp) (onesphere.BillingAccount, error) {
+	var billingAccount onesphere.BillingAccount
+
+	validOps := []string{"add", "replace", "remove"}
+	if op, ok := invalidOp(patchPayload, validOps...); ok {
+		return billingAccount, fmt.Errorf("UpdateBillingAccount received invalid Op in patchBodies.\nReceived Op: %s\nValid Ops: %v\n", op, validOps)
+	}
+	if err := c.call(ctx, "UpdateBillingAccount"); err != nil {
+		return billingAccount, err
+	}
+	err := c.patch("/rest/billing-accounts/"+id, patchPayload, &billingAccount)
+	return billingAccount, err
+}
@@ -0,0 +1,111 @@
+// (C) Copyright 2018 Hewlett Packard Enterprise Development LP.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.  IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package onespherefake
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/HewlettPackard/hpe-onesphere-go"
+)
+
+func (c *Client) GetCatalogs(userQuery, view string) (onesphere.CatalogList, error) {
+	var catalogs onesphere.CatalogList
+	if err := c.call(context.Background(), "GetCatalogs"); err != nil {
+		return catalogs, err
+	}
+	err := c.list("/rest/catalogs", filter{userQuery: userQuery}, &catalogs)
+	return catalogs, err
+}
+
+func (c *Client) GetCatalogByID(id, view string) (onesphere.Catalog, error) {
+	return c.GetCatalogByIDCtx(context.Background(), id, view)
+}
+
+func (c *Client) GetCatalogByIDCtx(ctx context.Context, id, view string) (onesphere.Catalog, error) {
+	var catalog onesphere.Catalog
+	if id == "" {
+		return catalog, fmt.Errorf("id must not be empty")
+	}
+	if err := c.call(ctx, "GetCatalogByID"); err != nil {
+		return catalog, err
+	}
+	err := c.get("/rest/catalogs/"+id, &catalog)
+	return catalog, err
+}
+
+func (c *Client) CreateCatalog(catalogRequest onesphere.CatalogRequest) (onesphere.Catalog, error) {
+	var catalog onesphere.Catalog
+	if err := c.call(context.Background(), "CreateCatalog"); err != nil {
+		return catalog, err
+	}
+	err := c.create("/rest/catalogs", catalogRequest, &catalog)
+	return catalog, err
+}
+
+func (c *Client) UpdateCatalog(catalogId string, updates []*onesphere.PatchOp) (onesphere.Catalog, error) {
+	var catalog onesphere.Catalog
+	if err := c.call(context.Background(), "UpdateCatalog"); err != nil {
+		return catalog, err
+	}
+	err := c.patch("/rest/catalogs/"+catalogId, updates, &catalog)
+	return catalog, err
+}
+
+func (c *Client) DeleteCatalog(catalogId string) error {
+	return c.DeleteCatalogCtx(context.Background(), catalogId)
+}
+
+// DeleteCatalogCtx returns a ProtectedError for a protected catalog, like Client
+func (c *Client) DeleteCatalogCtx(ctx context.Context, catalogId string) error {
+	if err := c.call(ctx, "DeleteCatalog"); err != nil {
+		return err
+	}
+	var catalog onesphere.Catalog
+	if err := c.get("/rest/catalogs/"+catalogId, &catalog); err != nil {
+		return err
+	}
+	if catalog.Protected {
+		return &onesphere.ProtectedError{Resource: "catalog", ID: catalogId}
+	}
+	return c.remove("/rest/catalogs/" + catalogId)
+}
+
+// ActionCatalog only checks that the catalog exists
+func (c *Client) ActionCatalog(catalog onesphere.Catalog, actionType string) error {
+	if catalog.ID == "" {
+		return fmt.Errorf("Catalog must have a non-empty ID")
+	}
+	if err := c.call(context.Background(), "ActionCatalog"); err != nil {
+		return err
+	}
+	return c.exists(http.MethodPost, "/rest/catalogs/"+catalog.ID)
+}
+
+func (c *Client) GetCatalogTypes() (onesphere.CatalogTypeList, error) {
+	var catalogTypes onesphere.CatalogTypeList
+	if err := c.call(context.Background(), "GetCatalogTypes"); err != nil {
+		return catalogTypes, err
+	}
+	err := c.list("/rest/catalog-types", filter{}, &catalogTypes)
+	return catalogTypes, err
+}
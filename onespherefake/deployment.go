@@ -0,0 +1,212 @@
+// (C) Copyright 2018 Hewlett Packard Enterprise Development LP.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.  IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package onespherefake
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/HewlettPackard/hpe-onesphere-go"
+)
+
+func (c *Client) GetDeployments(query string, userQuery string, sort string) (onesphere.DeploymentList, error) {
+	return c.GetDeploymentsCtx(context.Background(), query, userQuery, sort)
+}
+
+func (c *Client) GetDeploymentsCtx(ctx context.Context, query string, userQuery string, sort string) (onesphere.DeploymentList, error) {
+	if err := c.call(ctx, "GetDeployments"); err != nil {
+		return onesphere.DeploymentList{}, err
+	}
+	return c.ListDeploymentsCtx(ctx, onesphere.DeploymentListOptions{
+		Query:       query,
+		UserQuery:   userQuery,
+		ListOptions: onesphere.ListOptions{Sort: sort},
+	})
+}
+
+func (c *Client) ListDeployments(opts onesphere.DeploymentListOptions) (onesphere.DeploymentList, error) {
+	return c.ListDeploymentsCtx(context.Background(), opts)
+}
+
+func (c *Client) ListDeploymentsCtx(ctx context.Context, opts onesphere.DeploymentListOptions) (onesphere.DeploymentList, error) {
+	var deployments onesphere.DeploymentList
+	if err := c.call(ctx, "ListDeployments"); err != nil {
+		return deployments, err
+	}
+	err := c.list("/rest/deployments", filter{
+		query:     opts.Query,
+		userQuery: opts.UserQuery,
+		opts:      opts.ListOptions,
+	}, &deployments)
+	return deployments, err
+}
+
+func (c *Client) ForEachDeployment(opts onesphere.DeploymentListOptions, fn func(onesphere.Deployment) error) error {
+	return c.ForEachDeploymentCtx(context.Background(), opts, fn)
+}
+
+func (c *Client) ForEachDeploymentCtx(ctx context.Context, opts onesphere.DeploymentListOptions, fn func(onesphere.Deployment) error) error {
+	if err := c.call(ctx, "ForEachDeployment"); err != nil {
+		return err
+	}
+	opts.Count = 0
+	deployments, err := c.ListDeploymentsCtx(ctx, opts)
+	if err != nil {
+		return err
+	}
+	return visitAll(len(deployments.Members), func(i int) error { return fn(deployments.Members[i]) })
+}
+
+func (c *Client) GetDeploymentByID(id string) (onesphere.Deployment, error) {
+	return c.GetDeploymentByIDCtx(context.Background(), id)
+}
+
+func (c *Client) GetDeploymentByIDCtx(ctx context.Context, id string) (onesphere.Deployment, error) {
+	var deployment onesphere.Deployment
+	if id == "" {
+		return deployment, fmt.Errorf("id must not be empty")
+	}
+	if err := c.call(ctx, "GetDeploymentByID"); err != nil {
+		return deployment, err
+	}
+	err := c.get("/rest/deployments/"+id, &deployment)
+	return deployment, err
+}
+
+func (c *Client) GetDeploymentsByName(name string) (onesphere.DeploymentList, error) {
+	return c.GetDeploymentsByNameCtx(context.Background(), name)
+}
+
+func (c *Client) GetDeploymentsByNameCtx(ctx context.Context, name string) (onesphere.DeploymentList, error) {
+	if err := c.call(ctx, "GetDeploymentsByName"); err != nil {
+		return onesphere.DeploymentList{}, err
+	}
+	return c.GetDeploymentsCtx(ctx, "", name, "name:asc")
+}
+
+func (c *Client) GetDeploymentByName(name string) (onesphere.Deployment, error) {
+	return c.GetDeploymentByNameCtx(context.Background(), name)
+}
+
+func (c *Client) GetDeploymentByNameCtx(ctx context.Context, name string) (onesphere.Deployment, error) {
+	if name == "" {
+		return onesphere.Deployment{}, fmt.Errorf("name must not be empty")
+	}
+	if err := c.call(ctx, "GetDeploymentByName"); err != nil {
+		return onesphere.Deployment{}, err
+	}
+	deployments, err := c.ListDeploymentsCtx(ctx, onesphere.DeploymentListOptions{})
+	if err != nil {
+		return onesphere.Deployment{}, err
+	}
+	names, uris := make([]string, len(deployments.Members)), make([]string, len(deployments.Members))
+	for i, deployment := range deployments.Members {
+		names[i], uris[i] = deployment.Name, deployment.URI
+	}
+	i, err := matchName("deployment", name, names, uris)
+	if err != nil {
+		return onesphere.Deployment{}, err
+	}
+	return deployments.Members[i], nil
+}
+
+func (c *Client) CreateDeployment(deploymentRequest onesphere.DeploymentRequest) (onesphere.Deployment, error) {
+	return c.CreateDeploymentCtx(context.Background(), deploymentRequest)
+}
+
+func (c *Client) CreateDeploymentCtx(ctx context.Context, deploymentRequest onesphere.DeploymentRequest) (onesphere.Deployment, error) {
+	var deployment onesphere.Deployment
+	if err := c.call(ctx, "CreateDeployment"); err != nil {
+		return deployment, err
+	}
+	err := c.create("/rest/deployments", deploymentRequest, &deployment)
+	return deployment, err
+}
+
+func (c *Client) UpdateDeployment(deploymentId string, updates []*onesphere.PatchOp) (onesphere.Deployment, error) {
+	return c.UpdateDeploymentCtx(context.Background(), deploymentId, updates)
+}
+
+func (c *Client) UpdateDeploymentCtx(ctx context.Context, deploymentId string, updates []*onesphere.PatchOp) (onesphere.Deployment, error) {
+	var deployment onesphere.Deployment
+	if err := c.call(ctx, "UpdateDeployment"); err != nil {
+		return deployment, err
+	}
+	err := c.patch("/rest/deployments/"+deploymentId, updates, &deployment)
+	return deployment, err
+}
+
+func (c *Client) DeleteDeployment(deploymentId string) error {
+	return c.DeleteDeploymentCtx(context.Background(), deploymentId)
+}
+
+func (c *Client) DeleteDeploymentCtx(ctx context.Context, deploymentId string) error {
+	if err := c.call(ctx, "DeleteDeployment"); err != nil {
+		return err
+	}
+	return c.remove("/rest/deployments/" + deploymentId)
+}
+
+// ActionDeployment only checks that the deployment exists
+func (c *Client) ActionDeployment(deployment onesphere.Deployment, actionType string, force bool) error {
+	return c.ActionDeploymentCtx(context.Background(), deployment, actionType, force)
+}
+
+func (c *Client) ActionDeploymentCtx(ctx context.Context, deployment onesphere.Deployment, actionType string, force bool) error {
+	if deployment.ID == "" {
+		return fmt.Errorf("Deployment must have a non-empty ID")
+	}
+	if err := c.call(ctx, "ActionDeployment"); err != nil {
+		return err
+	}
+	return c.exists(http.MethodPost, "/rest/deployments/"+deployment.ID)
+}
+
+// GetDeploymentConsole returns the string seeded at the console uri of the deployment
+func (c *Client) GetDeploymentConsole(deployment onesphere.Deployment) (string, error) {
+	return c.GetDeploymentConsoleCtx(context.Background(), deployment)
+}
+
+func (c *Client) GetDeploymentConsoleCtx(ctx context.Context, deployment onesphere.Deployment) (string, error) {
+	if deployment.ID == "" {
+		return "", fmt.Errorf("Deployment must have a non-empty ID")
+	}
+	if err := c.call(ctx, "GetDeploymentConsole"); err != nil {
+		return "", err
+	}
+	return c.subresource("/rest/deployments/"+deployment.ID, "/console")
+}
+
+// GetDeploymentKubeConfig returns the string seeded at the kubeconfig uri of the deployment
+func (c *Client) GetDeploymentKubeConfig(deployment onesphere.Deployment) (string, error) {
+	return c.GetDeploymentKubeConfigCtx(context.Background(), deployment)
+}
+
+func (c *Client) GetDeploymentKubeConfigCtx(ctx context.Context, deployment onesphere.Deployment) (string, error) {
+	if deployment.ID == "" {
+		return "", fmt.Errorf("Deployment must have a non-empty ID")
+	}
+	if err := c.call(ctx, "GetDeploymentKubeConfig"); err != nil {
+		return "", err
+	}
+	return c.subresource("/rest/deployments/"+deployment.ID, "/kubeconfig")
+}
@@ -0,0 +1,90 @@
+// (C) Copyright 2018 Hewlett Packard Enterprise Development LP.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.  IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package onespherefake
+
+import (
+	"context"
+	"time"
+
+	"github.com/HewlettPackard/hpe-onesphere-go"
+	"github.com/HewlettPackard/hpe-onesphere-go/internal/jsondoc"
+)
+
+func (c *Client) GetEvents(resourceUri string) (onesphere.EventList, error) {
+	return c.GetEventsCtx(context.Background(), resourceUri)
+}
+
+func (c *Client) GetEventsCtx(ctx context.Context, resourceUri string) (onesphere.EventList, error) {
+	if err := c.call(ctx, "GetEvents"); err != nil {
+		return onesphere.EventList{}, err
+	}
+	return c.ListEventsCtx(ctx, onesphere.EventListOptions{ResourceURI: resourceUri})
+}
+
+// ListEvents filters the seeded events on their resource uri and creation time
+func (c *Client) ListEvents(opts onesphere.EventListOptions) (onesphere.EventList, error) {
+	return c.ListEventsCtx(context.Background(), opts)
+}
+
+func (c *Client) ListEventsCtx(ctx context.Context, opts onesphere.EventListOptions) (onesphere.EventList, error) {
+	var events onesphere.EventList
+	if err := c.call(ctx, "ListEvents"); err != nil {
+		return events, err
+	}
+	err := c.list("/rest/events", filter{
+		fields: map[string]string{"resourceUri": opts.ResourceURI},
+		match:  createdWithin(opts.Since, opts.Until),
+		opts:   opts.ListOptions,
+	}, &events)
+	return events, err
+}
+
+func (c *Client) ForEachEvent(opts onesphere.EventListOptions, fn func(onesphere.Event) error) error {
+	return c.ForEachEventCtx(context.Background(), opts, fn)
+}
+
+func (c *Client) ForEachEventCtx(ctx context.Context, opts onesphere.EventListOptions, fn func(onesphere.Event) error) error {
+	if err := c.call(ctx, "ForEachEvent"); err != nil {
+		return err
+	}
+	opts.Count = 0
+	events, err := c.ListEventsCtx(ctx, opts)
+	if err != nil {
+		return err
+	}
+	return visitAll(len(events.Members), func(i int) error { return fn(events.Members[i]) })
+}
+
+// createdWithin matches the objects created between since and until,
+// a zero bound is open
+func createdWithin(since, until time.Time) func(object) bool {
+	return func(obj object) bool {
+		if since.IsZero() && until.IsZero() {
+			return true
+		}
+		value, _ := jsondoc.Lookup(obj)("created")
+		created, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return false
+		}
+		return (since.IsZero() || !created.Before(since)) && (until.IsZero() || created.Before(until))
+	}
+}
@@ -0,0 +1,559 @@
+// (C) Copyright 2018 Hewlett Packard Enterprise Development LP.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.  IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+// Package onespherefake provides Client, an in-memory implementation of
+// onesphere.API for the unit tests of code depending on the OneSphere client.
+//
+//	fake := onespherefake.New()
+//	fake.Seed(onesphere.Zone{ID: "z1", Name: "prod"})
+//	service := NewService(fake) // accepts an onesphere.API or onesphere.ZonesAPI
+//
+// Resources are kept as the JSON documents OneSphere returns, keyed by uri,
+// so list filters use the query syntax and updates apply JSON Patch operations.
+// Calls on missing resources return an *onesphere.APIError with status 404.
+package onespherefake
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/HewlettPackard/hpe-onesphere-go"
+	"github.com/HewlettPackard/hpe-onesphere-go/internal/jsondoc"
+	"github.com/HewlettPackard/hpe-onesphere-go/query"
+)
+
+// collections maps the seeded types to the uri of their collection
+var collections = map[reflect.Type]string{
+	reflect.TypeOf(onesphere.Appliance{}):             "/rest/appliances",
+	reflect.TypeOf(onesphere.AzureSubscription{}):     "/rest/onboarding/azure/subscriptions",
+	reflect.TypeOf(onesphere.BillingAccount{}):        "/rest/billing-accounts",
+	reflect.TypeOf(onesphere.Catalog{}):               "/rest/catalogs",
+	reflect.TypeOf(onesphere.CatalogType{}):           "/rest/catalog-types",
+	reflect.TypeOf(onesphere.Deployment{}):            "/rest/deployments",
+	reflect.TypeOf(onesphere.Event{}):                 "/rest/events",
+	reflect.TypeOf(onesphere.KeyPair{}):               "/rest/keypairs",
+	reflect.TypeOf(onesphere.Membership{}):            "/rest/memberships",
+	reflect.TypeOf(onesphere.Metric{}):                "/rest/metrics",
+	reflect.TypeOf(onesphere.Network{}):               "/rest/networks",
+	reflect.TypeOf(onesphere.Project{}):               "/rest/projects",
+	reflect.TypeOf(onesphere.Provider{}):              "/rest/providers",
+	reflect.TypeOf(onesphere.ProviderType{}):          "/rest/provider-types",
+	reflect.TypeOf(onesphere.Rate{}):                  "/rest/rates",
+	reflect.TypeOf(onesphere.Region{}):                "/rest/regions",
+	reflect.TypeOf(onesphere.Role{}):                  "/rest/roles",
+	reflect.TypeOf(onesphere.Server{}):                "/rest/servers",
+	reflect.TypeOf(onesphere.Tag{}):                   "/rest/tags",
+	reflect.TypeOf(onesphere.TagKey{}):                "/rest/tag-keys",
+	reflect.TypeOf(onesphere.User{}):                  "/rest/users",
+	reflect.TypeOf(onesphere.VirtualMachineProfile{}): "/rest/virtual-machine-profiles",
+	reflect.TypeOf(onesphere.Volume{}):                "/rest/volumes",
+	reflect.TypeOf(onesphere.Zone{}):                  "/rest/zones",
+	reflect.TypeOf(onesphere.ZoneType{}):              "/rest/zone-types",
+}
+
+// singletons maps the seeded types that exist once to their uri
+var singletons = map[reflect.Type]string{
+	reflect.TypeOf(onesphere.Account{}):              "/rest/account",
+	reflect.TypeOf(onesphere.AzureLoginProperties{}): "/rest/onboarding/azure/properties",
+	reflect.TypeOf(onesphere.AzureProviderInfo{}):    "/rest/onboarding/azure/provider-info",
+	reflect.TypeOf(onesphere.Session{}):              "/rest/session",
+	reflect.TypeOf(onesphere.SessionIdp{}):           "/rest/session/idp",
+	reflect.TypeOf(onesphere.Status{}):               "/rest/status",
+	reflect.TypeOf(onesphere.Versions{}):             "/rest/about/versions",
+}
+
+// object is a resource decoded from its JSON document
+type object = map[string]interface{}
+
+// Client is an in-memory onesphere.API, the zero value is not usable, use New
+type Client struct {
+	mu     sync.Mutex
+	lastID int
+	docs   map[string]interface{}
+	order  []string
+	errs   map[string]error
+}
+
+var _ onesphere.API = (*Client)(nil)
+
+// New returns an empty Client
+func New() *Client {
+	return &Client{
+		docs: map[string]interface{}{},
+		errs: map[string]error{},
+	}
+}
+
+// Seed stores resources such as onesphere.Zone or onesphere.Status.
+// Resources keep their uri when it is set, otherwise they are stored in the
+// collection of their type and get an id when it is empty.
+func (c *Client) Seed(resources ...interface{}) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, resource := range resources {
+		doc, err := decode(resource)
+		if err != nil {
+			return err
+		}
+
+		t := reflect.Indirect(reflect.ValueOf(resource)).Type()
+		obj, _ := doc.(object)
+		if uri, ok := obj["uri"].(string); ok && uri != "" {
+			if id, _ := obj["id"].(string); id == "" {
+				obj["id"] = uri[strings.LastIndex(uri, "/")+1:]
+			}
+			c.put(uri, obj)
+			continue
+		}
+		if uri, ok := singletons[t]; ok {
+			c.put(uri, doc)
+			continue
+		}
+		collection, ok := collections[t]
+		if !ok || obj == nil {
+			return fmt.Errorf("onespherefake: set the uri of the %T to seed", resource)
+		}
+		c.insert(collection, obj)
+	}
+	return nil
+}
+
+// SeedAt stores value at uri, it may be a subresource such as a zone connection
+// or the string returned by calls such as GetDeploymentConsole
+func (c *Client) SeedAt(uri string, value interface{}) error {
+	doc, err := decode(value)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.put(uri, doc)
+	return nil
+}
+
+// SetError makes the calls of method, such as "CreateZone", return err until
+// it is cleared with a nil error. The Ctx variants share the error of their method.
+func (c *Client) SetError(method string, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	method = strings.TrimSuffix(method, "Ctx")
+	if err == nil {
+		delete(c.errs, method)
+		return
+	}
+	c.errs[method] = err
+}
+
+// call returns the error a call of method must fail with, if any
+func (c *Client) call(ctx context.Context, method string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.errs[method]
+}
+
+// decode returns the JSON document of v
+func decode(v interface{}) (interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// encode decodes doc into out
+func encode(doc interface{}, out interface{}) error {
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, out)
+}
+
+func (c *Client) put(uri string, doc interface{}) {
+	if _, ok := c.docs[uri]; !ok {
+		c.order = append(c.order, uri)
+	}
+	c.docs[uri] = doc
+}
+
+// insert adds obj to collection, assigning its id and uri
+func (c *Client) insert(collection string, obj object) string {
+	id, _ := obj["id"].(string)
+	if id == "" {
+		c.lastID++
+		id = fmt.Sprintf("00000000-0000-0000-0000-%012d", c.lastID)
+		obj["id"] = id
+	}
+	uri := collection + "/" + id
+	obj["uri"] = uri
+	c.put(uri, obj)
+	return uri
+}
+
+// members returns the objects of collection in insertion order
+func (c *Client) members(collection string) []object {
+	var objs []object
+	for _, uri := range c.order {
+		id := strings.TrimPrefix(uri, collection+"/")
+		if id == uri || id == "" || strings.Contains(id, "/") {
+			continue
+		}
+		if obj, ok := c.docs[uri].(object); ok {
+			objs = append(objs, obj)
+		}
+	}
+	return objs
+}
+
+// create stores the document of request in collection and decodes it into out
+func (c *Client) create(collection string, request interface{}, out interface{}) error {
+	doc, err := decode(request)
+	if err != nil {
+		return err
+	}
+	obj, ok := doc.(object)
+	if !ok {
+		return badRequest(http.MethodPost, collection, "the request must be an object")
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.insert(collection, obj)
+	return encode(obj, out)
+}
+
+// get decodes the document at uri into out
+func (c *Client) get(uri string, out interface{}) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	doc, ok := c.docs[uri]
+	if !ok {
+		return notFound(http.MethodGet, uri)
+	}
+	return encode(doc, out)
+}
+
+// exists returns a not found error unless there is a document at uri
+func (c *Client) exists(method, uri string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.docs[uri]; !ok {
+		return notFound(method, uri)
+	}
+	return nil
+}
+
+// remove deletes the document at uri and its subresources
+func (c *Client) remove(uri string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.docs[uri]; !ok {
+		return notFound(http.MethodDelete, uri)
+	}
+	for key := range c.docs {
+		if key == uri || strings.HasPrefix(key, uri+"/") {
+			delete(c.docs, key)
+		}
+	}
+	order := c.order[:0]
+	for _, key := range c.order {
+		if _, ok := c.docs[key]; ok {
+			order = append(order, key)
+		}
+	}
+	c.order = order
+	return nil
+}
+
+// patch applies ops to the object at uri and decodes the result into out
+func (c *Client) patch(uri string, ops []*onesphere.PatchOp, out interface{}) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	obj, ok := c.docs[uri].(object)
+	if !ok {
+		return notFound(http.MethodPatch, uri)
+	}
+
+	// apply to a copy so that a failing op leaves the resource unchanged
+	var patched interface{}
+	if err := encode(obj, &patched); err != nil {
+		return err
+	}
+	for _, op := range ops {
+		if op == nil {
+			continue
+		}
+		value, err := decode(op.Value)
+		if err != nil {
+			return err
+		}
+		if patched, err = jsondoc.Patch(patched, op.Op, op.Path, value); err != nil {
+			return badRequest(http.MethodPatch, uri, err.Error())
+		}
+	}
+	patchedObj, ok := patched.(object)
+	if !ok {
+		return badRequest(http.MethodPatch, uri, "the patched resource must remain an object")
+	}
+	// the identity of a resource cannot be patched
+	patchedObj["id"], patchedObj["uri"] = obj["id"], obj["uri"]
+	c.docs[uri] = patchedObj
+	return encode(patchedObj, out)
+}
+
+// merge sets the non-empty fields of update on the object at uri and decodes the result into out
+func (c *Client) merge(uri string, update interface{}, out interface{}) error {
+	doc, err := decode(update)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	obj, ok := c.docs[uri].(object)
+	if !ok {
+		return notFound(http.MethodPut, uri)
+	}
+	fields, _ := doc.(object)
+	for field, value := range fields {
+		if !jsondoc.IsEmpty(value) && field != "id" && field != "uri" {
+			obj[field] = value
+		}
+	}
+	return encode(obj, out)
+}
+
+// filter selects the members of a collection
+type filter struct {
+	query     string
+	userQuery string
+	fields    map[string]string
+	match     func(object) bool
+	opts      onesphere.ListOptions
+}
+
+// list decodes the members of collection matching f into out, a list type
+// with total and members fields and optionally start and count
+func (c *Client) list(collection string, f filter, out interface{}) error {
+	q, err := query.Parse(f.query)
+	if err != nil {
+		return badRequest(http.MethodGet, collection, err.Error())
+	}
+	if f.opts.Start < 0 || f.opts.Count < 0 {
+		return badRequest(http.MethodGet, collection, "start and count must not be negative")
+	}
+
+	c.mu.Lock()
+	var matched []object
+	for _, obj := range c.members(collection) {
+		if q.Match(jsondoc.Lookup(obj)) && jsondoc.ContainsText(obj, f.userQuery) && matchesFields(obj, f.fields) &&
+			(f.match == nil || f.match(obj)) {
+			// the stored documents are updated in place, sort and encode a copy
+			doc, err := decode(obj)
+			if err != nil {
+				c.mu.Unlock()
+				return err
+			}
+			matched = append(matched, doc.(object))
+		}
+	}
+	c.mu.Unlock()
+
+	if f.opts.Sort != "" {
+		jsondoc.Sort(matched, f.opts.Sort)
+	}
+
+	total := len(matched)
+	start := f.opts.Start
+	if start > total {
+		start = total
+	}
+	end := total
+	if f.opts.Count > 0 && start+f.opts.Count < total {
+		end = start + f.opts.Count
+	}
+	members := matched[start:end]
+	if members == nil {
+		members = []object{}
+	}
+
+	return encode(map[string]interface{}{
+		"total":   total,
+		"start":   start,
+		"count":   len(members),
+		"members": members,
+	}, out)
+}
+
+// matchesFields reports whether obj has the non-empty values of fields
+func matchesFields(obj object, fields map[string]string) bool {
+	get := jsondoc.Lookup(obj)
+	for field, want := range fields {
+		if want == "" {
+			continue
+		}
+		if got, _ := get(field); got != want {
+			return false
+		}
+	}
+	return true
+}
+
+func notFound(method, uri string) error {
+	return &onesphere.APIError{
+		ErrorBody: onesphere.ErrorBody{
+			ErrorCode: "NOT_FOUND",
+			Message:   "The resource " + uri + " was not found.",
+		},
+		StatusCode: http.StatusNotFound,
+		Method:     method,
+		Path:       uri,
+	}
+}
+
+func badRequest(method, uri, details string) error {
+	return &onesphere.APIError{
+		ErrorBody: onesphere.ErrorBody{
+			ErrorCode: "BAD_REQUEST",
+			Message:   "The request is invalid.",
+			Details:   details,
+		},
+		StatusCode: http.StatusBadRequest,
+		Method:     method,
+		Path:       uri,
+	}
+}
+
+// visitAll calls visit for each member like the ForEach methods do
+func visitAll(n int, visit func(i int) error) error {
+	for i := 0; i < n; i++ {
+		if err := visit(i); err != nil {
+			if err == onesphere.StopIteration {
+				return nil
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// matchName returns the index of the only candidate named name,
+// or the errors returned by the ByName lookups
+func matchName(resource, name string, names, uris []string) (int, error) {
+	found := -1
+	var matched []string
+	for i := range names {
+		if names[i] == name {
+			found = i
+			matched = append(matched, uris[i])
+		}
+	}
+
+	switch len(matched) {
+	case 0:
+		return -1, &onesphere.NameNotFoundError{Resource: resource, Name: name}
+	case 1:
+		return found, nil
+	}
+	return -1, &onesphere.AmbiguousNameError{Resource: resource, Name: name, URIs: matched}
+}
+
+// subresource returns the string seeded at the uri of parent followed by suffix,
+// an empty string when none was seeded
+func (c *Client) subresource(parent, suffix string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.docs[parent]; !ok {
+		return "", notFound(http.MethodGet, parent+suffix)
+	}
+	s, _ := c.docs[parent+suffix].(string)
+	return s, nil
+}
+
+// store saves the document of v at uri, below the existing resource parent,
+// and decodes it into out
+func (c *Client) store(parent, uri string, v interface{}, out interface{}) error {
+	doc, err := decode(v)
+	if err != nil {
+		return err
+	}
+	obj, ok := doc.(object)
+	if !ok {
+		return badRequest(http.MethodPost, uri, "the request must be an object")
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.docs[parent]; !ok {
+		return notFound(http.MethodPost, uri)
+	}
+	obj["uri"] = uri
+	c.put(uri, obj)
+	return encode(obj, out)
+}
+
+// invalidOp returns the first op of ops that is not allowed
+func invalidOp(ops []*onesphere.PatchOp, allowed ...string) (string, bool) {
+	for _, op := range ops {
+		if op == nil {
+			continue
+		}
+		valid := false
+		for _, a := range allowed {
+			valid = valid || op.Op == a
+		}
+		if !valid {
+			return op.Op, true
+		}
+	}
+	return "", false
+}
+
+// Disconnect only returns the error set with SetError, the Client keeps its resources
+func (c *Client) Disconnect() error {
+	return c.call(context.Background(), "Disconnect")
+}
+
+// Close does nothing
+func (c *Client) Close() {}
@@ -0,0 +1,228 @@
+package onespherefake
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/HewlettPackard/hpe-onesphere-go"
+	"github.com/HewlettPackard/hpe-onesphere-go/query"
+	"github.com/stretchr/testify/assert"
+)
+
+// zoneNames is the kind of code the fake is meant to test, it only depends on ZonesAPI
+func zoneNames(api onesphere.ZonesAPI, regionURI string) ([]string, error) {
+	var names []string
+	err := api.ForEachZone(onesphere.ZoneListOptions{RegionURI: regionURI}, func(zone onesphere.Zone) error {
+		names = append(names, zone.Name)
+		return nil
+	})
+	return names, err
+}
+
+func newSeededClient(t *testing.T) *Client {
+	c := New()
+	err := c.Seed(
+		onesphere.Zone{ID: "z1", Name: "prod", RegionURI: "/rest/regions/r1", Status: "Ok"},
+		onesphere.Zone{ID: "z2", Name: "dev", RegionURI: "/rest/regions/r1"},
+		onesphere.Zone{ID: "z3", Name: "dev", RegionURI: "/rest/regions/r2"},
+		onesphere.Status{Service: "OK", Database: "OK"},
+		onesphere.Service{ID: "s1", Name: "ubuntu", URI: "/rest/services/s1"},
+	)
+	assert.NoError(t, err)
+	return c
+}
+
+func TestSeedAndGet(t *testing.T) {
+	c := newSeededClient(t)
+
+	zone, err := c.GetZoneByID("z1")
+	assert.NoError(t, err)
+	assert.Equal(t, "/rest/zones/z1", zone.URI)
+	assert.Equal(t, "prod", zone.Name)
+
+	status, err := c.GetStatus()
+	assert.NoError(t, err)
+	assert.Equal(t, "OK", status.Service)
+
+	service, err := c.GetServiceByID("s1")
+	assert.NoError(t, err)
+	assert.Equal(t, "ubuntu", service.Name)
+
+	_, err = c.GetZoneByID("missing")
+	assert.True(t, onesphere.IsNotFound(err))
+
+	assert.Error(t, c.Seed(onesphere.Service{Name: "no uri"}))
+}
+
+func TestListFilters(t *testing.T) {
+	c := newSeededClient(t)
+
+	names, err := zoneNames(c, "/rest/regions/r1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"prod", "dev"}, names)
+
+	zones, err := c.ListZones(onesphere.ZoneListOptions{Query: query.Eq("name", "dev").String()})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, zones.Total)
+
+	zones, err = c.ListZones(onesphere.ZoneListOptions{ListOptions: onesphere.ListOptions{Sort: "name:asc", Start: 1, Count: 1}})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, zones.Total)
+	if assert.Len(t, zones.Members, 1) {
+		assert.Equal(t, "z3", zones.Members[0].ID)
+	}
+
+	_, err = c.ListZones(onesphere.ZoneListOptions{Query: "name EQ"})
+	var apiErr *onesphere.APIError
+	if assert.True(t, errors.As(err, &apiErr)) {
+		assert.Equal(t, 400, apiErr.StatusCode)
+	}
+
+	var visited int
+	err = c.ForEachZone(onesphere.ZoneListOptions{}, func(zone onesphere.Zone) error {
+		visited++
+		return onesphere.StopIteration
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, visited)
+
+	noon := time.Date(2018, 5, 1, 12, 0, 0, 0, time.UTC)
+	assert.NoError(t, c.Seed(
+		onesphere.Event{ID: "e1", ResourceURI: "/rest/zones/z1", Created: noon.Add(-time.Hour)},
+		onesphere.Event{ID: "e2", ResourceURI: "/rest/zones/z1", Created: noon.Add(time.Hour)},
+	))
+	events, err := c.ListEvents(onesphere.EventListOptions{ResourceURI: "/rest/zones/z1", Since: noon})
+	assert.NoError(t, err)
+	if assert.Len(t, events.Members, 1) {
+		assert.Equal(t, "e2", events.Members[0].ID)
+	}
+}
+
+func TestByName(t *testing.T) {
+	c := newSeededClient(t)
+
+	zone, err := c.GetZoneByName("prod")
+	assert.NoError(t, err)
+	assert.Equal(t, "z1", zone.ID)
+
+	_, err = c.GetZoneByName("test")
+	assert.True(t, onesphere.IsNotFound(err))
+
+	_, err = c.GetZoneByName("dev")
+	var ambiguous *onesphere.AmbiguousNameError
+	if assert.True(t, errors.As(err, &ambiguous)) {
+		assert.Equal(t, []string{"/rest/zones/z2", "/rest/zones/z3"}, ambiguous.URIs)
+	}
+}
+
+func TestCreateUpdateDelete(t *testing.T) {
+	c := New()
+
+	zone, err := c.CreateZone(onesphere.ZoneRequest{Name: "prod", RegionURI: "/rest/regions/r1"})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, zone.ID)
+	assert.Equal(t, "/rest/zones/"+zone.ID, zone.URI)
+	assert.Equal(t, "/rest/regions/r1", zone.RegionURI)
+
+	zone, err = c.UpdateZone(zone.ID, []*onesphere.PatchOp{
+		{Op: "replace", Path: "/name", Value: "production"},
+		{Op: "add", Path: "/status", Value: "Ok"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "production", zone.Name)
+	assert.Equal(t, "Ok", zone.Status)
+
+	// a failing op leaves the zone unchanged
+	_, err = c.UpdateZone(zone.ID, []*onesphere.PatchOp{
+		{Op: "replace", Path: "/name", Value: "renamed"},
+		{Op: "replace", Path: "/missing/field", Value: "x"},
+	})
+	assert.Error(t, err)
+	zone, _ = c.GetZoneByID(zone.ID)
+	assert.Equal(t, "production", zone.Name)
+
+	connection, err := c.CreateZoneConnection(zone.ID, onesphere.ConnectionRequest{UUID: "u1", Name: "c1"})
+	assert.NoError(t, err)
+	assert.Equal(t, "u1", connection.UUID)
+
+	assert.NoError(t, c.DeleteZone(zone.ID))
+	_, err = c.GetZoneByID(zone.ID)
+	assert.True(t, onesphere.IsNotFound(err))
+	_, err = c.GetZoneConnections(zone.ID, "")
+	assert.True(t, onesphere.IsNotFound(err), "subresources are deleted with the zone")
+	assert.True(t, onesphere.IsNotFound(c.DeleteZone(zone.ID)))
+
+	project, err := c.CreateProject(onesphere.ProjectRequest{Name: "p", Description: "first"})
+	assert.NoError(t, err)
+	project, err = c.UpdateProject(project.ID, onesphere.ProjectRequest{Name: "renamed"})
+	assert.NoError(t, err)
+	assert.Equal(t, "renamed", project.Name)
+
+	assert.NoError(t, c.Seed(onesphere.Catalog{ID: "c1", Protected: true}))
+	assert.True(t, onesphere.IsProtected(c.DeleteCatalog("c1")))
+	assert.NoError(t, c.DeleteProject(project.ID))
+}
+
+func TestConcurrentListAndUpdate(t *testing.T) {
+	c := New()
+	project, err := c.CreateProject(onesphere.ProjectRequest{Name: "p"})
+	assert.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			_, err := c.UpdateProject(project.ID, onesphere.ProjectRequest{Name: fmt.Sprintf("p%d", i)})
+			assert.NoError(t, err)
+		}(i)
+		go func() {
+			defer wg.Done()
+			_, err := c.ListProjects(onesphere.ProjectListOptions{ListOptions: onesphere.ListOptions{Sort: "name:asc"}})
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+}
+
+func TestInvalidListOptionsAndPatchOps(t *testing.T) {
+	c := newSeededClient(t)
+
+	_, err := c.ListZones(onesphere.ZoneListOptions{ListOptions: onesphere.ListOptions{Start: -1}})
+	var apiErr *onesphere.APIError
+	if assert.True(t, errors.As(err, &apiErr)) {
+		assert.Equal(t, 400, apiErr.StatusCode)
+	}
+
+	assert.NoError(t, c.Seed(onesphere.BillingAccount{ID: "b1", Name: "billing"}))
+	_, err = c.UpdateBillingAccount("b1", []*onesphere.PatchOp{nil, {Op: "replace", Path: "/name", Value: "renamed"}})
+	assert.NoError(t, err, "nil ops are skipped")
+}
+
+func TestSeedAtAndSetError(t *testing.T) {
+	c := New()
+	assert.NoError(t, c.Seed(onesphere.Deployment{ID: "d1", Name: "web"}))
+	assert.NoError(t, c.SeedAt("/rest/deployments/d1/console", "https://console.example.com/d1"))
+
+	console, err := c.GetDeploymentConsole(onesphere.Deployment{ID: "d1"})
+	assert.NoError(t, err)
+	assert.Equal(t, "https://console.example.com/d1", console)
+
+	boom := errors.New("boom")
+	c.SetError("GetDeploymentByID", boom)
+	_, err = c.GetDeploymentByIDCtx(context.Background(), "d1")
+	assert.Equal(t, boom, err)
+
+	c.SetError("GetDeploymentByID", nil)
+	_, err = c.GetDeploymentByID("d1")
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = c.GetDeploymentByIDCtx(ctx, "d1")
+	assert.Equal(t, context.Canceled, err)
+}
@@ -0,0 +1,49 @@
+// (C) Copyright 2018 Hewlett Packard Enterprise Development LP.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.  IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package onespherefake
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/HewlettPackard/hpe-onesphere-go"
+)
+
+func (c *Client) GetKeyPair(regionUri, projectUri string) (onesphere.KeyPair, error) {
+	return c.GetKeyPairCtx(context.Background(), regionUri, projectUri)
+}
+
+func (c *Client) GetKeyPairCtx(ctx context.Context, regionUri, projectUri string) (onesphere.KeyPair, error) {
+	var keyPairs struct {
+		Members []onesphere.KeyPair `json:"members"`
+	}
+	if err := c.call(ctx, "GetKeyPair"); err != nil {
+		return onesphere.KeyPair{}, err
+	}
+	err := c.list("/rest/keypairs", filter{fields: map[string]string{"regionUri": regionUri, "projectUri": projectUri}}, &keyPairs)
+	if err != nil {
+		return onesphere.KeyPair{}, err
+	}
+	if len(keyPairs.Members) == 0 {
+		return onesphere.KeyPair{}, notFound(http.MethodGet, "/rest/keypairs")
+	}
+	return keyPairs.Members[0], nil
+}
@@ -0,0 +1,143 @@
+// (C) Copyright 2018 Hewlett Packard Enterprise Development LP.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.  IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package onespherefake
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/HewlettPackard/hpe-onesphere-go"
+)
+
+func (c *Client) GetMemberships(query string) (onesphere.MembershipList, error) {
+	var memberships onesphere.MembershipList
+	if err := c.call(context.Background(), "GetMemberships"); err != nil {
+		return memberships, err
+	}
+	err := c.list("/rest/memberships", filter{query: query}, &memberships)
+	return memberships, err
+}
+
+func (c *Client) GetMembershipsByProject(projectUri string) (onesphere.MembershipList, error) {
+	if projectUri == "" {
+		return onesphere.MembershipList{}, fmt.Errorf("projectUri must be a non-empty value")
+	}
+	return c.membershipsWith("GetMembershipsByProject", "projectUri", projectUri)
+}
+
+func (c *Client) GetMembershipsByUser(userUri string) (onesphere.MembershipList, error) {
+	if userUri == "" {
+		return onesphere.MembershipList{}, fmt.Errorf("userUri must be a non-empty value")
+	}
+	return c.membershipsWith("GetMembershipsByUser", "userUri", userUri)
+}
+
+func (c *Client) GetMembershipsByUserGroup(userGroupUri string) (onesphere.MembershipList, error) {
+	if userGroupUri == "" {
+		return onesphere.MembershipList{}, fmt.Errorf("userGroupUri must be a non-empty value")
+	}
+	return c.membershipsWith("GetMembershipsByUserGroup", "groupUri", userGroupUri)
+}
+
+func (c *Client) GetMembershipsByRole(roleUri string) (onesphere.MembershipList, error) {
+	if roleUri == "" {
+		return onesphere.MembershipList{}, fmt.Errorf("roleUri must be a non-empty value")
+	}
+	return c.membershipsWith("GetMembershipsByRole", "membershipRoleUri", roleUri)
+}
+
+func (c *Client) GetMembershipByID(id string) (onesphere.Membership, error) {
+	var membership onesphere.Membership
+	if id == "" {
+		return membership, fmt.Errorf("id must not be empty")
+	}
+	if err := c.call(context.Background(), "GetMembershipByID"); err != nil {
+		return membership, err
+	}
+	err := c.get("/rest/memberships/"+id, &membership)
+	return membership, err
+}
+
+func (c *Client) CreateMembership(membershipRequest onesphere.MembershipRequest) (onesphere.Membership, error) {
+	var membership onesphere.Membership
+	if err := c.call(context.Background(), "CreateMembership"); err != nil {
+		return membership, err
+	}
+	err := c.create("/rest/memberships", membershipRequest, &membership)
+	return membership, err
+}
+
+func (c *Client) DeleteMembershipByID(membershipId string) error {
+	if err := c.call(context.Background(), "DeleteMembershipByID"); err != nil {
+		return err
+	}
+	return c.remove("/rest/memberships/" + membershipId)
+}
+
+// GetMembershipRoles lists the roles seeded with their uri under /rest/membership-roles
+func (c *Client) GetMembershipRoles() (onesphere.MembershipRoleList, error) {
+	return c.GetMembershipRolesCtx(context.Background())
+}
+
+func (c *Client) GetMembershipRolesCtx(ctx context.Context) (onesphere.MembershipRoleList, error) {
+	var membershipRoles onesphere.MembershipRoleList
+	if err := c.call(ctx, "GetMembershipRoles"); err != nil {
+		return membershipRoles, err
+	}
+	err := c.list("/rest/membership-roles", filter{}, &membershipRoles)
+	return membershipRoles, err
+}
+
+func (c *Client) GetMembershipRoleByName(name string) (onesphere.MembershipRole, error) {
+	return c.GetMembershipRoleByNameCtx(context.Background(), name)
+}
+
+func (c *Client) GetMembershipRoleByNameCtx(ctx context.Context, name string) (onesphere.MembershipRole, error) {
+	if name == "" {
+		return onesphere.MembershipRole{}, fmt.Errorf("name must not be empty")
+	}
+	if err := c.call(ctx, "GetMembershipRoleByName"); err != nil {
+		return onesphere.MembershipRole{}, err
+	}
+	membershipRoles, err := c.GetMembershipRolesCtx(ctx)
+	if err != nil {
+		return onesphere.MembershipRole{}, err
+	}
+	names, uris := make([]string, len(membershipRoles.Members)), make([]string, len(membershipRoles.Members))
+	for i, membershipRole := range membershipRoles.Members {
+		names[i], uris[i] = membershipRole.Name, membershipRole.URI
+	}
+	i, err := matchName("membership role", name, names, uris)
+	if err != nil {
+		return onesphere.MembershipRole{}, err
+	}
+	return membershipRoles.Members[i], nil
+}
+
+// membershipsWith lists the memberships whose field is value
+func (c *Client) membershipsWith(method, field, value string) (onesphere.MembershipList, error) {
+	var memberships onesphere.MembershipList
+	if err := c.call(context.Background(), method); err != nil {
+		return memberships, err
+	}
+	err := c.list("/rest/memberships", filter{fields: map[string]string{field: value}}, &memberships)
+	return memberships, err
+}
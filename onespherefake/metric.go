@@ -0,0 +1,93 @@
+// (C) Copyright 2018 Hewlett Packard Enterprise Development LP.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.  IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package onespherefake
+
+import (
+	"context"
+
+	"github.com/HewlettPackard/hpe-onesphere-go"
+)
+
+func (c *Client) GetMetrics(
+	resourceUri, category, groupBy, query, name string,
+	periodStart, period string,
+	periodCount int,
+	view string,
+	start, count int) (onesphere.MetricList, error) {
+	return c.GetMetricsCtx(context.Background(), resourceUri, category, groupBy, query, name, periodStart, period, periodCount, view, start, count)
+}
+
+func (c *Client) GetMetricsCtx(ctx context.Context,
+	resourceUri, category, groupBy, query, name string,
+	periodStart, period string,
+	periodCount int,
+	view string,
+	start, count int) (onesphere.MetricList, error) {
+	if err := c.call(ctx, "GetMetrics"); err != nil {
+		return onesphere.MetricList{}, err
+	}
+	// the period is not taken into account, see ListMetrics
+	return c.ListMetricsCtx(ctx, onesphere.MetricsQuery{
+		ResourceURI: resourceUri,
+		Category:    category,
+		GroupBy:     groupBy,
+		Query:       query,
+		Name:        name,
+		Period:      onesphere.Period(period),
+		PeriodCount: periodCount,
+		ListOptions: onesphere.ListOptions{View: onesphere.View(view), Start: start, Count: count},
+	})
+}
+
+// ListMetrics filters the seeded metrics on their resource uri, name and the
+// query, the period of the values is not taken into account
+func (c *Client) ListMetrics(q onesphere.MetricsQuery) (onesphere.MetricList, error) {
+	return c.ListMetricsCtx(context.Background(), q)
+}
+
+func (c *Client) ListMetricsCtx(ctx context.Context, q onesphere.MetricsQuery) (onesphere.MetricList, error) {
+	var metrics onesphere.MetricList
+	if err := c.call(ctx, "ListMetrics"); err != nil {
+		return metrics, err
+	}
+	err := c.list("/rest/metrics", filter{
+		query:  q.Query,
+		fields: map[string]string{"resourceUri": q.ResourceURI, "name": q.Name},
+		opts:   q.ListOptions,
+	}, &metrics)
+	return metrics, err
+}
+
+func (c *Client) ForEachMetric(q onesphere.MetricsQuery, fn func(onesphere.Metric) error) error {
+	return c.ForEachMetricCtx(context.Background(), q, fn)
+}
+
+func (c *Client) ForEachMetricCtx(ctx context.Context, q onesphere.MetricsQuery, fn func(onesphere.Metric) error) error {
+	if err := c.call(ctx, "ForEachMetric"); err != nil {
+		return err
+	}
+	q.Count = 0
+	metrics, err := c.ListMetricsCtx(ctx, q)
+	if err != nil {
+		return err
+	}
+	return visitAll(len(metrics.Members), func(i int) error { return fn(metrics.Members[i]) })
+}
@@ -0,0 +1,128 @@
+// (C) Copyright 2018 Hewlett Packard Enterprise Development LP.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.  IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package onespherefake
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/HewlettPackard/hpe-onesphere-go"
+	"github.com/HewlettPackard/hpe-onesphere-go/query"
+)
+
+func (c *Client) GetNetworks(query string) (onesphere.NetworkList, error) {
+	if err := c.call(context.Background(), "GetNetworks"); err != nil {
+		return onesphere.NetworkList{}, err
+	}
+	return c.ListNetworks(onesphere.NetworkListOptions{Query: query})
+}
+
+func (c *Client) ListNetworks(opts onesphere.NetworkListOptions) (onesphere.NetworkList, error) {
+	return c.ListNetworksCtx(context.Background(), opts)
+}
+
+func (c *Client) ListNetworksCtx(ctx context.Context, opts onesphere.NetworkListOptions) (onesphere.NetworkList, error) {
+	var networks onesphere.NetworkList
+	if err := c.call(ctx, "ListNetworks"); err != nil {
+		return networks, err
+	}
+	err := c.list("/rest/networks", filter{query: opts.Query, opts: opts.ListOptions}, &networks)
+	return networks, err
+}
+
+func (c *Client) ForEachNetwork(opts onesphere.NetworkListOptions, fn func(onesphere.Network) error) error {
+	return c.ForEachNetworkCtx(context.Background(), opts, fn)
+}
+
+func (c *Client) ForEachNetworkCtx(ctx context.Context, opts onesphere.NetworkListOptions, fn func(onesphere.Network) error) error {
+	if err := c.call(ctx, "ForEachNetwork"); err != nil {
+		return err
+	}
+	opts.Count = 0
+	networks, err := c.ListNetworksCtx(ctx, opts)
+	if err != nil {
+		return err
+	}
+	return visitAll(len(networks.Members), func(i int) error { return fn(networks.Members[i]) })
+}
+
+func (c *Client) GetNetworkByID(id string) (onesphere.Network, error) {
+	var network onesphere.Network
+	if id == "" {
+		return network, fmt.Errorf("id must not be empty")
+	}
+	if err := c.call(context.Background(), "GetNetworkByID"); err != nil {
+		return network, err
+	}
+	err := c.get("/rest/networks/"+id, &network)
+	return network, err
+}
+
+// GetNetworkByZoneURI returns the first network of the zone, a zero Network when it has none
+func (c *Client) GetNetworkByZoneURI(zoneUri string) (onesphere.Network, error) {
+	if zoneUri == "" {
+		return onesphere.Network{}, fmt.Errorf("zoneUri must not be empty")
+	}
+	if err := c.call(context.Background(), "GetNetworkByZoneURI"); err != nil {
+		return onesphere.Network{}, err
+	}
+	networks, err := c.zoneNetworks(zoneUri)
+	if err != nil || len(networks.Members) == 0 {
+		return onesphere.Network{}, err
+	}
+	return networks.Members[0], nil
+}
+
+// GetNetworkByNameAndZoneURI returns a zero Network when the zone has no network named name
+func (c *Client) GetNetworkByNameAndZoneURI(name, zoneUri string) (onesphere.Network, error) {
+	if zoneUri == "" {
+		return onesphere.Network{}, fmt.Errorf("zoneUri must not be empty")
+	}
+	if name == "" {
+		return onesphere.Network{}, fmt.Errorf("name must not be empty")
+	}
+	if err := c.call(context.Background(), "GetNetworkByNameAndZoneURI"); err != nil {
+		return onesphere.Network{}, err
+	}
+	networks, err := c.zoneNetworks(zoneUri)
+	if err != nil {
+		return onesphere.Network{}, err
+	}
+	for _, network := range networks.Members {
+		if network.Name == name {
+			return network, nil
+		}
+	}
+	return onesphere.Network{}, nil
+}
+
+func (c *Client) UpdateNetwork(networkId string, updates []*onesphere.PatchOp) (onesphere.Network, error) {
+	var network onesphere.Network
+	if err := c.call(context.Background(), "UpdateNetwork"); err != nil {
+		return network, err
+	}
+	err := c.patch("/rest/networks/"+networkId, updates, &network)
+	return network, err
+}
+
+func (c *Client) zoneNetworks(zoneUri string) (onesphere.NetworkList, error) {
+	return c.ListNetworks(onesphere.NetworkListOptions{Query: query.Eq("zoneUri", zoneUri).String()})
+}
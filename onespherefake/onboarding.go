@@ -0,0 +1,102 @@
+// (C) Copyright 2018 Hewlett Packard Enterprise Development LP.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.  IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package onespherefake
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/HewlettPackard/hpe-onesphere-go"
+)
+
+func (c *Client) GetAzureLoginProperties() (onesphere.AzureLoginProperties, error) {
+	return c.GetAzureLoginPropertiesCtx(context.Background())
+}
+
+func (c *Client) GetAzureLoginPropertiesCtx(ctx context.Context) (onesphere.AzureLoginProperties, error) {
+	var properties onesphere.AzureLoginProperties
+	if err := c.call(ctx, "GetAzureLoginProperties"); err != nil {
+		return properties, err
+	}
+	err := c.get("/rest/onboarding/azure/properties", &properties)
+	return properties, err
+}
+
+// GetAzureProviderInfo returns the seeded AzureProviderInfo whatever the directory and location
+func (c *Client) GetAzureProviderInfo(directoryUri, location string) (onesphere.AzureProviderInfo, error) {
+	return c.GetAzureProviderInfoCtx(context.Background(), directoryUri, location)
+}
+
+func (c *Client) GetAzureProviderInfoCtx(ctx context.Context, directoryUri, location string) (onesphere.AzureProviderInfo, error) {
+	var info onesphere.AzureProviderInfo
+	if err := c.call(ctx, "GetAzureProviderInfo"); err != nil {
+		return info, err
+	}
+	err := c.get("/rest/onboarding/azure/provider-info", &info)
+	return info, err
+}
+
+// GetAzureSubscriptions lists the seeded subscriptions whatever the directory and location
+func (c *Client) GetAzureSubscriptions(directoryUri, location string) (onesphere.AzureSubscriptionList, error) {
+	return c.GetAzureSubscriptionsCtx(context.Background(), directoryUri, location)
+}
+
+func (c *Client) GetAzureSubscriptionsCtx(ctx context.Context, directoryUri, location string) (onesphere.AzureSubscriptionList, error) {
+	var subscriptions onesphere.AzureSubscriptionList
+	if err := c.call(ctx, "GetAzureSubscriptions"); err != nil {
+		return subscriptions, err
+	}
+	err := c.list("/rest/onboarding/azure/subscriptions", filter{}, &subscriptions)
+	return subscriptions, err
+}
+
+func (c *Client) UpdateAzureSubscription(directoryUri, location, subscriptionId string, patchPayload []*onesphere.PatchOp) (onesphere.AzureSubscription, error) {
+	return c.UpdateAzureSubscriptionCtx(context.Background(), directoryUri, location, subscriptionId, patchPayload)
+}
+
+func (c *Client) UpdateAzureSubscriptionCtx(ctx context.Context, directoryUri, location, subscriptionId string, patchPayload []*onesphere.PatchOp) (onesphere.AzureSubscription, error) {
+	var subscription onesphere.AzureSubscription
+
+	allowedOps := []string{"add", "replace"}
+	if op, ok := invalidOp(patchPayload, allowedOps...); ok {
+		return subscription, fmt.Errorf("UpdateAzureSubscription received invalid Op for update.\nReceived Op: %s\nValid Ops: %v\n", op, allowedOps)
+	}
+	if err := c.call(ctx, "UpdateAzureSubscription"); err != nil {
+		return subscription, err
+	}
+
+	collection := "/rest/onboarding/azure/subscriptions"
+	c.mu.Lock()
+	uri := ""
+	for _, obj := range c.members(collection) {
+		if obj["subscriptionId"] == subscriptionId || obj["id"] == subscriptionId {
+			uri = obj["uri"].(string)
+		}
+	}
+	c.mu.Unlock()
+	if uri == "" {
+		return subscription, notFound(http.MethodPatch, collection+"/"+subscriptionId)
+	}
+
+	err := c.patch(uri, patchPayload, &subscription)
+	return subscription, err
+}
@@ -0,0 +1,162 @@
+// (C) Copyright 2018 Hewlett Packard Enterprise Development LP.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.  IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package onespherefake
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/HewlettPackard/hpe-onesphere-go"
+)
+
+func (c *Client) GetProjects(userQuery, view string) (onesphere.ProjectList, error) {
+	return c.GetProjectsCtx(context.Background(), userQuery, view)
+}
+
+func (c *Client) GetProjectsCtx(ctx context.Context, userQuery, view string) (onesphere.ProjectList, error) {
+	if err := c.call(ctx, "GetProjects"); err != nil {
+		return onesphere.ProjectList{}, err
+	}
+	return c.ListProjectsCtx(ctx, onesphere.ProjectListOptions{
+		UserQuery:   userQuery,
+		ListOptions: onesphere.ListOptions{View: onesphere.View(view)},
+	})
+}
+
+func (c *Client) ListProjects(opts onesphere.ProjectListOptions) (onesphere.ProjectList, error) {
+	return c.ListProjectsCtx(context.Background(), opts)
+}
+
+func (c *Client) ListProjectsCtx(ctx context.Context, opts onesphere.ProjectListOptions) (onesphere.ProjectList, error) {
+	var projects onesphere.ProjectList
+	if err := c.call(ctx, "ListProjects"); err != nil {
+		return projects, err
+	}
+	err := c.list("/rest/projects", filter{userQuery: opts.UserQuery, opts: opts.ListOptions}, &projects)
+	return projects, err
+}
+
+func (c *Client) ForEachProject(opts onesphere.ProjectListOptions, fn func(onesphere.Project) error) error {
+	return c.ForEachProjectCtx(context.Background(), opts, fn)
+}
+
+func (c *Client) ForEachProjectCtx(ctx context.Context, opts onesphere.ProjectListOptions, fn func(onesphere.Project) error) error {
+	if err := c.call(ctx, "ForEachProject"); err != nil {
+		return err
+	}
+	opts.Count = 0
+	projects, err := c.ListProjectsCtx(ctx, opts)
+	if err != nil {
+		return err
+	}
+	return visitAll(len(projects.Members), func(i int) error { return fn(projects.Members[i]) })
+}
+
+func (c *Client) GetProjectByID(id, view string) (onesphere.Project, error) {
+	return c.GetProjectByIDCtx(context.Background(), id, view)
+}
+
+func (c *Client) GetProjectByIDCtx(ctx context.Context, id, view string) (onesphere.Project, error) {
+	var project onesphere.Project
+	if id == "" {
+		return project, fmt.Errorf("id must not be empty")
+	}
+	if err := c.call(ctx, "GetProjectByID"); err != nil {
+		return project, err
+	}
+	err := c.get("/rest/projects/"+id, &project)
+	return project, err
+}
+
+func (c *Client) GetProjectByName(name string) (onesphere.Project, error) {
+	return c.GetProjectByNameCtx(context.Background(), name)
+}
+
+func (c *Client) GetProjectByNameCtx(ctx context.Context, name string) (onesphere.Project, error) {
+	if name == "" {
+		return onesphere.Project{}, fmt.Errorf("name must not be empty")
+	}
+	if err := c.call(ctx, "GetProjectByName"); err != nil {
+		return onesphere.Project{}, err
+	}
+	projects, err := c.ListProjectsCtx(ctx, onesphere.ProjectListOptions{})
+	if err != nil {
+		return onesphere.Project{}, err
+	}
+	names, uris := make([]string, len(projects.Members)), make([]string, len(projects.Members))
+	for i, project := range projects.Members {
+		names[i], uris[i] = project.Name, project.URI
+	}
+	i, err := matchName("project", name, names, uris)
+	if err != nil {
+		return onesphere.Project{}, err
+	}
+	return projects.Members[i], nil
+}
+
+func (c *Client) CreateProject(projectRequest onesphere.ProjectRequest) (onesphere.Project, error) {
+	return c.CreateProjectCtx(context.Background(), projectRequest)
+}
+
+func (c *Client) CreateProjectCtx(ctx context.Context, projectRequest onesphere.ProjectRequest) (onesphere.Project, error) {
+	var project onesphere.Project
+	if err := c.call(ctx, "CreateProject"); err != nil {
+		return project, err
+	}
+	err := c.create("/rest/projects", projectRequest, &project)
+	return project, err
+}
+
+// UpdateProject sets the non-empty fields of updates
+func (c *Client) UpdateProject(projectId string, updates onesphere.ProjectRequest) (onesphere.Project, error) {
+	return c.UpdateProjectCtx(context.Background(), projectId, updates)
+}
+
+func (c *Client) UpdateProjectCtx(ctx context.Context, projectId string, updates onesphere.ProjectRequest) (onesphere.Project, error) {
+	var project onesphere.Project
+	if projectId == "" {
+		return project, fmt.Errorf("projectId must be non-empty")
+	}
+	if err := c.call(ctx, "UpdateProject"); err != nil {
+		return project, err
+	}
+	err := c.merge("/rest/projects/"+projectId, updates, &project)
+	return project, err
+}
+
+func (c *Client) DeleteProject(projectId string) error {
+	return c.DeleteProjectCtx(context.Background(), projectId)
+}
+
+// DeleteProjectCtx returns a ProtectedError for a protected project, like Client
+func (c *Client) DeleteProjectCtx(ctx context.Context, projectId string) error {
+	if err := c.call(ctx, "DeleteProject"); err != nil {
+		return err
+	}
+	var project onesphere.Project
+	if err := c.get("/rest/projects/"+projectId, &project); err != nil {
+		return err
+	}
+	if project.Protected {
+		return &onesphere.ProtectedError{Resource: "project", ID: projectId}
+	}
+	return c.remove("/rest/projects/" + projectId)
+}
@@ -0,0 +1,83 @@
+// (C) Copyright 2018 Hewlett Packard Enterprise Development LP.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.  IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package onespherefake
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/HewlettPackard/hpe-onesphere-go"
+)
+
+func (c *Client) GetProviders(query string) (onesphere.ProviderList, error) {
+	var providers onesphere.ProviderList
+	if err := c.call(context.Background(), "GetProviders"); err != nil {
+		return providers, err
+	}
+	err := c.list("/rest/providers", filter{query: query}, &providers)
+	return providers, err
+}
+
+func (c *Client) GetProviderByID(id, view string, discover bool) (onesphere.Provider, error) {
+	var provider onesphere.Provider
+	if id == "" {
+		return provider, fmt.Errorf("id must not be empty")
+	}
+	if err := c.call(context.Background(), "GetProviderByID"); err != nil {
+		return provider, err
+	}
+	err := c.get("/rest/providers/"+id, &provider)
+	return provider, err
+}
+
+func (c *Client) CreateProvider(providerRequest onesphere.ProviderRequest) (onesphere.Provider, error) {
+	var provider onesphere.Provider
+	if err := c.call(context.Background(), "CreateProvider"); err != nil {
+		return provider, err
+	}
+	err := c.create("/rest/providers", providerRequest, &provider)
+	return provider, err
+}
+
+func (c *Client) UpdateProvider(providerId string, updates []*onesphere.PatchOp) (onesphere.Provider, error) {
+	var provider onesphere.Provider
+	if err := c.call(context.Background(), "UpdateProvider"); err != nil {
+		return provider, err
+	}
+	err := c.patch("/rest/providers/"+providerId, updates, &provider)
+	return provider, err
+}
+
+func (c *Client) DeleteProvider(providerId string) error {
+	if err := c.call(context.Background(), "DeleteProvider"); err != nil {
+		return err
+	}
+	return c.remove("/rest/providers/" + providerId)
+}
+
+func (c *Client) GetProviderTypes() (onesphere.ProviderTypeList, error) {
+	var providerTypes onesphere.ProviderTypeList
+	if err := c.call(context.Background(), "GetProviderTypes"); err != nil {
+		return providerTypes, err
+	}
+	err := c.list("/rest/provider-types", filter{}, &providerTypes)
+	return providerTypes, err
+}
@@ -0,0 +1,97 @@
+// (C) Copyright 2018 Hewlett Packard Enterprise Development LP.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.  IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package onespherefake
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/HewlettPackard/hpe-onesphere-go"
+)
+
+func (c *Client) GetRates(resourceUri, effectiveForDate, effectiveDate, metricName string,
+	active bool, start, count int) (onesphere.RateList, error) {
+	return c.GetRatesCtx(context.Background(), resourceUri, effectiveForDate, effectiveDate, metricName, active, start, count)
+}
+
+func (c *Client) GetRatesCtx(ctx context.Context, resourceUri, effectiveForDate, effectiveDate, metricName string,
+	active bool, start, count int) (onesphere.RateList, error) {
+	if err := c.call(ctx, "GetRates"); err != nil {
+		return onesphere.RateList{}, err
+	}
+	// the effective dates are not taken into account, see ListRates
+	return c.ListRatesCtx(ctx, onesphere.RateListOptions{
+		ResourceURI: resourceUri,
+		MetricName:  metricName,
+		Active:      &active,
+		ListOptions: onesphere.ListOptions{Start: start, Count: count},
+	})
+}
+
+// ListRates filters the seeded rates on their resource uri and metric name,
+// the effective dates and Active are not taken into account
+func (c *Client) ListRates(opts onesphere.RateListOptions) (onesphere.RateList, error) {
+	return c.ListRatesCtx(context.Background(), opts)
+}
+
+func (c *Client) ListRatesCtx(ctx context.Context, opts onesphere.RateListOptions) (onesphere.RateList, error) {
+	var rates onesphere.RateList
+	if err := c.call(ctx, "ListRates"); err != nil {
+		return rates, err
+	}
+	err := c.list("/rest/rates", filter{
+		fields: map[string]string{"resourceUri": opts.ResourceURI, "metricName": opts.MetricName},
+		opts:   opts.ListOptions,
+	}, &rates)
+	return rates, err
+}
+
+func (c *Client) ForEachRate(opts onesphere.RateListOptions, fn func(onesphere.Rate) error) error {
+	return c.ForEachRateCtx(context.Background(), opts, fn)
+}
+
+func (c *Client) ForEachRateCtx(ctx context.Context, opts onesphere.RateListOptions, fn func(onesphere.Rate) error) error {
+	if err := c.call(ctx, "ForEachRate"); err != nil {
+		return err
+	}
+	opts.Count = 0
+	rates, err := c.ListRatesCtx(ctx, opts)
+	if err != nil {
+		return err
+	}
+	return visitAll(len(rates.Members), func(i int) error { return fn(rates.Members[i]) })
+}
+
+func (c *Client) GetRate(rateID string) (onesphere.Rate, error) {
+	return c.GetRateCtx(context.Background(), rateID)
+}
+
+func (c *Client) GetRateCtx(ctx context.Context, rateID string) (onesphere.Rate, error) {
+	var rate onesphere.Rate
+	if rateID == "" {
+		return rate, fmt.Errorf("rateID must not be empty")
+	}
+	if err := c.call(ctx, "GetRate"); err != nil {
+		return rate, err
+	}
+	err := c.get("/rest/rates/"+rateID, &rate)
+	return rate, err
+}
@@ -0,0 +1,212 @@
+// (C) Copyright 2018 Hewlett Packard Enterprise Development LP.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.  IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package onespherefake
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/HewlettPackard/hpe-onesphere-go"
+)
+
+func (c *Client) GetRegions(query, view string) (onesphere.RegionList, error) {
+	return c.GetRegionsCtx(context.Background(), query, view)
+}
+
+func (c *Client) GetRegionsCtx(ctx context.Context, query, view string) (onesphere.RegionList, error) {
+	if err := c.call(ctx, "GetRegions"); err != nil {
+		return onesphere.RegionList{}, err
+	}
+	return c.ListRegionsCtx(ctx, onesphere.RegionListOptions{
+		Query:       query,
+		ListOptions: onesphere.ListOptions{View: onesphere.View(view)},
+	})
+}
+
+func (c *Client) ListRegions(opts onesphere.RegionListOptions) (onesphere.RegionList, error) {
+	return c.ListRegionsCtx(context.Background(), opts)
+}
+
+func (c *Client) ListRegionsCtx(ctx context.Context, opts onesphere.RegionListOptions) (onesphere.RegionList, error) {
+	var regions onesphere.RegionList
+	if err := c.call(ctx, "ListRegions"); err != nil {
+		return regions, err
+	}
+	err := c.list("/rest/regions", filter{query: opts.Query, opts: opts.ListOptions}, &regions)
+	return regions, err
+}
+
+func (c *Client) ForEachRegion(opts onesphere.RegionListOptions, fn func(onesphere.Region) error) error {
+	return c.ForEachRegionCtx(context.Background(), opts, fn)
+}
+
+func (c *Client) ForEachRegionCtx(ctx context.Context, opts onesphere.RegionListOptions, fn func(onesphere.Region) error) error {
+	if err := c.call(ctx, "ForEachRegion"); err != nil {
+		return err
+	}
+	opts.Count = 0
+	regions, err := c.ListRegionsCtx(ctx, opts)
+	if err != nil {
+		return err
+	}
+	return visitAll(len(regions.Members), func(i int) error { return fn(regions.Members[i]) })
+}
+
+func (c *Client) GetRegionByID(id, view string, discover bool) (onesphere.Region, error) {
+	return c.GetRegionByIDCtx(context.Background(), id, view, discover)
+}
+
+func (c *Client) GetRegionByIDCtx(ctx context.Context, id, view string, discover bool) (onesphere.Region, error) {
+	var region onesphere.Region
+	if id == "" {
+		return region, fmt.Errorf("id must not be empty")
+	}
+	if err := c.call(ctx, "GetRegionByID"); err != nil {
+		return region, err
+	}
+	err := c.get("/rest/regions/"+id, &region)
+	return region, err
+}
+
+func (c *Client) GetRegionByName(name string) (onesphere.Region, error) {
+	return c.GetRegionByNameCtx(context.Background(), name)
+}
+
+func (c *Client) GetRegionByNameCtx(ctx context.Context, name string) (onesphere.Region, error) {
+	if name == "" {
+		return onesphere.Region{}, fmt.Errorf("name must not be empty")
+	}
+	if err := c.call(ctx, "GetRegionByName"); err != nil {
+		return onesphere.Region{}, err
+	}
+	regions, err := c.ListRegionsCtx(ctx, onesphere.RegionListOptions{})
+	if err != nil {
+		return onesphere.Region{}, err
+	}
+	names, uris := make([]string, len(regions.Members)), make([]string, len(regions.Members))
+	for i, region := range regions.Members {
+		names[i], uris[i] = region.Name, region.URI
+	}
+	i, err := matchName("region", name, names, uris)
+	if err != nil {
+		return onesphere.Region{}, err
+	}
+	return regions.Members[i], nil
+}
+
+func (c *Client) CreateRegion(regionRequest onesphere.RegionRequest) (onesphere.Region, error) {
+	return c.CreateRegionCtx(context.Background(), regionRequest)
+}
+
+func (c *Client) CreateRegionCtx(ctx context.Context, regionRequest onesphere.RegionRequest) (onesphere.Region, error) {
+	var region onesphere.Region
+	if err := c.call(ctx, "CreateRegion"); err != nil {
+		return region, err
+	}
+	err := c.create("/rest/regions", regionRequest, &region)
+	return region, err
+}
+
+func (c *Client) UpdateRegion(regionId string, updates []*onesphere.PatchOp) (onesphere.Region, error) {
+	return c.UpdateRegionCtx(context.Background(), regionId, updates)
+}
+
+func (c *Client) UpdateRegionCtx(ctx context.Context, regionId string, updates []*onesphere.PatchOp) (onesphere.Region, error) {
+	var region onesphere.Region
+	if err := c.call(ctx, "UpdateRegion"); err != nil {
+		return region, err
+	}
+	err := c.patch("/rest/regions/"+regionId, updates, &region)
+	return region, err
+}
+
+func (c *Client) DeleteRegion(regionId string) error {
+	return c.DeleteRegionCtx(context.Background(), regionId)
+}
+
+func (c *Client) DeleteRegionCtx(ctx context.Context, regionId string) error {
+	if err := c.call(ctx, "DeleteRegion"); err != nil {
+		return err
+	}
+	return c.remove("/rest/regions/" + regionId)
+}
+
+func (c *Client) GetRegionConnection(regionId string) (onesphere.RegionConnection, error) {
+	return c.GetRegionConnectionCtx(context.Background(), regionId)
+}
+
+func (c *Client) GetRegionConnectionCtx(ctx context.Context, regionId string) (onesphere.RegionConnection, error) {
+	var regionConn onesphere.RegionConnection
+	if regionId == "" {
+		return regionConn, fmt.Errorf("regionId must not be empty")
+	}
+	if err := c.call(ctx, "GetRegionConnection"); err != nil {
+		return regionConn, err
+	}
+	err := c.get("/rest/regions/"+regionId+"/connection", &regionConn)
+	return regionConn, err
+}
+
+func (c *Client) CreateRegionConnection(regionId string, regionConnectionRequest onesphere.RegionConnectionRequest) (onesphere.RegionConnection, error) {
+	return c.CreateRegionConnectionCtx(context.Background(), regionId, regionConnectionRequest)
+}
+
+func (c *Client) CreateRegionConnectionCtx(ctx context.Context, regionId string, regionConnectionRequest onesphere.RegionConnectionRequest) (onesphere.RegionConnection, error) {
+	var regionConn onesphere.RegionConnection
+	if regionId == "" {
+		return regionConn, fmt.Errorf("regionId must not be empty")
+	}
+	if err := c.call(ctx, "CreateRegionConnection"); err != nil {
+		return regionConn, err
+	}
+	uri := "/rest/regions/" + regionId
+	err := c.store(uri, uri+"/connection", regionConnectionRequest, &regionConn)
+	return regionConn, err
+}
+
+func (c *Client) DeleteRegionConnection(regionId string) error {
+	return c.DeleteRegionConnectionCtx(context.Background(), regionId)
+}
+
+func (c *Client) DeleteRegionConnectionCtx(ctx context.Context, regionId string) error {
+	if regionId == "" {
+		return fmt.Errorf("regionId must not be empty")
+	}
+	if err := c.call(ctx, "DeleteRegionConnection"); err != nil {
+		return err
+	}
+	return c.remove("/rest/regions/" + regionId + "/connection")
+}
+
+// GetRegionConnectorImage returns the string seeded at the connector-image uri of the region
+func (c *Client) GetRegionConnectorImage(regionId string) (string, error) {
+	return c.GetRegionConnectorImageCtx(context.Background(), regionId)
+}
+
+func (c *Client) GetRegionConnectorImageCtx(ctx context.Context, regionId string) (string, error) {
+	if regionId == "" {
+		return "", fmt.Errorf("regionId must not be empty")
+	}
+	if err := c.call(ctx, "GetRegionConnectorImage"); err != nil {
+		return "", err
+	}
+	return c.subresource("/rest/regions/"+regionId, "/connector-image")
+}
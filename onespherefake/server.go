@@ -0,0 +1,124 @@
+// (C) Copyright 2018 Hewlett Packard Enterprise Development LP.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.  IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package onespherefake
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/HewlettPackard/hpe-onesphere-go"
+)
+
+func (c *Client) GetServers(regionUri, applianceUri, zoneUri string) (onesphere.ServerList, error) {
+	return c.GetServersCtx(context.Background(), regionUri, applianceUri, zoneUri)
+}
+
+func (c *Client) GetServersCtx(ctx context.Context, regionUri, applianceUri, zoneUri string) (onesphere.ServerList, error) {
+	if err := c.call(ctx, "GetServers"); err != nil {
+		return onesphere.ServerList{}, err
+	}
+	return c.ListServersCtx(ctx, onesphere.ServerListOptions{
+		RegionURI:    regionUri,
+		ApplianceURI: applianceUri,
+		ZoneURI:      zoneUri,
+	})
+}
+
+func (c *Client) ListServers(opts onesphere.ServerListOptions) (onesphere.ServerList, error) {
+	return c.ListServersCtx(context.Background(), opts)
+}
+
+func (c *Client) ListServersCtx(ctx context.Context, opts onesphere.ServerListOptions) (onesphere.ServerList, error) {
+	var servers onesphere.ServerList
+	if err := c.call(ctx, "ListServers"); err != nil {
+		return servers, err
+	}
+	err := c.list("/rest/servers", filter{
+		fields: map[string]string{
+			"regionUri":    opts.RegionURI,
+			"applianceUri": opts.ApplianceURI,
+			"zoneUri":      opts.ZoneURI,
+		},
+		opts: opts.ListOptions,
+	}, &servers)
+	return servers, err
+}
+
+func (c *Client) CreateServer(server *onesphere.Server) (onesphere.Server, error) {
+	return c.CreateServerCtx(context.Background(), server)
+}
+
+func (c *Client) CreateServerCtx(ctx context.Context, server *onesphere.Server) (onesphere.Server, error) {
+	var created onesphere.Server
+	if err := c.call(ctx, "CreateServer"); err != nil {
+		return created, err
+	}
+	err := c.create("/rest/servers", server, &created)
+	return created, err
+}
+
+func (c *Client) DeleteServer(serverID string, force bool) error {
+	return c.DeleteServerCtx(context.Background(), serverID, force)
+}
+
+func (c *Client) DeleteServerCtx(ctx context.Context, serverID string, force bool) error {
+	if serverID == "" {
+		return fmt.Errorf("serverID must not be empty")
+	}
+	if err := c.call(ctx, "DeleteServer"); err != nil {
+		return err
+	}
+	return c.remove("/rest/servers/" + serverID)
+}
+
+func (c *Client) GetServer(serverID string) (onesphere.Server, error) {
+	return c.GetServerCtx(context.Background(), serverID)
+}
+
+func (c *Client) GetServerCtx(ctx context.Context, serverID string) (onesphere.Server, error) {
+	var server onesphere.Server
+	if serverID == "" {
+		return server, fmt.Errorf("serverID must not be empty")
+	}
+	if err := c.call(ctx, "GetServer"); err != nil {
+		return server, err
+	}
+	err := c.get("/rest/servers/"+serverID, &server)
+	return server, err
+}
+
+func (c *Client) UpdateServer(serverID string, patchPayload []*onesphere.PatchOp) (onesphere.Server, error) {
+	return c.UpdateServerCtx(context.Background(), serverID, patchPayload)
+}
+
+func (c *Client) UpdateServerCtx(ctx context.Context, serverID string, patchPayload []*onesphere.PatchOp) (onesphere.Server, error) {
+	var server onesphere.Server
+
+	allowedOps := []string{"replace", "remove"}
+	if op, ok := invalidOp(patchPayload, allowedOps...); ok {
+		return server, fmt.Errorf("UpdateServer received invalid Op for update.\nReceived Op: %s\nValid Ops: %v\n", op, allowedOps)
+	}
+	if err := c.call(ctx, "UpdateServer"); err != nil {
+		return server, err
+	}
+	err := c.patch("/rest/servers/"+serverID, patchPayload, &server)
+	return server, err
+}
@@ -0,0 +1,92 @@
+// (C) Copyright 2018 Hewlett Packard Enterprise Development LP.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.  IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package onespherefake
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/HewlettPackard/hpe-onesphere-go"
+)
+
+// GetServices lists the services seeded with their uri under /rest/services
+func (c *Client) GetServices(query, userQuery string) (onesphere.ServiceList, error) {
+	var services onesphere.ServiceList
+	if err := c.call(context.Background(), "GetServices"); err != nil {
+		return services, err
+	}
+	err := c.list("/rest/services", filter{query: query, userQuery: userQuery}, &services)
+	return services, err
+}
+
+func (c *Client) GetServiceByID(id string) (onesphere.Service, error) {
+	var service onesphere.Service
+	if id == "" {
+		return service, fmt.Errorf("id must not be empty")
+	}
+	if err := c.call(context.Background(), "GetServiceByID"); err != nil {
+		return service, err
+	}
+	err := c.get("/rest/services/"+id, &service)
+	return service, err
+}
+
+// GetServiceByName returns a zero Service when no service is named name
+func (c *Client) GetServiceByName(name string) (onesphere.Service, error) {
+	if name == "" {
+		return onesphere.Service{}, fmt.Errorf("name must not be empty")
+	}
+	if err := c.call(context.Background(), "GetServiceByName"); err != nil {
+		return onesphere.Service{}, err
+	}
+	services, err := c.GetServices("", name)
+	if err != nil {
+		return onesphere.Service{}, err
+	}
+	for _, service := range services.Members {
+		if service.Name == name {
+			return service, nil
+		}
+	}
+	return onesphere.Service{}, nil
+}
+
+// GetServiceTypes lists the service types seeded with their uri under /rest/service-types
+func (c *Client) GetServiceTypes() (onesphere.ServiceTypeList, error) {
+	var serviceTypes onesphere.ServiceTypeList
+	if err := c.call(context.Background(), "GetServiceTypes"); err != nil {
+		return serviceTypes, err
+	}
+	err := c.list("/rest/service-types", filter{}, &serviceTypes)
+	return serviceTypes, err
+}
+
+func (c *Client) GetServiceTypeByID(id string) (onesphere.ServiceType, error) {
+	var serviceType onesphere.ServiceType
+	if id == "" {
+		return serviceType, fmt.Errorf("id must not be empty")
+	}
+	if err := c.call(context.Background(), "GetServiceTypeByID"); err != nil {
+		return serviceType, err
+	}
+	err := c.get("/rest/service-types/"+id, &serviceType)
+	return serviceType, err
+}
@@ -0,0 +1,54 @@
+// (C) Copyright 2018 Hewlett Packard Enterprise Development LP.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.  IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package onespherefake
+
+import (
+	"context"
+
+	"github.com/HewlettPackard/hpe-onesphere-go"
+)
+
+func (c *Client) GetSession(view string) (onesphere.Session, error) {
+	return c.GetSessionCtx(context.Background(), view)
+}
+
+func (c *Client) GetSessionCtx(ctx context.Context, view string) (onesphere.Session, error) {
+	var session onesphere.Session
+	if err := c.call(ctx, "GetSession"); err != nil {
+		return session, err
+	}
+	err := c.get("/rest/session", &session)
+	return session, err
+}
+
+// GetSessionIdp returns the SessionIdp seeded at /rest/session/idp whatever the user
+func (c *Client) GetSessionIdp(userName string) (onesphere.SessionIdp, error) {
+	return c.GetSessionIdpCtx(context.Background(), userName)
+}
+
+func (c *Client) GetSessionIdpCtx(ctx context.Context, userName string) (onesphere.SessionIdp, error) {
+	var idp onesphere.SessionIdp
+	if err := c.call(ctx, "GetSessionIdp"); err != nil {
+		return idp, err
+	}
+	err := c.get("/rest/session/idp", &idp)
+	return idp, err
+}
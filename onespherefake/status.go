@@ -0,0 +1,53 @@
+// (C) Copyright 2018 Hewlett Packard Enterprise Development LP.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.  IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package onespherefake
+
+import (
+	"context"
+
+	"github.com/HewlettPackard/hpe-onesphere-go"
+)
+
+func (c *Client) GetStatus() (onesphere.Status, error) {
+	return c.GetStatusCtx(context.Background())
+}
+
+func (c *Client) GetStatusCtx(ctx context.Context) (onesphere.Status, error) {
+	var status onesphere.Status
+	if err := c.call(ctx, "GetStatus"); err != nil {
+		return status, err
+	}
+	err := c.get("/rest/status", &status)
+	return status, err
+}
+
+func (c *Client) GetVersions() (onesphere.Versions, error) {
+	return c.GetVersionsCtx(context.Background())
+}
+
+func (c *Client) GetVersionsCtx(ctx context.Context) (onesphere.Versions, error) {
+	var versions onesphere.Versions
+	if err := c.call(ctx, "GetVersions"); err != nil {
+		return versions, err
+	}
+	err := c.get("/rest/about/versions", &versions)
+	return versions, err
+}
@@ -0,0 +1,102 @@
+// (C) Copyright 2018 Hewlett Packard Enterprise Development LP.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.  IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package onespherefake
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/HewlettPackard/hpe-onesphere-go"
+)
+
+func (c *Client) GetTags(view string) (onesphere.TagList, error) {
+	var tags onesphere.TagList
+	if err := c.call(context.Background(), "GetTags"); err != nil {
+		return tags, err
+	}
+	err := c.list("/rest/tags", filter{}, &tags)
+	return tags, err
+}
+
+func (c *Client) GetTagByID(id, view string) (onesphere.Tag, error) {
+	var tag onesphere.Tag
+	if id == "" {
+		return tag, fmt.Errorf("id must not be empty")
+	}
+	if err := c.call(context.Background(), "GetTagByID"); err != nil {
+		return tag, err
+	}
+	err := c.get("/rest/tags/"+id, &tag)
+	return tag, err
+}
+
+func (c *Client) CreateTag(tagRequest onesphere.TagRequest) (onesphere.Tag, error) {
+	var tag onesphere.Tag
+	if err := c.call(context.Background(), "CreateTag"); err != nil {
+		return tag, err
+	}
+	err := c.create("/rest/tags", tagRequest, &tag)
+	return tag, err
+}
+
+func (c *Client) DeleteTag(tagId string) error {
+	if err := c.call(context.Background(), "DeleteTag"); err != nil {
+		return err
+	}
+	return c.remove("/rest/tags/" + tagId)
+}
+
+func (c *Client) GetTagKeys(view string) (onesphere.TagKeyList, error) {
+	var tagKeys onesphere.TagKeyList
+	if err := c.call(context.Background(), "GetTagKeys"); err != nil {
+		return tagKeys, err
+	}
+	err := c.list("/rest/tag-keys", filter{}, &tagKeys)
+	return tagKeys, err
+}
+
+func (c *Client) GetTagKeyByID(id, view string) (onesphere.TagKey, error) {
+	var tagKey onesphere.TagKey
+	if id == "" {
+		return tagKey, fmt.Errorf("id must not be empty")
+	}
+	if err := c.call(context.Background(), "GetTagKeyByID"); err != nil {
+		return tagKey, err
+	}
+	err := c.get("/rest/tag-keys/"+id, &tagKey)
+	return tagKey, err
+}
+
+func (c *Client) CreateTagKey(tagKeyRequest onesphere.TagKeyRequest) (onesphere.TagKey, error) {
+	var tagKey onesphere.TagKey
+	if err := c.call(context.Background(), "CreateTagKey"); err != nil {
+		return tagKey, err
+	}
+	err := c.create("/rest/tag-keys", tagKeyRequest, &tagKey)
+	return tagKey, err
+}
+
+func (c *Client) DeleteTagKey(tagKeyId string) error {
+	if err := c.call(context.Background(), "DeleteTagKey"); err != nil {
+		return err
+	}
+	return c.remove("/rest/tag-keys/" + tagKeyId)
+}
@@ -0,0 +1,134 @@
+// (C) Copyright 2018 Hewlett Packard Enterprise Development LP.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.  IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package onespherefake
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/HewlettPackard/hpe-onesphere-go"
+)
+
+func (c *Client) GetUsers(userQuery string) (onesphere.UserList, error) {
+	if err := c.call(context.Background(), "GetUsers"); err != nil {
+		return onesphere.UserList{}, err
+	}
+	return c.ListUsers(onesphere.UserListOptions{UserQuery: userQuery})
+}
+
+func (c *Client) ListUsers(opts onesphere.UserListOptions) (onesphere.UserList, error) {
+	return c.ListUsersCtx(context.Background(), opts)
+}
+
+func (c *Client) ListUsersCtx(ctx context.Context, opts onesphere.UserListOptions) (onesphere.UserList, error) {
+	var users onesphere.UserList
+	if err := c.call(ctx, "ListUsers"); err != nil {
+		return users, err
+	}
+	err := c.list("/rest/users", filter{userQuery: opts.UserQuery, opts: opts.ListOptions}, &users)
+	return users, err
+}
+
+func (c *Client) ForEachUser(opts onesphere.UserListOptions, fn func(onesphere.User) error) error {
+	return c.ForEachUserCtx(context.Background(), opts, fn)
+}
+
+func (c *Client) ForEachUserCtx(ctx context.Context, opts onesphere.UserListOptions, fn func(onesphere.User) error) error {
+	if err := c.call(ctx, "ForEachUser"); err != nil {
+		return err
+	}
+	opts.Count = 0
+	users, err := c.ListUsersCtx(ctx, opts)
+	if err != nil {
+		return err
+	}
+	return visitAll(len(users.Members), func(i int) error { return fn(users.Members[i]) })
+}
+
+func (c *Client) GetUserByID(id string) (onesphere.User, error) {
+	var user onesphere.User
+	if id == "" {
+		return user, fmt.Errorf("id must not be empty")
+	}
+	if err := c.call(context.Background(), "GetUserByID"); err != nil {
+		return user, err
+	}
+	err := c.get("/rest/users/"+id, &user)
+	return user, err
+}
+
+func (c *Client) GetUserByName(name string) (onesphere.User, error) {
+	return c.GetUserByNameCtx(context.Background(), name)
+}
+
+func (c *Client) GetUserByNameCtx(ctx context.Context, name string) (onesphere.User, error) {
+	if name == "" {
+		return onesphere.User{}, fmt.Errorf("name must not be empty")
+	}
+	if err := c.call(ctx, "GetUserByName"); err != nil {
+		return onesphere.User{}, err
+	}
+	users, err := c.ListUsersCtx(ctx, onesphere.UserListOptions{})
+	if err != nil {
+		return onesphere.User{}, err
+	}
+	names, uris := make([]string, len(users.Members)), make([]string, len(users.Members))
+	for i, user := range users.Members {
+		names[i], uris[i] = user.Name, user.URI
+	}
+	i, err := matchName("user", name, names, uris)
+	if err != nil {
+		return onesphere.User{}, err
+	}
+	return users.Members[i], nil
+}
+
+func (c *Client) CreateUser(userRequest onesphere.UserRequest) (onesphere.User, error) {
+	var user onesphere.User
+	if err := c.call(context.Background(), "CreateUser"); err != nil {
+		return user, err
+	}
+	// the password is write only
+	userRequest.Password = ""
+	err := c.create("/rest/users", userRequest, &user)
+	return user, err
+}
+
+// UpdateUser sets the non-empty fields of updates
+func (c *Client) UpdateUser(userId string, updates onesphere.UserRequest) (onesphere.User, error) {
+	var user onesphere.User
+	if userId == "" {
+		return user, fmt.Errorf("userId must be non-empty")
+	}
+	if err := c.call(context.Background(), "UpdateUser"); err != nil {
+		return user, err
+	}
+	updates.Password = ""
+	err := c.merge("/rest/users/"+userId, updates, &user)
+	return user, err
+}
+
+func (c *Client) DeleteUser(userId string) error {
+	if err := c.call(context.Background(), "DeleteUser"); err != nil {
+		return err
+	}
+	return c.remove("/rest/users/" + userId)
+}
@@ -0,0 +1,64 @@
+// (C) Copyright 2018 Hewlett Packard Enterprise Development LP.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.  IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package onespherefake
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/HewlettPackard/hpe-onesphere-go"
+	"github.com/HewlettPackard/hpe-onesphere-go/query"
+)
+
+// GetVirtualMachineProfiles decodes the matching profiles into a
+// VirtualMachineProfileList the way the Client does
+func (c *Client) GetVirtualMachineProfiles(query string) (onesphere.VirtualMachineProfileList, error) {
+	var profiles onesphere.VirtualMachineProfileList
+	if err := c.call(context.Background(), "GetVirtualMachineProfiles"); err != nil {
+		return profiles, err
+	}
+	err := c.list("/rest/virtual-machine-profiles", filter{query: query}, &profiles)
+	return profiles, err
+}
+
+func (c *Client) GetVirtualMachineProfilesByServiceURI(serviceURI string) (onesphere.VirtualMachineProfileList, error) {
+	return c.GetVirtualMachineProfiles(query.Eq("serviceUri", serviceURI).String())
+}
+
+func (c *Client) GetVirtualMachineProfilesByZoneURI(zoneURI string) (onesphere.VirtualMachineProfileList, error) {
+	return c.GetVirtualMachineProfiles(query.Eq("zoneUri", zoneURI).String())
+}
+
+func (c *Client) GetVirtualMachineProfilesByServiceAndZoneURI(serviceURI, zoneURI string) (onesphere.VirtualMachineProfileList, error) {
+	return c.GetVirtualMachineProfiles(query.Eq("serviceUri", serviceURI).And(query.Eq("zoneUri", zoneURI)).String())
+}
+
+func (c *Client) GetVirtualMachineProfileByID(id string) (onesphere.VirtualMachineProfile, error) {
+	var profile onesphere.VirtualMachineProfile
+	if id == "" {
+		return profile, fmt.Errorf("id must not be empty")
+	}
+	if err := c.call(context.Background(), "GetVirtualMachineProfileByID"); err != nil {
+		return profile, err
+	}
+	err := c.get("/rest/virtual-machine-profiles/"+id, &profile)
+	return profile, err
+}
@@ -0,0 +1,119 @@
+// (C) Copyright 2018 Hewlett Packard Enterprise Development LP.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.  IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package onespherefake
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/HewlettPackard/hpe-onesphere-go"
+)
+
+func (c *Client) GetVolumes(query, view string) (onesphere.VolumeList, error) {
+	return c.GetVolumesCtx(context.Background(), query, view)
+}
+
+func (c *Client) GetVolumesCtx(ctx context.Context, query, view string) (onesphere.VolumeList, error) {
+	if err := c.call(ctx, "GetVolumes"); err != nil {
+		return onesphere.VolumeList{}, err
+	}
+	return c.ListVolumesCtx(ctx, onesphere.VolumeListOptions{
+		Query:       query,
+		ListOptions: onesphere.ListOptions{View: onesphere.View(view)},
+	})
+}
+
+func (c *Client) ListVolumes(opts onesphere.VolumeListOptions) (onesphere.VolumeList, error) {
+	return c.ListVolumesCtx(context.Background(), opts)
+}
+
+func (c *Client) ListVolumesCtx(ctx context.Context, opts onesphere.VolumeListOptions) (onesphere.VolumeList, error) {
+	var volumes onesphere.VolumeList
+	if err := c.call(ctx, "ListVolumes"); err != nil {
+		return volumes, err
+	}
+	err := c.list("/rest/volumes", filter{query: opts.Query, opts: opts.ListOptions}, &volumes)
+	return volumes, err
+}
+
+func (c *Client) CreateVolume(name string, sizeGiB int, zoneUri, projectUri string) (onesphere.Volume, error) {
+	return c.CreateVolumeCtx(context.Background(), name, sizeGiB, zoneUri, projectUri)
+}
+
+func (c *Client) CreateVolumeCtx(ctx context.Context, name string, sizeGiB int, zoneUri, projectUri string) (onesphere.Volume, error) {
+	var volume onesphere.Volume
+	if err := c.call(ctx, "CreateVolume"); err != nil {
+		return volume, err
+	}
+	err := c.create("/rest/volumes", map[string]interface{}{
+		"name":       name,
+		"sizeGiB":    sizeGiB,
+		"zoneUri":    zoneUri,
+		"projectUri": projectUri,
+	}, &volume)
+	return volume, err
+}
+
+func (c *Client) GetVolume(volumeID string) (onesphere.Volume, error) {
+	return c.GetVolumeCtx(context.Background(), volumeID)
+}
+
+func (c *Client) GetVolumeCtx(ctx context.Context, volumeID string) (onesphere.Volume, error) {
+	var volume onesphere.Volume
+	if volumeID == "" {
+		return volume, fmt.Errorf("volumeID must not be empty")
+	}
+	if err := c.call(ctx, "GetVolume"); err != nil {
+		return volume, err
+	}
+	err := c.get("/rest/volumes/"+volumeID, &volume)
+	return volume, err
+}
+
+func (c *Client) UpdateVolume(volumeID, name string, sizeGiB int) (onesphere.Volume, error) {
+	return c.UpdateVolumeCtx(context.Background(), volumeID, name, sizeGiB)
+}
+
+func (c *Client) UpdateVolumeCtx(ctx context.Context, volumeID, name string, sizeGiB int) (onesphere.Volume, error) {
+	var volume onesphere.Volume
+	if volumeID == "" {
+		return volume, fmt.Errorf("volumeID must not be empty")
+	}
+	if err := c.call(ctx, "UpdateVolume"); err != nil {
+		return volume, err
+	}
+	err := c.merge("/rest/volumes/"+volumeID, map[string]interface{}{"name": name, "sizeGiB": sizeGiB}, &volume)
+	return volume, err
+}
+
+func (c *Client) DeleteVolume(volumeID string) error {
+	return c.DeleteVolumeCtx(context.Background(), volumeID)
+}
+
+func (c *Client) DeleteVolumeCtx(ctx context.Context, volumeID string) error {
+	if volumeID == "" {
+		return fmt.Errorf("volumeID must not be empty")
+	}
+	if err := c.call(ctx, "DeleteVolume"); err != nil {
+		return err
+	}
+	return c.remove("/rest/volumes/" + volumeID)
+}
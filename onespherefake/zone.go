@@ -0,0 +1,308 @@
+// (C) Copyright 2018 Hewlett Packard Enterprise Development LP.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.  IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package onespherefake
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/HewlettPackard/hpe-onesphere-go"
+)
+
+func (c *Client) GetZones(query, regionUri, providerUri, applianceUri, view string) (onesphere.ZoneList, error) {
+	return c.GetZonesCtx(context.Background(), query, regionUri, providerUri, applianceUri, view)
+}
+
+func (c *Client) GetZonesCtx(ctx context.Context, query, regionUri, providerUri, applianceUri, view string) (onesphere.ZoneList, error) {
+	if err := c.call(ctx, "GetZones"); err != nil {
+		return onesphere.ZoneList{}, err
+	}
+	return c.ListZonesCtx(ctx, onesphere.ZoneListOptions{
+		Query:        query,
+		RegionURI:    regionUri,
+		ProviderURI:  providerUri,
+		ApplianceURI: applianceUri,
+		ListOptions:  onesphere.ListOptions{View: onesphere.View(view)},
+	})
+}
+
+func (c *Client) ListZones(opts onesphere.ZoneListOptions) (onesphere.ZoneList, error) {
+	return c.ListZonesCtx(context.Background(), opts)
+}
+
+func (c *Client) ListZonesCtx(ctx context.Context, opts onesphere.ZoneListOptions) (onesphere.ZoneList, error) {
+	var zones onesphere.ZoneList
+	if err := c.call(ctx, "ListZones"); err != nil {
+		return zones, err
+	}
+	err := c.list("/rest/zones", filter{
+		query: opts.Query,
+		fields: map[string]string{
+			"regionUri":    opts.RegionURI,
+			"providerUri":  opts.ProviderURI,
+			"applianceUri": opts.ApplianceURI,
+		},
+		opts: opts.ListOptions,
+	}, &zones)
+	return zones, err
+}
+
+func (c *Client) ForEachZone(opts onesphere.ZoneListOptions, fn func(onesphere.Zone) error) error {
+	return c.ForEachZoneCtx(context.Background(), opts, fn)
+}
+
+func (c *Client) ForEachZoneCtx(ctx context.Context, opts onesphere.ZoneListOptions, fn func(onesphere.Zone) error) error {
+	if err := c.call(ctx, "ForEachZone"); err != nil {
+		return err
+	}
+	opts.Count = 0
+	zones, err := c.ListZonesCtx(ctx, opts)
+	if err != nil {
+		return err
+	}
+	return visitAll(len(zones.Members), func(i int) error { return fn(zones.Members[i]) })
+}
+
+func (c *Client) GetZoneByID(id string) (onesphere.Zone, error) {
+	return c.GetZoneByIDCtx(context.Background(), id)
+}
+
+func (c *Client) GetZoneByIDCtx(ctx context.Context, id string) (onesphere.Zone, error) {
+	var zone onesphere.Zone
+	if id == "" {
+		return zone, fmt.Errorf("id must not be empty")
+	}
+	if err := c.call(ctx, "GetZoneByID"); err != nil {
+		return zone, err
+	}
+	err := c.get("/rest/zones/"+id, &zone)
+	return zone, err
+}
+
+func (c *Client) GetZoneByName(name string) (onesphere.Zone, error) {
+	return c.GetZoneByNameCtx(context.Background(), name)
+}
+
+func (c *Client) GetZoneByNameCtx(ctx context.Context, name string) (onesphere.Zone, error) {
+	if name == "" {
+		return onesphere.Zone{}, fmt.Errorf("name must not be empty")
+	}
+	if err := c.call(ctx, "GetZoneByName"); err != nil {
+		return onesphere.Zone{}, err
+	}
+	zones, err := c.ListZonesCtx(ctx, onesphere.ZoneListOptions{})
+	if err != nil {
+		return onesphere.Zone{}, err
+	}
+	names, uris := make([]string, len(zones.Members)), make([]string, len(zones.Members))
+	for i, zone := range zones.Members {
+		names[i], uris[i] = zone.Name, zone.URI
+	}
+	i, err := matchName("zone", name, names, uris)
+	if err != nil {
+		return onesphere.Zone{}, err
+	}
+	return zones.Members[i], nil
+}
+
+// GetZoneApplianceImage returns the string seeded at the appliance-image uri of the zone
+func (c *Client) GetZoneApplianceImage(id string) (string, error) {
+	return c.GetZoneApplianceImageCtx(context.Background(), id)
+}
+
+func (c *Client) GetZoneApplianceImageCtx(ctx context.Context, id string) (string, error) {
+	if id == "" {
+		return "", fmt.Errorf("id must not be empty")
+	}
+	if err := c.call(ctx, "GetZoneApplianceImage"); err != nil {
+		return "", err
+	}
+	return c.subresource("/rest/zones/"+id, "/appliance-image")
+}
+
+// GetZoneTaskStatus returns the string seeded at the task-status uri of the zone
+func (c *Client) GetZoneTaskStatus(id string) (string, error) {
+	return c.GetZoneTaskStatusCtx(context.Background(), id)
+}
+
+func (c *Client) GetZoneTaskStatusCtx(ctx context.Context, id string) (string, error) {
+	if id == "" {
+		return "", fmt.Errorf("id must not be empty")
+	}
+	if err := c.call(ctx, "GetZoneTaskStatus"); err != nil {
+		return "", err
+	}
+	return c.subresource("/rest/zones/"+id, "/task-status")
+}
+
+func (c *Client) GetZoneConnections(id, uuid string) (onesphere.ConnectionList, error) {
+	return c.GetZoneConnectionsCtx(context.Background(), id, uuid)
+}
+
+func (c *Client) GetZoneConnectionsCtx(ctx context.Context, id, uuid string) (onesphere.ConnectionList, error) {
+	var connections onesphere.ConnectionList
+	if err := c.call(ctx, "GetZoneConnections"); err != nil {
+		return connections, err
+	}
+	if err := c.exists(http.MethodGet, "/rest/zones/"+id); err != nil {
+		return connections, err
+	}
+	err := c.list("/rest/zones/"+id+"/connections", filter{fields: map[string]string{"uuid": uuid}}, &connections)
+	return connections, err
+}
+
+func (c *Client) CreateZone(zoneRequest onesphere.ZoneRequest) (onesphere.Zone, error) {
+	return c.CreateZoneCtx(context.Background(), zoneRequest)
+}
+
+func (c *Client) CreateZoneCtx(ctx context.Context, zoneRequest onesphere.ZoneRequest) (onesphere.Zone, error) {
+	var zone onesphere.Zone
+	if err := c.call(ctx, "CreateZone"); err != nil {
+		return zone, err
+	}
+	err := c.create("/rest/zones", zoneRequest, &zone)
+	return zone, err
+}
+
+func (c *Client) CreateZoneConnection(id string, connectionRequest onesphere.ConnectionRequest) (onesphere.Connection, error) {
+	return c.CreateZoneConnectionCtx(context.Background(), id, connectionRequest)
+}
+
+func (c *Client) CreateZoneConnectionCtx(ctx context.Context, id string, connectionRequest onesphere.ConnectionRequest) (onesphere.Connection, error) {
+	var connection onesphere.Connection
+	if err := c.call(ctx, "CreateZoneConnection"); err != nil {
+		return connection, err
+	}
+	if err := c.exists(http.MethodPost, "/rest/zones/"+id); err != nil {
+		return connection, err
+	}
+	err := c.create("/rest/zones/"+id+"/connections", connectionRequest, &connection)
+	return connection, err
+}
+
+func (c *Client) UpdateZone(zoneId string, updates []*onesphere.PatchOp) (onesphere.Zone, error) {
+	return c.UpdateZoneCtx(context.Background(), zoneId, updates)
+}
+
+func (c *Client) UpdateZoneCtx(ctx context.Context, zoneId string, updates []*onesphere.PatchOp) (onesphere.Zone, error) {
+	var zone onesphere.Zone
+	if err := c.call(ctx, "UpdateZone"); err != nil {
+		return zone, err
+	}
+	err := c.patch("/rest/zones/"+zoneId, updates, &zone)
+	return zone, err
+}
+
+// UpdateZoneConnection patches the zone connection whose uuid is connectionUuid
+func (c *Client) UpdateZoneConnection(zoneId, connectionUuid string, updates []*onesphere.PatchOp) (onesphere.Connection, error) {
+	return c.UpdateZoneConnectionCtx(context.Background(), zoneId, connectionUuid, updates)
+}
+
+func (c *Client) UpdateZoneConnectionCtx(ctx context.Context, zoneId, connectionUuid string, updates []*onesphere.PatchOp) (onesphere.Connection, error) {
+	var connection onesphere.Connection
+	if err := c.call(ctx, "UpdateZoneConnection"); err != nil {
+		return connection, err
+	}
+	uri, err := c.zoneConnectionURI(http.MethodPatch, zoneId, connectionUuid)
+	if err != nil {
+		return connection, err
+	}
+	err = c.patch(uri, updates, &connection)
+	return connection, err
+}
+
+func (c *Client) DeleteZone(zoneId string) error {
+	return c.DeleteZoneCtx(context.Background(), zoneId)
+}
+
+func (c *Client) DeleteZoneCtx(ctx context.Context, zoneId string) error {
+	if err := c.call(ctx, "DeleteZone"); err != nil {
+		return err
+	}
+	return c.remove("/rest/zones/" + zoneId)
+}
+
+func (c *Client) DeleteZoneConnection(zoneId, connectionUuid string) error {
+	return c.DeleteZoneConnectionCtx(context.Background(), zoneId, connectionUuid)
+}
+
+func (c *Client) DeleteZoneConnectionCtx(ctx context.Context, zoneId, connectionUuid string) error {
+	if err := c.call(ctx, "DeleteZoneConnection"); err != nil {
+		return err
+	}
+	uri, err := c.zoneConnectionURI(http.MethodDelete, zoneId, connectionUuid)
+	if err != nil {
+		return err
+	}
+	return c.remove(uri)
+}
+
+// ActionZone only checks that the zone exists
+func (c *Client) ActionZone(zoneId string, action onesphere.ZoneAction) error {
+	return c.ActionZoneCtx(context.Background(), zoneId, action)
+}
+
+func (c *Client) ActionZoneCtx(ctx context.Context, zoneId string, action onesphere.ZoneAction) error {
+	if zoneId == "" {
+		return fmt.Errorf("zoneId must be non-empty")
+	}
+	if err := c.call(ctx, "ActionZone"); err != nil {
+		return err
+	}
+	return c.exists(http.MethodPost, "/rest/zones/"+zoneId)
+}
+
+func (c *Client) GetZoneTypes() (onesphere.ZoneTypeList, error) {
+	var zoneTypes onesphere.ZoneTypeList
+	if err := c.call(context.Background(), "GetZoneTypes"); err != nil {
+		return zoneTypes, err
+	}
+	err := c.list("/rest/zone-types", filter{}, &zoneTypes)
+	return zoneTypes, err
+}
+
+// GetZoneTypeResourceProfiles lists the profiles seeded under the resource-profiles uri of the zone type
+func (c *Client) GetZoneTypeResourceProfiles(zoneTypeId string) (onesphere.ZoneTypeResourceProfileList, error) {
+	var profiles onesphere.ZoneTypeResourceProfileList
+	if err := c.call(context.Background(), "GetZoneTypeResourceProfiles"); err != nil {
+		return profiles, err
+	}
+	if err := c.exists(http.MethodGet, "/rest/zone-types/"+zoneTypeId); err != nil {
+		return profiles, err
+	}
+	err := c.list("/rest/zone-types/"+zoneTypeId+"/resource-profiles", filter{}, &profiles)
+	return profiles, err
+}
+
+// zoneConnectionURI returns the uri of the connection of the zone whose uuid is connectionUuid
+func (c *Client) zoneConnectionURI(method, zoneId, connectionUuid string) (string, error) {
+	collection := "/rest/zones/" + zoneId + "/connections"
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, obj := range c.members(collection) {
+		if obj["uuid"] == connectionUuid {
+			return obj["uri"].(string), nil
+		}
+	}
+	return "", notFound(method, collection+"/"+connectionUuid)
+}
@@ -0,0 +1,481 @@
+// (C) Copyright 2018 Hewlett Packard Enterprise Development LP.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.  IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package onespheretest
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/HewlettPackard/hpe-onesphere-go/internal/jsondoc"
+	"github.com/HewlettPackard/hpe-onesphere-go/query"
+)
+
+// apiError is the error body returned by OneSphere
+type apiError struct {
+	status             int
+	ErrorCode          string   `json:"errorCode"`
+	Message            string   `json:"message"`
+	Details            string   `json:"details"`
+	RecommendedActions []string `json:"recommendedActions"`
+	ErrorSource        string   `json:"errorSource"`
+	CanForce           bool     `json:"canForce"`
+}
+
+func notFound(uri string) *apiError {
+	return &apiError{
+		status:             http.StatusNotFound,
+		ErrorCode:          "NOT_FOUND",
+		Message:            "The requested resource could not be found.",
+		Details:            "The resource " + uri + " does not exist.",
+		RecommendedActions: []string{"Check the uri of the resource and try again."},
+		ErrorSource:        uri,
+	}
+}
+
+func badRequest(uri, details string) *apiError {
+	return &apiError{
+		status:             http.StatusBadRequest,
+		ErrorCode:          "BAD_REQUEST",
+		Message:            "The request is invalid.",
+		Details:            details,
+		RecommendedActions: []string{"Correct the request and try again."},
+		ErrorSource:        uri,
+	}
+}
+
+func unauthorized(uri string) *apiError {
+	return &apiError{
+		status:             http.StatusUnauthorized,
+		ErrorCode:          "UNAUTHORIZED",
+		Message:            "The session is not valid.",
+		Details:            "The request does not carry a valid session token.",
+		RecommendedActions: []string{"Log in again and retry the request."},
+		ErrorSource:        uri,
+	}
+}
+
+func protected(uri string) *apiError {
+	return &apiError{
+		status:             http.StatusConflict,
+		ErrorCode:          "RESOURCE_PROTECTED",
+		Message:            "The resource is protected and cannot be deleted.",
+		Details:            "The resource " + uri + " is protected.",
+		RecommendedActions: []string{"Protected resources are managed by OneSphere."},
+		ErrorSource:        uri,
+	}
+}
+
+func methodNotAllowed(method, uri string) *apiError {
+	return &apiError{
+		status:             http.StatusMethodNotAllowed,
+		ErrorCode:          "METHOD_NOT_ALLOWED",
+		Message:            "The request method is not supported by the resource.",
+		Details:            method + " is not supported by " + uri + ".",
+		RecommendedActions: []string{"Check the API documentation for the supported methods."},
+		ErrorSource:        uri,
+	}
+}
+
+// public lists the paths served without a session
+var public = []string{"/rest/session/idp", "/rest/status", "/rest/about/versions"}
+
+// ServeHTTP answers the OneSphere API requests
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimSuffix(r.URL.Path, "/")
+
+	var body interface{}
+	if data, _ := ioutil.ReadAll(r.Body); len(data) > 0 {
+		if err := json.Unmarshal(data, &body); err != nil {
+			writeError(w, badRequest(path, "The request body is not valid JSON: "+err.Error()))
+			return
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	status, resp, err := s.serve(r.Method, path, r.URL.Query(), r.Header.Get("Authorization"), body)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, status, resp)
+}
+
+// serve routes a request and returns the status and body of the response
+func (s *Server) serve(method, path string, params url.Values, token string, body interface{}) (int, interface{}, *apiError) {
+	if path == "/rest/session" && method == http.MethodPost {
+		return s.login(path, body)
+	}
+
+	userURI, ok := s.sessions[token]
+	if !ok && !isPublic(path) {
+		return 0, nil, unauthorized(path)
+	}
+
+	switch {
+	case path == "/rest/session":
+		return s.session(method, path, params, token, userURI)
+	case method == http.MethodPost && matchPath("/rest/*/*/actions", path):
+		return s.action(path)
+	case method == http.MethodPost && matchPath("/rest/deployments/*/console", path):
+		return s.read(path, params)
+	case method == http.MethodPost && matchPath("/rest/regions/*/connection", path):
+		return s.replace(path, body)
+	}
+
+	switch method {
+	case http.MethodGet:
+		return s.read(path, params)
+	case http.MethodPost:
+		return s.create(path, body)
+	case http.MethodPatch:
+		return s.update(path, body)
+	case http.MethodPut:
+		if _, ok := s.docs[path]; !ok {
+			return 0, nil, notFound(path)
+		}
+		return s.replace(path, body)
+	case http.MethodDelete:
+		return s.delete(path)
+	}
+	return 0, nil, methodNotAllowed(method, path)
+}
+
+// login creates a session for the userName and password of body
+func (s *Server) login(path string, body interface{}) (int, interface{}, *apiError) {
+	creds, _ := body.(object)
+	userName, _ := creds["userName"].(string)
+	password, _ := creds["password"].(string)
+
+	l, ok := s.logins[userName]
+	if !ok || l.password != password {
+		return 0, nil, &apiError{
+			status:             http.StatusUnauthorized,
+			ErrorCode:          "INVALID_CREDENTIALS",
+			Message:            "The user name or password is incorrect.",
+			Details:            "Login failed for user " + strconv.Quote(userName) + ".",
+			RecommendedActions: []string{"Check the user name and password and try again."},
+			ErrorSource:        path,
+		}
+	}
+
+	token := newToken()
+	s.sessions[token] = l.userURI
+	return http.StatusOK, object{"token": token, "userUri": l.userURI}, nil
+}
+
+// session reads or ends the session of token
+func (s *Server) session(method, path string, params url.Values, token, userURI string) (int, interface{}, *apiError) {
+	switch method {
+	case http.MethodGet:
+		session := object{"token": token, "userUri": userURI}
+		if params.Get("view") == "full" {
+			session["user"] = s.docs[userURI]
+		}
+		return http.StatusOK, session, nil
+	case http.MethodDelete:
+		delete(s.sessions, token)
+		return http.StatusNoContent, nil, nil
+	}
+	return 0, nil, methodNotAllowed(method, path)
+}
+
+// read returns the document at path or lists the collection at path
+func (s *Server) read(path string, params url.Values) (int, interface{}, *apiError) {
+	if doc, ok := s.docs[path]; ok {
+		return http.StatusOK, doc, nil
+	}
+	if !s.isCollection(path) || !s.parentExists(path) {
+		return 0, nil, notFound(path)
+	}
+	return s.list(path, params)
+}
+
+// list returns a page of the members of collection matching params
+func (s *Server) list(collection string, params url.Values) (int, interface{}, *apiError) {
+	q, err := query.Parse(params.Get("query"))
+	if err != nil {
+		return 0, nil, badRequest(collection, err.Error())
+	}
+	start, err := intParam(params, "start", 0)
+	if err != nil {
+		return 0, nil, badRequest(collection, err.Error())
+	}
+	count, err := intParam(params, "count", -1)
+	if err != nil {
+		return 0, nil, badRequest(collection, err.Error())
+	}
+	userQuery := strings.Trim(params.Get("userQuery"), "'\"")
+	since, err := timeParam(params, "startTime")
+	if err != nil {
+		return 0, nil, badRequest(collection, err.Error())
+	}
+	until, err := timeParam(params, "endTime")
+	if err != nil {
+		return 0, nil, badRequest(collection, err.Error())
+	}
+
+	matched := []object{}
+	for _, doc := range s.members(collection) {
+		if q.Match(jsondoc.Lookup(doc)) && jsondoc.ContainsText(doc, userQuery) && matchesParams(doc, params) &&
+			createdWithin(doc, since, until) {
+			matched = append(matched, doc)
+		}
+	}
+	if sort := params.Get("sort"); sort != "" {
+		jsondoc.Sort(matched, sort)
+	}
+
+	total := len(matched)
+	if start > total {
+		start = total
+	}
+	end := total
+	if count >= 0 && start+count < total {
+		end = start + count
+	}
+
+	page := object{
+		"total":   total,
+		"start":   start,
+		"count":   end - start,
+		"members": matched[start:end],
+	}
+	if end < total {
+		next := url.Values{}
+		for key, values := range params {
+			next[key] = values
+		}
+		next.Set("start", strconv.Itoa(end))
+		next.Set("count", strconv.Itoa(end-start))
+		page["nextPageUri"] = collection + "?" + next.Encode()
+	}
+	return http.StatusOK, page, nil
+}
+
+// matchesParams reports whether doc has the values of the name and "...Uri"
+// parameters, which OneSphere uses to filter collections
+func matchesParams(doc object, params url.Values) bool {
+	get := jsondoc.Lookup(doc)
+	for key := range params {
+		if key != "name" && !strings.HasSuffix(key, "Uri") {
+			continue
+		}
+		if want := params.Get(key); want != "" {
+			if got, _ := get(key); got != want {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// createdWithin reports whether doc was created at or after since and before
+// until, the window of the startTime and endTime parameters of /rest/events
+func createdWithin(doc object, since, until time.Time) bool {
+	if since.IsZero() && until.IsZero() {
+		return true
+	}
+	value, _ := jsondoc.Lookup(doc)("created")
+	created, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return false
+	}
+	return (since.IsZero() || !created.Before(since)) && (until.IsZero() || created.Before(until))
+}
+
+// create adds the object body to the collection at path
+func (s *Server) create(path string, body interface{}) (int, interface{}, *apiError) {
+	if !s.isCollection(path) || !s.parentExists(path) {
+		return 0, nil, notFound(path)
+	}
+	doc, ok := body.(object)
+	if !ok {
+		return 0, nil, badRequest(path, "The request body must be a JSON object.")
+	}
+
+	password, _ := doc["password"].(string)
+	delete(doc, "password")
+	delete(doc, "id")
+	doc = s.insert(path, doc)
+
+	// users created with a password can log in
+	if email, _ := doc["email"].(string); path == "/rest/users" && email != "" && password != "" {
+		s.logins[email] = &login{password: password, userURI: doc["uri"].(string), user: copyObject(doc)}
+	}
+	return http.StatusCreated, doc, nil
+}
+
+// update applies the JSON Patch operations of body, or the non-empty fields
+// of an object body, to the document at path
+func (s *Server) update(path string, body interface{}) (int, interface{}, *apiError) {
+	doc, ok := s.docs[path].(object)
+	if !ok {
+		return 0, nil, notFound(path)
+	}
+
+	// apply to a copy so that a failing op leaves the resource unchanged
+	var patched interface{} = copyObject(doc)
+	switch body := body.(type) {
+	case []interface{}:
+		for _, o := range body {
+			op, _ := o.(object)
+			name, _ := op["op"].(string)
+			pointer, _ := op["path"].(string)
+			var err error
+			if patched, err = jsondoc.Patch(patched, name, pointer, op["value"]); err != nil {
+				return 0, nil, badRequest(path, err.Error())
+			}
+		}
+	case object:
+		for field, value := range body {
+			if !jsondoc.IsEmpty(value) {
+				patched.(object)[field] = value
+			}
+		}
+	default:
+		return 0, nil, badRequest(path, "The request body must be a JSON Patch array or an object.")
+	}
+
+	result, ok := patched.(object)
+	if !ok {
+		return 0, nil, badRequest(path, "The patched resource must remain an object.")
+	}
+	return http.StatusOK, s.keepIdentity(path, doc, result), nil
+}
+
+// replace stores body as the document at path
+func (s *Server) replace(path string, body interface{}) (int, interface{}, *apiError) {
+	if _, ok := s.docs[path[:strings.LastIndex(path, "/")]]; !ok {
+		if _, ok := s.docs[path]; !ok {
+			return 0, nil, notFound(path)
+		}
+	}
+	doc, ok := body.(object)
+	if !ok {
+		return 0, nil, badRequest(path, "The request body must be a JSON object.")
+	}
+	old, _ := s.docs[path].(object)
+	return http.StatusOK, s.keepIdentity(path, old, doc), nil
+}
+
+// keepIdentity stores doc at path with the id and uri of old, if any
+func (s *Server) keepIdentity(path string, old, doc object) object {
+	if old != nil {
+		if id, ok := old["id"]; ok {
+			doc["id"] = id
+		}
+		if uri, ok := old["uri"]; ok {
+			doc["uri"] = uri
+		}
+	}
+	s.put(path, doc)
+	return doc
+}
+
+// delete removes the document at path and its subresources
+func (s *Server) delete(path string) (int, interface{}, *apiError) {
+	doc, ok := s.docs[path]
+	if !ok {
+		return 0, nil, notFound(path)
+	}
+	if obj, _ := doc.(object); obj["protected"] == true {
+		return 0, nil, protected(path)
+	}
+	s.remove(path)
+	for userName, l := range s.logins {
+		if l.userURI == path {
+			delete(s.logins, userName)
+		}
+	}
+	return http.StatusNoContent, nil, nil
+}
+
+// action accepts an action on an existing resource
+func (s *Server) action(path string) (int, interface{}, *apiError) {
+	resource := strings.TrimSuffix(path, "/actions")
+	if _, ok := s.docs[resource]; !ok {
+		return 0, nil, notFound(resource)
+	}
+	return http.StatusAccepted, nil, nil
+}
+
+func isPublic(path string) bool {
+	for _, p := range public {
+		if p == path {
+			return true
+		}
+	}
+	return false
+}
+
+func intParam(params url.Values, name string, def int) (int, error) {
+	value := params.Get(name)
+	if value == "" {
+		return def, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("%s must be a non-negative integer, got %q", name, value)
+	}
+	return n, nil
+}
+
+func timeParam(params url.Values, name string) (time.Time, error) {
+	value := params.Get(name)
+	if value == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return t, fmt.Errorf("%s must be an RFC 3339 timestamp, got %q", name, value)
+	}
+	return t, nil
+}
+
+func newToken() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	if v == nil {
+		w.WriteHeader(status)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, err *apiError) {
+	writeJSON(w, err.status, err)
+}
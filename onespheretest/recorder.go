@@ -0,0 +1,234 @@
+// (C) Copyright 2018 Hewlett Packard Enterprise Development LP.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.  IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package onespheretest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/HewlettPackard/hpe-onesphere-go/redact"
+)
+
+// RecorderMode selects whether a Recorder captures or serves back the exchanges
+type RecorderMode int
+
+const (
+	// ModeRecord sends the requests on and saves every exchange to the cassette
+	ModeRecord RecorderMode = iota
+	// ModeReplay answers the requests from the cassette without any network access
+	ModeReplay
+)
+
+// cassette is the file format of the recorded exchanges
+type cassette struct {
+	Interactions []interaction `json:"interactions"`
+}
+
+type interaction struct {
+	Request  recordedRequest  `json:"request"`
+	Response recordedResponse `json:"response"`
+}
+
+type recordedRequest struct {
+	Method string      `json:"method"`
+	URI    string      `json:"uri"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+type recordedResponse struct {
+	StatusCode int         `json:"statusCode"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// Recorder is an http.RoundTripper saving the exchanges with a OneSphere
+// server to a cassette file and serving them back, so that tests recorded
+// once against a live appliance replay deterministically:
+//
+//	rec, err := onespheretest.NewRecorder("testdata/zones.json", onespheretest.ModeReplay)
+//	client, err := onesphere.Connect(host, user, password, onesphere.WithTransport(rec.Wrap))
+//
+// Session tokens, passwords and access or secret keys are scrubbed from the
+// saved headers and JSON bodies. A replayed request gets the first unused
+// exchange with the same method and request uri, whatever the host.
+type Recorder struct {
+	mode RecorderMode
+	path string
+	next http.RoundTripper
+
+	mu           sync.Mutex
+	interactions []interaction
+	played       []bool
+}
+
+// NewRecorder returns a Recorder for the cassette at path. In ModeRecord the
+// cassette is rewritten after each exchange, in ModeReplay it must exist.
+func NewRecorder(path string, mode RecorderMode) (*Recorder, error) {
+	r := &Recorder{mode: mode, path: path, next: http.DefaultTransport}
+
+	switch mode {
+	case ModeRecord:
+	case ModeReplay:
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		var c cassette
+		if err := json.Unmarshal(data, &c); err != nil {
+			return nil, fmt.Errorf("onespheretest: invalid cassette %s: %v", path, err)
+		}
+		r.interactions = c.Interactions
+		r.played = make([]bool, len(c.Interactions))
+	default:
+		return nil, fmt.Errorf("onespheretest: unknown recorder mode %d", mode)
+	}
+	return r, nil
+}
+
+// Wrap makes r send the recorded requests through next and returns r,
+// use it with onesphere.WithTransport
+func (r *Recorder) Wrap(next http.RoundTripper) http.RoundTripper {
+	r.next = next
+	return r
+}
+
+// RoundTrip records or replays a single exchange
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if r.mode == ModeReplay {
+		return r.replay(req)
+	}
+	return r.record(req, body)
+}
+
+// CloseIdleConnections closes the idle connections of the wrapped transport
+func (r *Recorder) CloseIdleConnections() {
+	if c, ok := r.next.(interface{ CloseIdleConnections() }); ok {
+		c.CloseIdleConnections()
+	}
+}
+
+func (r *Recorder) record(req *http.Request, body []byte) (*http.Response, error) {
+	out := req.Clone(req.Context())
+	out.Body = ioutil.NopCloser(bytes.NewReader(body))
+	out.ContentLength = int64(len(body))
+
+	resp, err := r.next.RoundTrip(out)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(respBody))
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.interactions = append(r.interactions, interaction{
+		Request: recordedRequest{
+			Method: req.Method,
+			URI:    req.URL.RequestURI(),
+			Header: scrubHeader(req.Header),
+			Body:   string(redact.JSON(body)),
+		},
+		Response: recordedResponse{
+			StatusCode: resp.StatusCode,
+			Header:     scrubHeader(resp.Header),
+			Body:       string(redact.JSON(respBody)),
+		},
+	})
+	if err := r.save(); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (r *Recorder) replay(req *http.Request) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	uri := req.URL.RequestURI()
+	for i, in := range r.interactions {
+		if r.played[i] || in.Request.Method != req.Method || in.Request.URI != uri {
+			continue
+		}
+		r.played[i] = true
+		// scrubbing may have changed the length of the recorded body
+		header := in.Response.Header.Clone()
+		if header == nil {
+			header = http.Header{}
+		}
+		header.Set("Content-Length", strconv.Itoa(len(in.Response.Body)))
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", in.Response.StatusCode, http.StatusText(in.Response.StatusCode)),
+			StatusCode:    in.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          ioutil.NopCloser(strings.NewReader(in.Response.Body)),
+			ContentLength: int64(len(in.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+	return nil, fmt.Errorf("onespheretest: no recorded response left for %s %s in %s", req.Method, uri, r.path)
+}
+
+// save writes the cassette, it may hold data of the recorded account so it is private
+func (r *Recorder) save() error {
+	data, err := json.MarshalIndent(cassette{Interactions: r.interactions}, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(r.path, data, 0600)
+}
+
+// scrubHeader returns a copy of header with the secret values redacted
+func scrubHeader(header http.Header) http.Header {
+	if len(header) == 0 {
+		return nil
+	}
+	return redact.Header(header)
+}
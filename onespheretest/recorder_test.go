@@ -0,0 +1,73 @@
+package onespheretest
+
+import (
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/HewlettPackard/hpe-onesphere-go/redact"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecorderScrubsSecrets(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	s.AddUser("lab@example.com", "s3cr3t-lab")
+	cassette := filepath.Join(t.TempDir(), "session.json")
+
+	rec, err := NewRecorder(cassette, ModeRecord)
+	assert.NoError(t, err)
+	client := &http.Client{Transport: rec.Wrap(http.DefaultTransport)}
+
+	resp, err := client.Post(s.URL+"/rest/session", "application/json",
+		strings.NewReader(`{"userName":"lab@example.com","password":"s3cr3t-lab","profile":{"apiAccessKey":"AKIA"}}`))
+	assert.NoError(t, err)
+	body, _ := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	assert.Contains(t, string(body), `"token"`)
+	assert.NotContains(t, string(body), redact.Redacted, "the caller gets the real response")
+
+	data, err := ioutil.ReadFile(cassette)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(data), "s3cr3t-lab")
+	assert.NotContains(t, string(data), "AKIA")
+	for token := range s.sessions {
+		assert.NotContains(t, string(data), token)
+	}
+	assert.Contains(t, string(data), "lab@example.com")
+}
+
+func TestRecorderReplay(t *testing.T) {
+	s := NewServer()
+	cassette := filepath.Join(t.TempDir(), "zones.json")
+	rec, err := NewRecorder(cassette, ModeRecord)
+	assert.NoError(t, err)
+	client := &http.Client{Transport: rec.Wrap(http.DefaultTransport)}
+
+	for _, path := range []string{"/rest/zones", "/rest/zones/missing"} {
+		resp, err := client.Get(s.URL + path)
+		assert.NoError(t, err)
+		resp.Body.Close()
+	}
+	s.Close()
+
+	_, err = NewRecorder(filepath.Join(t.TempDir(), "missing.json"), ModeReplay)
+	assert.Error(t, err)
+
+	rec, err = NewRecorder(cassette, ModeReplay)
+	assert.NoError(t, err)
+	client = &http.Client{Transport: rec}
+
+	resp, err := client.Get("http://another-host/rest/zones/missing")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	body, _ := ioutil.ReadAll(resp.Body)
+	assert.Contains(t, string(body), "UNAUTHORIZED")
+
+	_, err = client.Get("http://another-host/rest/zones/missing")
+	assert.Error(t, err, "each exchange is replayed once")
+	_, err = client.Get("http://another-host/rest/zones")
+	assert.NoError(t, err)
+}
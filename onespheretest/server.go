@@ -0,0 +1,296 @@
+// (C) Copyright 2018 Hewlett Packard Enterprise Development LP.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.  IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+// Package onespheretest provides Server, an in-process OneSphere API for
+// tests that exercise the real client over HTTP without a live host.
+//
+//	server := onespheretest.NewServer()
+//	defer server.Close()
+//	server.Seed("/rest/zones", map[string]interface{}{"id": "z1", "name": "prod"})
+//	client, err := onesphere.Connect(server.URL, onespheretest.DefaultUser, onespheretest.DefaultPassword)
+//
+// Resources are kept in memory as JSON documents keyed by uri. Collections
+// support creation, the query, userQuery, start, count and sort parameters,
+// JSON Patch updates and deletion, which is refused with 409 Conflict for the
+// documents marked "protected". Failures are answered with the error bodies
+// of OneSphere. Every request but logging in and the public endpoints such as
+// /rest/status needs a session token.
+package onespheretest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http/httptest"
+	"strings"
+	"sync"
+)
+
+// The credentials of the user created by NewServer
+const (
+	DefaultUser     = "administrator@onesphere.local"
+	DefaultPassword = "password"
+)
+
+// defaultCollections are the collections the Server accepts before anything is seeded
+var defaultCollections = []string{
+	"/rest/deployments",
+	"/rest/events",
+	"/rest/memberships",
+	"/rest/networks",
+	"/rest/projects",
+	"/rest/providers",
+	"/rest/regions",
+	"/rest/tag-keys",
+	"/rest/tags",
+	"/rest/users",
+	"/rest/zones",
+	"/rest/zones/*/connections",
+}
+
+// object is a resource decoded from its JSON document
+type object = map[string]interface{}
+
+// login holds the credentials of a user allowed to create a session
+type login struct {
+	password string
+	userURI  string
+	user     object
+}
+
+// Server is a fake OneSphere API listening on a local address
+type Server struct {
+	*httptest.Server
+
+	mu          sync.Mutex
+	lastID      int
+	docs        map[string]interface{}
+	order       []string
+	collections []string
+	logins      map[string]*login
+	sessions    map[string]string
+}
+
+// NewServer starts a Server with the default collections and a user
+// logging in with DefaultUser and DefaultPassword. Call Close when done.
+func NewServer() *Server {
+	s := &Server{
+		logins:   map[string]*login{},
+		sessions: map[string]string{},
+	}
+	s.reset()
+	s.AddUser(DefaultUser, DefaultPassword)
+	s.Server = httptest.NewServer(s)
+	return s
+}
+
+// AddUser creates a user able to log in with userName and password and returns its uri
+func (s *Server) AddUser(userName, password string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	user := s.insert("/rest/users", object{
+		"name":    userName,
+		"email":   userName,
+		"role":    "administrator",
+		"isLocal": true,
+	})
+	uri := user["uri"].(string)
+	s.logins[userName] = &login{password: password, userURI: uri, user: copyObject(user)}
+	return uri
+}
+
+// Seed adds resources such as onesphere.Zone values or maps to collection,
+// the collection is created when the Server does not know it yet.
+// Resources get an id when it is empty, the uri is derived from the id.
+func (s *Server) Seed(collection string, resources ...interface{}) error {
+	collection = strings.TrimSuffix(collection, "/")
+	docs := make([]object, 0, len(resources))
+	for _, resource := range resources {
+		var doc object
+		if err := roundtrip(resource, &doc); err != nil {
+			return err
+		}
+		if doc == nil {
+			return fmt.Errorf("onespheretest: %T is not a JSON object", resource)
+		}
+		docs = append(docs, doc)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.isCollection(collection) {
+		s.collections = append(s.collections, collection)
+	}
+	for _, doc := range docs {
+		s.insert(collection, doc)
+	}
+	return nil
+}
+
+// SeedAt stores value as the document at uri, replacing any previous one.
+// Use it for singletons such as /rest/status and for subresources.
+func (s *Server) SeedAt(uri string, value interface{}) error {
+	var doc interface{}
+	if err := roundtrip(value, &doc); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.put(uri, doc)
+	return nil
+}
+
+// Get decodes the document at uri into out and reports whether it exists
+func (s *Server) Get(uri string, out interface{}) (bool, error) {
+	s.mu.Lock()
+	doc, ok := s.docs[uri]
+	s.mu.Unlock()
+
+	if !ok {
+		return false, nil
+	}
+	return true, roundtrip(doc, out)
+}
+
+// Reset removes every seeded or created resource and collection and restores
+// the default status, versions and local identity provider. Users added with
+// AddUser and their sessions are kept.
+func (s *Server) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.reset()
+	for _, l := range s.logins {
+		s.put(l.userURI, copyObject(l.user))
+	}
+}
+
+func (s *Server) reset() {
+	s.docs = map[string]interface{}{}
+	s.order = nil
+	s.collections = append([]string(nil), defaultCollections...)
+	s.put("/rest/status", object{"service": "OK", "database": "OK"})
+	s.put("/rest/about/versions", object{"versions": []interface{}{"v1"}})
+	s.put("/rest/session/idp", object{"name": "OneSphere", "type": "local"})
+}
+
+// insert stores doc as a member of collection, assigning its id and uri
+func (s *Server) insert(collection string, doc object) object {
+	id, _ := doc["id"].(string)
+	if id == "" {
+		s.lastID++
+		id = fmt.Sprintf("00000000-0000-0000-0000-%012d", s.lastID)
+	}
+	doc["id"] = id
+	doc["uri"] = collection + "/" + id
+	s.put(collection+"/"+id, doc)
+	return doc
+}
+
+// put stores doc at uri keeping the order in which uris were first stored
+func (s *Server) put(uri string, doc interface{}) {
+	if _, ok := s.docs[uri]; !ok {
+		s.order = append(s.order, uri)
+	}
+	s.docs[uri] = doc
+}
+
+// remove deletes the document at uri and its subresources
+func (s *Server) remove(uri string) {
+	order := s.order[:0]
+	for _, u := range s.order {
+		if u == uri || strings.HasPrefix(u, uri+"/") {
+			delete(s.docs, u)
+			continue
+		}
+		order = append(order, u)
+	}
+	s.order = order
+}
+
+// members returns the documents directly below collection in insertion order
+func (s *Server) members(collection string) []object {
+	var members []object
+	for _, uri := range s.order {
+		rest := strings.TrimPrefix(uri, collection+"/")
+		if rest == uri || rest == "" || strings.Contains(rest, "/") {
+			continue
+		}
+		if doc, ok := s.docs[uri].(object); ok {
+			members = append(members, doc)
+		}
+	}
+	return members
+}
+
+// isCollection reports whether path names a known collection, "*" in the
+// known collections stands for any single path element
+func (s *Server) isCollection(path string) bool {
+	for _, collection := range s.collections {
+		if matchPath(collection, path) {
+			return true
+		}
+	}
+	return false
+}
+
+// parentExists reports whether the resource owning a nested collection exists
+func (s *Server) parentExists(collection string) bool {
+	for _, c := range s.collections {
+		if matchPath(c, collection) && !strings.Contains(c, "*") {
+			return true
+		}
+	}
+	_, ok := s.docs[collection[:strings.LastIndex(collection, "/")]]
+	return ok
+}
+
+// matchPath reports whether path matches pattern, "*" matches any single element
+func matchPath(pattern, path string) bool {
+	want, got := strings.Split(pattern, "/"), strings.Split(path, "/")
+	if len(want) != len(got) {
+		return false
+	}
+	for i := range want {
+		if want[i] != "*" && want[i] != got[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// roundtrip encodes v to JSON and decodes it into out
+func roundtrip(v interface{}, out interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, out)
+}
+
+// copyObject returns a deep copy of doc
+func copyObject(doc object) object {
+	var c object
+	roundtrip(doc, &c)
+	return c
+}
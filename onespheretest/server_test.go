@@ -0,0 +1,160 @@
+package onespheretest
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// do sends a request with token and decodes the JSON response into out
+func do(t *testing.T, s *Server, method, path, token string, body, out interface{}) int {
+	var buf bytes.Buffer
+	if body != nil {
+		assert.NoError(t, json.NewEncoder(&buf).Encode(body))
+	}
+	req, err := http.NewRequest(method, s.URL+path, &buf)
+	assert.NoError(t, err)
+	req.Header.Set("Authorization", token)
+	resp, err := http.DefaultClient.Do(req)
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+	if out != nil {
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(out))
+	}
+	return resp.StatusCode
+}
+
+func newSession(t *testing.T, s *Server) string {
+	var session map[string]string
+	status := do(t, s, "POST", "/rest/session", "", map[string]string{"userName": DefaultUser, "password": DefaultPassword}, &session)
+	assert.Equal(t, http.StatusOK, status)
+	return session["token"]
+}
+
+func TestSession(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	var apiErr apiError
+	status := do(t, s, "POST", "/rest/session", "", map[string]string{"userName": DefaultUser, "password": "wrong"}, &apiErr)
+	assert.Equal(t, http.StatusUnauthorized, status)
+	assert.Equal(t, "INVALID_CREDENTIALS", apiErr.ErrorCode)
+
+	status = do(t, s, "GET", "/rest/zones", "", nil, &apiErr)
+	assert.Equal(t, http.StatusUnauthorized, status)
+	assert.Equal(t, "UNAUTHORIZED", apiErr.ErrorCode)
+
+	token := newSession(t, s)
+	var session struct {
+		UserURI string                 `json:"userUri"`
+		User    map[string]interface{} `json:"user"`
+	}
+	assert.Equal(t, http.StatusOK, do(t, s, "GET", "/rest/session?view=full", token, nil, &session))
+	assert.Equal(t, session.UserURI, session.User["uri"])
+	assert.Equal(t, DefaultUser, session.User["email"])
+
+	assert.Equal(t, http.StatusNoContent, do(t, s, "DELETE", "/rest/session", token, nil, nil))
+	assert.Equal(t, http.StatusUnauthorized, do(t, s, "GET", "/rest/session", token, nil, nil))
+}
+
+func TestCollections(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	token := newSession(t, s)
+
+	assert.NoError(t, s.Seed("/rest/zones",
+		map[string]interface{}{"id": "z1", "name": "prod", "regionUri": "/rest/regions/r1"},
+		map[string]interface{}{"id": "z2", "name": "test", "regionUri": "/rest/regions/r2"},
+		map[string]interface{}{"id": "z3", "name": "prod-2", "regionUri": "/rest/regions/r1"},
+	))
+
+	var page struct {
+		Total       int                      `json:"total"`
+		Count       int                      `json:"count"`
+		NextPageURI string                   `json:"nextPageUri"`
+		Members     []map[string]interface{} `json:"members"`
+	}
+	assert.Equal(t, http.StatusOK, do(t, s, "GET", "/rest/zones?regionUri=/rest/regions/r1&count=1", token, nil, &page))
+	assert.Equal(t, 2, page.Total)
+	assert.Equal(t, 1, page.Count)
+	assert.Equal(t, "z1", page.Members[0]["id"])
+	assert.Contains(t, page.NextPageURI, "start=1")
+
+	page.NextPageURI = ""
+	assert.Equal(t, http.StatusOK, do(t, s, "GET", "/rest/zones?query=name+EQ+test", token, nil, &page))
+	assert.Equal(t, 1, page.Total)
+	assert.Empty(t, page.NextPageURI)
+
+	assert.Equal(t, http.StatusOK, do(t, s, "GET", "/rest/zones?userQuery='PROD'&sort=name:desc", token, nil, &page))
+	assert.Equal(t, "prod-2", page.Members[0]["name"])
+
+	var zone map[string]interface{}
+	assert.Equal(t, http.StatusCreated, do(t, s, "POST", "/rest/zones", token, map[string]interface{}{"name": "new"}, &zone))
+	uri := zone["uri"].(string)
+
+	ops := []map[string]interface{}{{"op": "replace", "path": "/name", "value": "renamed"}}
+	assert.Equal(t, http.StatusOK, do(t, s, "PATCH", uri, token, ops, &zone))
+	assert.Equal(t, "renamed", zone["name"])
+
+	var apiErr apiError
+	ops = []map[string]interface{}{{"op": "remove", "path": "/missing"}}
+	assert.Equal(t, http.StatusBadRequest, do(t, s, "PATCH", uri, token, ops, &apiErr))
+	assert.Equal(t, "BAD_REQUEST", apiErr.ErrorCode)
+
+	assert.Equal(t, http.StatusNoContent, do(t, s, "DELETE", uri, token, nil, nil))
+	assert.Equal(t, http.StatusNotFound, do(t, s, "GET", uri, token, nil, &apiErr))
+	assert.Equal(t, "NOT_FOUND", apiErr.ErrorCode)
+	assert.Equal(t, uri, apiErr.ErrorSource)
+
+	assert.NoError(t, s.Seed("/rest/projects", map[string]interface{}{"id": "p1", "protected": true}))
+	assert.Equal(t, http.StatusConflict, do(t, s, "DELETE", "/rest/projects/p1", token, nil, &apiErr))
+	assert.Equal(t, "RESOURCE_PROTECTED", apiErr.ErrorCode)
+
+	assert.Equal(t, http.StatusNotFound, do(t, s, "GET", "/rest/catalogs", token, nil, nil))
+	assert.Equal(t, http.StatusBadRequest, do(t, s, "GET", "/rest/zones?query=name+LIKE", token, nil, nil))
+}
+
+func TestSubresources(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	token := newSession(t, s)
+
+	assert.NoError(t, s.Seed("/rest/zones", map[string]interface{}{"id": "z1"}))
+	assert.Equal(t, http.StatusNotFound, do(t, s, "POST", "/rest/zones/z9/connections", token, map[string]interface{}{}, nil))
+	assert.Equal(t, http.StatusCreated, do(t, s, "POST", "/rest/zones/z1/connections", token, map[string]interface{}{"name": "c"}, nil))
+	assert.Equal(t, http.StatusAccepted, do(t, s, "POST", "/rest/zones/z1/actions", token, map[string]interface{}{"type": "reset"}, nil))
+
+	// deleting a zone removes its connections
+	assert.Equal(t, http.StatusNoContent, do(t, s, "DELETE", "/rest/zones/z1", token, nil, nil))
+	assert.Equal(t, http.StatusNotFound, do(t, s, "GET", "/rest/zones/z1/connections", token, nil, nil))
+	assert.Equal(t, http.StatusNotFound, do(t, s, "POST", "/rest/zones/z1/actions", token, map[string]interface{}{}, nil))
+}
+
+func TestSeedAtGetAndReset(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	token := newSession(t, s)
+
+	assert.NoError(t, s.SeedAt("/rest/status", map[string]string{"service": "DEGRADED"}))
+	var status map[string]string
+	assert.Equal(t, http.StatusOK, do(t, s, "GET", "/rest/status", "", nil, &status))
+	assert.Equal(t, "DEGRADED", status["service"])
+
+	assert.NoError(t, s.Seed("/rest/catalogs", map[string]interface{}{"id": "c1", "name": "docker"}))
+	var catalog map[string]interface{}
+	ok, err := s.Get("/rest/catalogs/c1", &catalog)
+	assert.True(t, ok)
+	assert.NoError(t, err)
+	assert.Equal(t, "docker", catalog["name"])
+
+	s.Reset()
+	ok, _ = s.Get("/rest/catalogs/c1", &catalog)
+	assert.False(t, ok)
+	assert.Equal(t, http.StatusNotFound, do(t, s, "GET", "/rest/catalogs", token, nil, nil))
+	assert.Equal(t, http.StatusOK, do(t, s, "GET", "/rest/status", "", nil, &status))
+	assert.Equal(t, "OK", status["service"])
+	assert.Equal(t, http.StatusOK, do(t, s, "GET", "/rest/session?view=full", token, nil, nil))
+}
@@ -0,0 +1,310 @@
+// (C) Copyright 2018 Hewlett Packard Enterprise Development LP.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.  IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package onesphere
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+)
+
+// Progress is the state of an Operation as of its last poll
+type Progress struct {
+	// State is the state of the resource
+	State string
+	// TaskStatus is the status reported for the tasks of a zone
+	TaskStatus string
+	// PercentComplete is 100 once the operation is done
+	PercentComplete int
+	// Message is the latest progress update
+	Message string
+	// Tasks are the names of the tasks still running
+	Tasks []string
+}
+
+// Operation tracks an asynchronous change of a zone or deployment started by
+// one of the Async calls. Poll refreshes it, Wait polls until it is done.
+//
+//	op, err := client.ActionZoneAsync(ctx, id, action)
+//	for !op.Done() {
+//		if err := op.Poll(ctx); err != nil { ... }
+//		bar.Set(op.Progress().PercentComplete)
+//		time.Sleep(time.Second)
+//	}
+type Operation struct {
+	// Resource and ID identify the changed resource, e.g. "zone" and its id
+	Resource string
+	ID       string
+
+	client *Client
+	poll   func(ctx context.Context) (Progress, bool, error)
+
+	mu       sync.Mutex
+	progress Progress
+	done     bool
+	err      error
+	// before is the state of the resource when an action was sent, the
+	// action has started once a poll finds another state or work in progress,
+	// or after startPolls polls for an action leaving the state unchanged
+	before  string
+	started bool
+	polls   int
+}
+
+// startPolls is the number of polls after which an action that never showed
+// on the resource is taken as started, e.g. a restart going Running to Running
+const startPolls = 3
+
+func newOperation(c *Client, resource, id string, poll func(ctx context.Context) (Progress, bool, error)) *Operation {
+	return &Operation{Resource: resource, ID: id, client: c, poll: poll, started: true}
+}
+
+// after makes op wait for the resource to leave state, or report a transitional
+// state, before it can be done: right after an action the resource usually
+// still shows the stable state it had before. An action finishing without
+// either is done after startPolls polls.
+func (op *Operation) after(state string) *Operation {
+	op.before, op.started = state, false
+	return op
+}
+
+// Poll reads the resource once and updates the progress of op.
+// It returns an OperationFailedError once the resource reports a failure;
+// after that, or once op is done, it returns without any request.
+func (op *Operation) Poll(ctx context.Context) error {
+	op.mu.Lock()
+	done, err := op.done, op.err
+	op.mu.Unlock()
+	if done {
+		return err
+	}
+
+	progress, done, err := op.poll(ctx)
+	if err != nil && !IsOperationFailed(err) {
+		return err
+	}
+
+	op.mu.Lock()
+	defer op.mu.Unlock()
+	op.polls++
+	if !op.started {
+		op.started = !done || !strings.EqualFold(progress.State, op.before) || op.polls >= startPolls
+	}
+	if !op.started && err == nil {
+		done, progress.PercentComplete = false, 0
+	}
+	op.progress = progress
+	op.done = done || err != nil
+	op.err = err
+	return err
+}
+
+// Wait polls op according to the WaitPolicy of the Client until it is done
+func (op *Operation) Wait(ctx context.Context) error {
+	return op.client.wait(ctx, func(ctx context.Context) (bool, error) {
+		if err := op.Poll(ctx); err != nil {
+			return false, err
+		}
+		return op.Done(), nil
+	})
+}
+
+// Done reports whether the last poll found op finished or failed
+func (op *Operation) Done() bool {
+	op.mu.Lock()
+	defer op.mu.Unlock()
+	return op.done
+}
+
+// Progress returns the progress found by the last poll
+func (op *Operation) Progress() Progress {
+	op.mu.Lock()
+	defer op.mu.Unlock()
+	return op.progress
+}
+
+// transitionalStates are the states, compared ignoring case, of a resource being changed
+var transitionalStates = []string{
+	"Pending", "Creating", "Updating", "Deleting", "Provisioning", "Connecting", "Enabling", "Disabling",
+	"Starting", "Stopping", "Restarting", "Suspending", "Resuming", "Refreshing",
+}
+
+// finishedTaskStates are the states, compared ignoring case, of a task that no longer runs
+var finishedTaskStates = []string{"Completed", "Complete", "Succeeded", "Success", "Failed", "Error", "Cancelled", "Canceled"}
+
+func stateIn(state string, states []string) bool {
+	for _, s := range states {
+		if strings.EqualFold(state, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// zoneOperation tracks the tasks of the zone id
+func (c *Client) zoneOperation(id string) *Operation {
+	return newOperation(c, "zone", id, func(ctx context.Context) (Progress, bool, error) {
+		zone, err := c.GetZoneByIDCtx(ctx, id)
+		if err != nil {
+			return Progress{}, false, err
+		}
+		// the task status is informative, zones without tasks may not report it
+		taskStatus, err := c.GetZoneTaskStatusCtx(ctx, id)
+		if err != nil && !IsNotFound(err) {
+			return Progress{}, false, err
+		}
+		return zoneProgress(zone, taskStatus)
+	})
+}
+
+// zoneProgress reports the progress of the tasks of zone and whether they are done
+func zoneProgress(zone Zone, taskStatus string) (Progress, bool, error) {
+	task := zone.EsxLcmTask
+	p := Progress{
+		State:           zone.State,
+		TaskStatus:      unquote(taskStatus),
+		PercentComplete: task.PercentComplete,
+	}
+	if n := len(task.ProgressUpdates); n > 0 {
+		p.Message = task.ProgressUpdates[n-1].StatusUpdate
+	}
+	for _, t := range zone.CurrentTasks {
+		if !stateIn(t.TaskState, finishedTaskStates) {
+			p.Tasks = append(p.Tasks, t.TaskName)
+		}
+	}
+
+	zoneErr := zone.Error
+	if zoneErr != nil && zoneErr.Message == "" && zoneErr.ErrorCode == "" {
+		zoneErr = nil
+	}
+	if zoneErr == nil && task.TaskFailed {
+		zoneErr = task.Error
+		if zoneErr == nil {
+			zoneErr = &Error{Message: p.Message}
+		}
+	}
+	if zoneErr != nil || isFailedState(zone.State) {
+		return p, true, &OperationFailedError{Resource: "zone", ID: zone.ID, State: zone.State, Err: zoneErr}
+	}
+
+	taskRunning := task.State != "" && !stateIn(task.State, finishedTaskStates)
+	done := len(p.Tasks) == 0 && !stateIn(zone.State, transitionalStates) && !taskRunning
+	if done {
+		p.PercentComplete = 100
+	}
+	return p, done, nil
+}
+
+// deploymentOperation tracks the state of the deployment id
+func (c *Client) deploymentOperation(id string) *Operation {
+	return newOperation(c, "deployment", id, func(ctx context.Context) (Progress, bool, error) {
+		deployment, err := c.GetDeploymentByIDCtx(ctx, id)
+		if err != nil {
+			return Progress{}, false, err
+		}
+		p := Progress{State: deployment.State}
+		if isFailedState(deployment.State) {
+			return p, true, &OperationFailedError{Resource: "deployment", ID: id, State: deployment.State}
+		}
+		done := !stateIn(deployment.State, transitionalStates)
+		if done {
+			p.PercentComplete = 100
+		}
+		return p, done, nil
+	})
+}
+
+// deletion is done once get fails with a not found error
+func (c *Client) deletion(resource, id string, get func(ctx context.Context) (string, error)) *Operation {
+	return newOperation(c, resource, id, func(ctx context.Context) (Progress, bool, error) {
+		state, err := get(ctx)
+		if IsNotFound(err) {
+			return Progress{PercentComplete: 100}, true, nil
+		}
+		if err != nil {
+			return Progress{}, false, err
+		}
+		if isFailedState(state) {
+			return Progress{State: state}, true, &OperationFailedError{Resource: resource, ID: id, State: state}
+		}
+		return Progress{State: state}, false, nil
+	})
+}
+
+// unquote returns the string of a JSON string body, other bodies as they are
+func unquote(body string) string {
+	var s string
+	if err := json.Unmarshal([]byte(body), &s); err == nil {
+		return s
+	}
+	return strings.TrimSpace(body)
+}
+
+// CreateZoneAsync is like CreateZoneCtx and also returns an Operation tracking the creation
+func (c *Client) CreateZoneAsync(ctx context.Context, zoneRequest ZoneRequest) (Zone, *Operation, error) {
+	zone, err := c.CreateZoneCtx(ctx, zoneRequest)
+	if err != nil {
+		return zone, nil, err
+	}
+	return zone, c.zoneOperation(zone.ID), nil
+}
+
+// ActionZoneAsync is like ActionZoneCtx and returns an Operation tracking the action.
+// The zone is read first, the Operation is not done before the zone leaves the
+// state it had or reports running tasks.
+func (c *Client) ActionZoneAsync(ctx context.Context, zoneId string, action ZoneAction) (*Operation, error) {
+	zone, err := c.GetZoneByIDCtx(ctx, zoneId)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.ActionZoneCtx(ctx, zoneId, action); err != nil {
+		return nil, err
+	}
+	return c.zoneOperation(zoneId).after(zone.State), nil
+}
+
+// DeleteZoneAsync is like DeleteZoneCtx and returns an Operation done once the zone is gone
+func (c *Client) DeleteZoneAsync(ctx context.Context, zoneId string) (*Operation, error) {
+	if err := c.DeleteZoneCtx(ctx, zoneId); err != nil {
+		return nil, err
+	}
+	return c.deletion("zone", zoneId, func(ctx context.Context) (string, error) {
+		zone, err := c.GetZoneByIDCtx(ctx, zoneId)
+		return zone.State, err
+	}), nil
+}
+
+// ActionDeploymentAsync is like ActionDeploymentCtx and returns an Operation
+// done once the deployment leaves the transitional states such as "Restarting".
+// The deployment is read first, the Operation is not done before the
+// deployment leaves the state it had or reports a transitional state.
+func (c *Client) ActionDeploymentAsync(ctx context.Context, deployment Deployment, actionType string, force bool) (*Operation, error) {
+	current, err := c.GetDeploymentByIDCtx(ctx, deployment.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.ActionDeploymentCtx(ctx, deployment, actionType, force); err != nil {
+		return nil, err
+	}
+	return c.deploymentOperation(deployment.ID).after(current.State), nil
+}
@@ -0,0 +1,162 @@
+package onesphere
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// zoneWithTask is a zone document running a task at percent
+func zoneWithTask(taskState string, percent int, failed bool) map[string]interface{} {
+	return map[string]interface{}{
+		"id":           "z1",
+		"state":        "Enabled",
+		"currentTasks": []map[string]interface{}{{"taskName": "add-capacity", "taskState": taskState}},
+		"esxLcmTask": map[string]interface{}{
+			"percentComplete": percent,
+			"taskFailed":      failed,
+			"ProgressUpdates": []map[string]interface{}{{"StatusUpdate": "Deploying hosts"}},
+		},
+	}
+}
+
+func TestActionZoneAsync(t *testing.T) {
+	server, c := newWaitClient(t, time.Second)
+	server.Seed("/rest/zones", zoneWithTask("Running", 40, false))
+	server.SeedAt("/rest/zones/z1/task-status", "InProgress")
+
+	op, err := c.ActionZoneAsync(context.Background(), "z1", ZoneAction{Type: "add-capacity"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := op.Poll(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	p := op.Progress()
+	if op.Done() || p.PercentComplete != 40 || p.Message != "Deploying hosts" || p.TaskStatus != "InProgress" || len(p.Tasks) != 1 {
+		t.Errorf("TestActionZoneAsync unexpected progress %+v, done %v", p, op.Done())
+	}
+
+	server.Seed("/rest/zones", zoneWithTask("Completed", 90, false))
+	if err := op.Wait(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if !op.Done() || op.Progress().PercentComplete != 100 {
+		t.Errorf("TestActionZoneAsync expected a finished operation, got %+v", op.Progress())
+	}
+}
+
+func TestZoneOperationFailure(t *testing.T) {
+	server, c := newWaitClient(t, time.Second)
+	server.Seed("/rest/zones", zoneWithTask("Failed", 60, true))
+
+	op, err := c.ActionZoneAsync(context.Background(), "z1", ZoneAction{Type: "add-capacity"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := op.Wait(context.Background()); !IsOperationFailed(err) {
+		t.Errorf("TestZoneOperationFailure expected an OperationFailedError, got %v", err)
+	}
+	if err := op.Poll(context.Background()); !IsOperationFailed(err) || !op.Done() {
+		t.Errorf("TestZoneOperationFailure Poll should keep the failure, got %v", err)
+	}
+}
+
+func TestDeleteZoneAsync(t *testing.T) {
+	server, c := newWaitClient(t, time.Second)
+	server.Seed("/rest/zones", Zone{ID: "z1", State: "Enabled"})
+
+	op, err := c.DeleteZoneAsync(context.Background(), "z1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := op.Wait(context.Background()); err != nil || !op.Done() {
+		t.Errorf("TestDeleteZoneAsync unexpected result %v, done %v", err, op.Done())
+	}
+}
+
+func TestActionDeploymentAsync(t *testing.T) {
+	server, c := newWaitClient(t, time.Second)
+	server.Seed("/rest/deployments", Deployment{ID: "d1", State: "Restarting"})
+
+	op, err := c.ActionDeploymentAsync(context.Background(), Deployment{ID: "d1"}, "restart", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := op.Poll(context.Background()); err != nil || op.Done() || op.Progress().State != "Restarting" {
+		t.Errorf("TestActionDeploymentAsync unexpected progress %+v, %v", op.Progress(), err)
+	}
+
+	server.Seed("/rest/deployments", Deployment{ID: "d1", State: "Running"})
+	if err := op.Wait(context.Background()); err != nil || op.Progress().State != "Running" {
+		t.Errorf("TestActionDeploymentAsync unexpected result %+v, %v", op.Progress(), err)
+	}
+}
+
+func TestActionDeploymentAsyncWaitsForTheActionToStart(t *testing.T) {
+	server, c := newWaitClient(t, time.Second)
+	server.Seed("/rest/deployments", Deployment{ID: "d1", State: "Running"})
+
+	op, err := c.ActionDeploymentAsync(context.Background(), Deployment{ID: "d1"}, "stop", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := op.Poll(context.Background()); err != nil || op.Done() {
+		t.Errorf("TestActionDeploymentAsyncWaitsForTheActionToStart should not be done in the state before the action, got %+v, %v", op.Progress(), err)
+	}
+
+	server.Seed("/rest/deployments", Deployment{ID: "d1", State: "Stopped"})
+	if err := op.Wait(context.Background()); err != nil || op.Progress().State != "Stopped" {
+		t.Errorf("TestActionDeploymentAsyncWaitsForTheActionToStart unexpected result %+v, %v", op.Progress(), err)
+	}
+}
+
+func TestActionZoneAsyncWaitsForTheActionToStart(t *testing.T) {
+	server, c := newWaitClient(t, time.Second)
+	server.Seed("/rest/zones", Zone{ID: "z1", State: "Enabled"})
+
+	op, err := c.ActionZoneAsync(context.Background(), "z1", ZoneAction{Type: "add-capacity"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := op.Poll(context.Background()); err != nil || op.Done() || op.Progress().PercentComplete != 0 {
+		t.Errorf("TestActionZoneAsyncWaitsForTheActionToStart should not be done before the tasks start, got %+v, %v", op.Progress(), err)
+	}
+
+	server.Seed("/rest/zones", zoneWithTask("Running", 40, false))
+	if err := op.Poll(context.Background()); err != nil || op.Done() {
+		t.Errorf("TestActionZoneAsyncWaitsForTheActionToStart unexpected progress %+v, %v", op.Progress(), err)
+	}
+
+	server.Seed("/rest/zones", zoneWithTask("Completed", 90, false))
+	if err := op.Wait(context.Background()); err != nil || op.Progress().PercentComplete != 100 {
+		t.Errorf("TestActionZoneAsyncWaitsForTheActionToStart unexpected result %+v, %v", op.Progress(), err)
+	}
+}
+
+func TestActionDeploymentAsyncUnchangedState(t *testing.T) {
+	server, c := newWaitClient(t, time.Second)
+	server.Seed("/rest/deployments", Deployment{ID: "d1", State: "Running"})
+
+	// a restart finished before the first poll leaves the deployment Running
+	op, err := c.ActionDeploymentAsync(context.Background(), Deployment{ID: "d1"}, "restart", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := op.Wait(context.Background()); err != nil || !op.Done() || op.Progress().PercentComplete != 100 {
+		t.Errorf("TestActionDeploymentAsyncUnchangedState expected a finished operation, got %+v, %v", op.Progress(), err)
+	}
+}
+
+func TestZoneProgressRunningLcmTask(t *testing.T) {
+	zone := Zone{ID: "z1", State: "Enabled"}
+	zone.EsxLcmTask.State = "Running"
+	if _, done, err := zoneProgress(zone, ""); done || err != nil {
+		t.Errorf("TestZoneProgressRunningLcmTask a running LCM task should not be done, got %v, %v", done, err)
+	}
+
+	zone.EsxLcmTask.State = "Completed"
+	if p, done, err := zoneProgress(zone, ""); !done || err != nil || p.PercentComplete != 100 {
+		t.Errorf("TestZoneProgressRunningLcmTask a completed LCM task should be done, got %+v, %v, %v", p, done, err)
+	}
+}
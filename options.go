@@ -0,0 +1,307 @@
+// (C) Copyright 2018 Hewlett Packard Enterprise Development LP.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.  IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package onesphere
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/HewlettPackard/hpe-onesphere-go/log"
+	"github.com/HewlettPackard/hpe-onesphere-go/rest"
+)
+
+const (
+	defaultUserAgent = "hpe-onesphere-go"
+
+	// maxIdleConnsPerHost allows concurrent callers to keep their connections
+	// to the single OneSphere host instead of the net/http default of 2
+	maxIdleConnsPerHost = 32
+)
+
+// Option configures a Client created by Connect or NewClient
+type Option func(*clientOptions) error
+
+type clientOptions struct {
+	httpClient   *http.Client
+	rootCAs      *x509.CertPool
+	certificates []tls.Certificate
+	proxyURL     *url.URL
+	retryPolicy  *RetryPolicy
+	waitPolicy   *WaitPolicy
+	tokenCache   TokenCache
+	timeout      time.Duration
+	userAgent    string
+	wrappers     []func(http.RoundTripper) http.RoundTripper
+	headers      map[string]string
+	query        map[string]interface{}
+	logger       log.Logger
+}
+
+// WithHTTPClient makes the Client send every request through httpClient.
+// It cannot be combined with the TLS and proxy options, which configure
+// the http.Client built by this package.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(o *clientOptions) error {
+		if httpClient == nil {
+			return fmt.Errorf("httpClient must not be nil")
+		}
+		o.httpClient = httpClient
+		return nil
+	}
+}
+
+// WithTransport wraps the transport sending the requests, for example to
+// record them. Wrappers added first are closest to the network.
+func WithTransport(wrap func(http.RoundTripper) http.RoundTripper) Option {
+	return func(o *clientOptions) error {
+		if wrap == nil {
+			return fmt.Errorf("wrap must not be nil")
+		}
+		o.wrappers = append(o.wrappers, wrap)
+		return nil
+	}
+}
+
+// WithCACertificates trusts the PEM encoded certificates in addition to the system roots
+func WithCACertificates(pemCerts []byte) Option {
+	return func(o *clientOptions) error {
+		if o.rootCAs == nil {
+			pool, err := x509.SystemCertPool()
+			if err != nil || pool == nil {
+				pool = x509.NewCertPool()
+			}
+			o.rootCAs = pool
+		}
+		if !o.rootCAs.AppendCertsFromPEM(pemCerts) {
+			return fmt.Errorf("no PEM encoded certificates found in CA bundle")
+		}
+		return nil
+	}
+}
+
+// WithCAFile trusts the PEM encoded certificates read from caFile
+func WithCAFile(caFile string) Option {
+	return func(o *clientOptions) error {
+		pemCerts, err := ioutil.ReadFile(caFile)
+		if err != nil {
+			return err
+		}
+		return WithCACertificates(pemCerts)(o)
+	}
+}
+
+// WithClientCertificate presents cert to the server for mutual TLS
+func WithClientCertificate(cert tls.Certificate) Option {
+	return func(o *clientOptions) error {
+		o.certificates = append(o.certificates, cert)
+		return nil
+	}
+}
+
+// WithClientCertificateFile loads a PEM encoded certificate and key pair for mutual TLS
+func WithClientCertificateFile(certFile, keyFile string) Option {
+	return func(o *clientOptions) error {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return err
+		}
+		return WithClientCertificate(cert)(o)
+	}
+}
+
+// WithTimeout bounds each request, including reading the response body.
+// A zero timeout means no limit other than the context of the call.
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *clientOptions) error {
+		if timeout < 0 {
+			return fmt.Errorf("timeout must not be negative")
+		}
+		o.timeout = timeout
+		return nil
+	}
+}
+
+// WithProxy sends requests through proxyURL instead of the proxy from the environment
+func WithProxy(proxyURL string) Option {
+	return func(o *clientOptions) error {
+		u, err := url.Parse(proxyURL)
+		if err != nil {
+			return err
+		}
+		o.proxyURL = u
+		return nil
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request
+func WithUserAgent(userAgent string) Option {
+	return func(o *clientOptions) error {
+		o.userAgent = userAgent
+		return nil
+	}
+}
+
+// WithHeader sends the header with every request. The headers set by the
+// calls themselves, such as Content-Type and Authorization, take precedence.
+func WithHeader(key, value string) Option {
+	return func(o *clientOptions) error {
+		if key == "" {
+			return fmt.Errorf("header key must not be empty")
+		}
+		if o.headers == nil {
+			o.headers = map[string]string{}
+		}
+		o.headers[key] = value
+		return nil
+	}
+}
+
+// WithQueryParam adds the query parameter to every request.
+// The parameters of the calls replace those with the same key.
+func WithQueryParam(key, value string) Option {
+	return func(o *clientOptions) error {
+		if key == "" {
+			return fmt.Errorf("query parameter key must not be empty")
+		}
+		if o.query == nil {
+			o.query = map[string]interface{}{}
+		}
+		o.query[key] = value
+		return nil
+	}
+}
+
+// WithLogger sends the diagnostics of the Client to logger, the requests and
+// responses are logged at debug level. Nothing is logged by default.
+func WithLogger(logger log.Logger) Option {
+	return func(o *clientOptions) error {
+		if logger == nil {
+			return fmt.Errorf("logger must not be nil")
+		}
+		o.logger = logger
+		return nil
+	}
+}
+
+// NewClient returns an unauthenticated Client for hostURL configured by opts.
+// Server certificates are always verified against the system roots and any
+// CA added with WithCACertificates or WithCAFile.
+func NewClient(hostURL string, opts ...Option) (*Client, error) {
+	o := &clientOptions{userAgent: defaultUserAgent, logger: log.NopLogger{}}
+	for _, opt := range opts {
+		if err := opt(o); err != nil {
+			return nil, err
+		}
+	}
+
+	httpClient, err := o.buildHTTPClient()
+	if err != nil {
+		return nil, err
+	}
+
+	transport := &rest.Client{
+		Endpoint:   hostURL,
+		Option:     rest.Options{Headers: o.headers, Query: o.query},
+		HTTPClient: httpClient,
+	}
+	transport.SetLogger(o.logger)
+
+	return &Client{
+		Auth: &Auth{
+			HostURL: hostURL,
+		},
+		httpClient:    httpClient,
+		ownsTransport: o.httpClient == nil,
+		transport:     transport,
+		logger:        o.logger,
+		retryPolicy:   o.retryPolicy,
+		waitPolicy:    o.waitPolicy,
+		tokenCache:    o.tokenCache,
+		timeout:       o.timeout,
+		userAgent:     o.userAgent,
+	}, nil
+}
+
+func (o *clientOptions) buildHTTPClient() (*http.Client, error) {
+	if o.httpClient != nil {
+		if o.rootCAs != nil || len(o.certificates) > 0 || o.proxyURL != nil {
+			return nil, fmt.Errorf("WithHTTPClient cannot be combined with TLS or proxy options")
+		}
+		if len(o.wrappers) == 0 {
+			return o.httpClient, nil
+		}
+		// wrap a copy, the supplied http.Client may be shared
+		httpClient := *o.httpClient
+		transport := httpClient.Transport
+		if transport == nil {
+			transport = http.DefaultTransport
+		}
+		httpClient.Transport = o.wrap(transport)
+		return &httpClient, nil
+	}
+
+	transport := newTransport()
+	transport.TLSClientConfig = &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		RootCAs:      o.rootCAs,
+		Certificates: o.certificates,
+	}
+	if o.proxyURL != nil {
+		transport.Proxy = http.ProxyURL(o.proxyURL)
+	}
+
+	return &http.Client{Transport: o.wrap(transport)}, nil
+}
+
+// wrap applies the WithTransport wrappers to transport
+func (o *clientOptions) wrap(transport http.RoundTripper) http.RoundTripper {
+	for _, wrap := range o.wrappers {
+		transport = wrap(transport)
+	}
+	return transport
+}
+
+// newTransport returns the transport shared by all calls of a Client.
+// Idle connections are kept per host so that sweeps over many resources
+// reuse the same TLS sessions instead of handshaking on every call.
+func newTransport() *http.Transport {
+	return &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          100,
+		MaxIdleConnsPerHost:   maxIdleConnsPerHost,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+		// leaving compression enabled requests gzip and decodes it transparently
+		DisableCompression: false,
+	}
+}
@@ -0,0 +1,217 @@
+package onesphere
+
+import (
+	"context"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/HewlettPackard/hpe-onesphere-go/log"
+	"github.com/HewlettPackard/hpe-onesphere-go/onespheretest"
+)
+
+func newSessionServer(tlsServer bool, handler http.HandlerFunc) *httptest.Server {
+	mux := newSessionMux(handler)
+	if tlsServer {
+		return httptest.NewTLSServer(mux)
+	}
+	return httptest.NewServer(mux)
+}
+
+// newSessionMux serves the session endpoint and sends the other requests to handler
+func newSessionMux(handler http.HandlerFunc) *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/session", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"token":"test-token","userUri":"/rest/users/1"}`))
+	})
+	if handler != nil {
+		mux.HandleFunc("/", handler)
+	}
+	return mux
+}
+
+func TestConnectVerifiesCertificates(t *testing.T) {
+	ts := newSessionServer(true, nil)
+	defer ts.Close()
+
+	if _, err := Connect(ts.URL, "user", "password"); err == nil {
+		t.Errorf("TestConnectVerifiesCertificates Connect should fail for an untrusted certificate")
+	}
+
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ts.Certificate().Raw})
+	c, err := Connect(ts.URL, "user", "password", WithCACertificates(caPEM))
+	if err != nil {
+		t.Fatalf("TestConnectVerifiesCertificates Connect with the server CA failed: %v", err)
+	}
+	if c.Auth.Token != "test-token" {
+		t.Errorf("TestConnectVerifiesCertificates unexpected token %q", c.Auth.Token)
+	}
+}
+
+func TestWithHTTPClient(t *testing.T) {
+	ts := newSessionServer(true, nil)
+	defer ts.Close()
+
+	if _, err := Connect(ts.URL, "user", "password", WithHTTPClient(ts.Client())); err != nil {
+		t.Errorf("TestWithHTTPClient Connect failed: %v", err)
+	}
+
+	if _, err := NewClient(ts.URL, WithHTTPClient(ts.Client()), WithProxy("http://proxy:3128")); err == nil {
+		t.Errorf("TestWithHTTPClient WithHTTPClient should not be combined with WithProxy")
+	}
+}
+
+func TestWithUserAgentAndTimeout(t *testing.T) {
+	var userAgent string
+	ts := newSessionServer(false, func(w http.ResponseWriter, r *http.Request) {
+		userAgent = r.Header.Get("User-Agent")
+		if r.URL.Path == "/rest/zones/slow" {
+			time.Sleep(200 * time.Millisecond)
+		}
+		w.Write([]byte(`{"id":"fast"}`))
+	})
+	defer ts.Close()
+
+	c, err := Connect(ts.URL, "user", "password", WithUserAgent("inventory-sweep/1.0"), WithTimeout(50*time.Millisecond))
+	if err != nil {
+		t.Fatalf("TestWithUserAgentAndTimeout Connect failed: %v", err)
+	}
+
+	if _, err := c.GetZoneByID("fast"); err != nil {
+		t.Errorf("TestWithUserAgentAndTimeout GetZoneByID failed: %v", err)
+	}
+	if userAgent != "inventory-sweep/1.0" {
+		t.Errorf("TestWithUserAgentAndTimeout unexpected User-Agent %q", userAgent)
+	}
+
+	if _, err := c.GetZoneByID("slow"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("TestWithUserAgentAndTimeout expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestClientReusesConnections(t *testing.T) {
+	var newConns int32
+	ts := httptest.NewUnstartedServer(newSessionMux(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id":"1"}`))
+	}))
+	ts.Config.ConnState = func(conn net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			atomic.AddInt32(&newConns, 1)
+		}
+	}
+	ts.StartTLS()
+	defer ts.Close()
+
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ts.Certificate().Raw})
+	c, err := Connect(ts.URL, "user", "password", WithCACertificates(caPEM))
+	if err != nil {
+		t.Fatalf("TestClientReusesConnections Connect failed: %v", err)
+	}
+	defer c.Close()
+
+	for i := 0; i < 20; i++ {
+		if _, err := c.GetZoneByID("1"); err != nil {
+			t.Fatalf("TestClientReusesConnections GetZoneByID failed: %v", err)
+		}
+	}
+
+	if n := atomic.LoadInt32(&newConns); n != 1 {
+		t.Errorf("TestClientReusesConnections expected 1 connection for 21 sequential calls, got %d", n)
+	}
+}
+
+func TestWithTransportRecordAndReplay(t *testing.T) {
+	server := onespheretest.NewServer()
+	server.Seed("/rest/zones", Zone{ID: "z1", Name: "prod"})
+	cassette := filepath.Join(t.TempDir(), "zones.json")
+
+	rec, err := onespheretest.NewRecorder(cassette, onespheretest.ModeRecord)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := Connect(server.URL, onespheretest.DefaultUser, onespheretest.DefaultPassword, WithTransport(rec.Wrap))
+	if err != nil {
+		t.Fatalf("TestWithTransportRecordAndReplay Connect failed: %v", err)
+	}
+	if zone, err := c.GetZoneByID("z1"); err != nil || zone.Name != "prod" {
+		t.Fatalf("TestWithTransportRecordAndReplay GetZoneByID recorded %+v, %v", zone, err)
+	}
+	server.Close()
+
+	rec, err = onespheretest.NewRecorder(cassette, onespheretest.ModeReplay)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err = Connect(server.URL, onespheretest.DefaultUser, "another password", WithTransport(rec.Wrap))
+	if err != nil {
+		t.Fatalf("TestWithTransportRecordAndReplay Connect failed on replay: %v", err)
+	}
+	if zone, err := c.GetZoneByID("z1"); err != nil || zone.Name != "prod" {
+		t.Errorf("TestWithTransportRecordAndReplay GetZoneByID replayed %+v, %v", zone, err)
+	}
+	if _, err := c.GetZoneByID("z1"); err == nil {
+		t.Errorf("TestWithTransportRecordAndReplay expected an error once the cassette is used up")
+	}
+}
+
+// debugRecorder is a log.Logger keeping the Debugf lines, its other methods are not used
+type debugRecorder struct {
+	log.Logger
+	lines []string
+}
+
+func (l *debugRecorder) Debugf(fmtString string, args ...interface{}) {
+	l.lines = append(l.lines, fmt.Sprintf(fmtString, args...))
+}
+
+func TestWithHeaderQueryParamAndLogger(t *testing.T) {
+	var header http.Header
+	var query string
+	ts := newSessionServer(false, func(w http.ResponseWriter, r *http.Request) {
+		header, query = r.Header, r.URL.RawQuery
+		w.Write([]byte(`{"id":"z1"}`))
+	})
+	defer ts.Close()
+
+	logger := &debugRecorder{}
+	c, err := Connect(ts.URL, "user", "password",
+		WithHeader("X-Tenant", "lab"),
+		WithHeader("Content-Type", "text/plain"),
+		WithQueryParam("trace", "on"),
+		WithQueryParam("view", "default"),
+		WithLogger(logger))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.GetZoneByID("z1"); err != nil {
+		t.Fatal(err)
+	}
+	if header.Get("X-Tenant") != "lab" || header.Get("Content-Type") != "application/json" || header.Get("Authorization") != "test-token" {
+		t.Errorf("TestWithHeaderQueryParamAndLogger unexpected headers %v", header)
+	}
+	if query != "trace=on&view=default" {
+		t.Errorf("TestWithHeaderQueryParamAndLogger unexpected query %q", query)
+	}
+
+	if len(logger.lines) == 0 || !strings.Contains(strings.Join(logger.lines, "\n"), "GET "+ts.URL+"/rest/zones/z1") {
+		t.Errorf("TestWithHeaderQueryParamAndLogger expected the requests to be logged, got %q", logger.lines)
+	}
+	for _, line := range logger.lines {
+		if strings.Contains(line, "test-token") {
+			t.Errorf("TestWithHeaderQueryParamAndLogger the session token must not be logged: %q", line)
+		}
+	}
+
+	if _, err := NewClient(ts.URL, WithHeader("", "value")); err == nil {
+		t.Errorf("TestWithHeaderQueryParamAndLogger WithHeader should reject an empty key")
+	}
+}
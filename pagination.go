@@ -0,0 +1,108 @@
+// (C) Copyright 2018 Hewlett Packard Enterprise Development LP.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.  IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package onesphere
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strconv"
+
+	"github.com/HewlettPackard/hpe-onesphere-go/rest"
+)
+
+// The ForEach methods take the options of their List counterpart followed by
+// the callback, e.g. ForEachZone(ZoneListOptions, func(Zone) error). The
+// start and count of the options set the first offset and the page size.
+
+// StopIteration can be returned by a ForEach callback to stop early without error
+var StopIteration = errors.New("stop iteration")
+
+// pageSize is the number of members requested per page by the ForEach methods
+const pageSize = 100
+
+// page describes a decoded page of a list endpoint
+type page struct {
+	total       int
+	members     int
+	nextPageURI string
+}
+
+// forEachPage requests uri page by page until every member has been seen.
+// It follows nextPageUri when the server sends one and advances start by the
+// number of members received otherwise. The start and count of queryParams
+// set the first offset and the page size. visit decodes a page and calls the
+// ForEach callback for each of its members.
+func (c *Client) forEachPage(ctx context.Context, uri string, queryParams map[string]string, visit func(response string) (page, error)) error {
+	seen, _ := strconv.Atoi(queryParams["start"])
+	count := queryParams["count"]
+	if count == "" {
+		count = strconv.Itoa(pageSize)
+	}
+
+	path := uri
+	for {
+		var params map[string]string
+		if path == uri {
+			params = map[string]string{}
+			for key, value := range queryParams {
+				params[key] = value
+			}
+			params["start"] = strconv.Itoa(seen)
+			params["count"] = count
+		}
+
+		response, err := c.RestAPICallCtx(ctx, rest.GET, path, params, nil)
+		if err != nil {
+			return err
+		}
+
+		p, err := visit(response)
+		if err == StopIteration {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		seen += p.members
+		if p.members == 0 || seen >= p.total {
+			return nil
+		}
+
+		// the next page URI already carries the filters, start and count
+		path = uri
+		if p.nextPageURI != "" {
+			if path, err = relativeURI(p.nextPageURI); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// relativeURI strips the scheme and host from an absolute page URI
+func relativeURI(uri string) (string, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return "", err
+	}
+	return u.RequestURI(), nil
+}
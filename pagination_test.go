@@ -0,0 +1,108 @@
+package onesphere
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/HewlettPackard/hpe-onesphere-go/utils"
+)
+
+// newPagedServer serves total users named "user-0", "user-1", ... honoring
+// start and count, and deployments linked through nextPageUri in pages of 2
+func newPagedServer(t *testing.T, total int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start, _ := strconv.Atoi(r.URL.Query().Get("start"))
+		count, _ := strconv.Atoi(r.URL.Query().Get("count"))
+
+		switch r.URL.Path {
+		case "/rest/users":
+			if r.URL.Query().Get("userQuery") != "user" {
+				t.Errorf("newPagedServer filters should be sent with every page, got %q", r.URL.RawQuery)
+			}
+			count = 3 // the server caps the page size below the requested count
+			users := UserList{Total: total, Start: start}
+			for i := start; i < start+count && i < total; i++ {
+				users.Members = append(users.Members, User{ID: strconv.Itoa(i), Name: "user-" + strconv.Itoa(i)})
+			}
+			users.Count = len(users.Members)
+			json.NewEncoder(w).Encode(users)
+		case "/rest/deployments":
+			deployments := DeploymentList{Total: total, Start: start}
+			for i := start; i < start+2 && i < total; i++ {
+				deployments.Members = append(deployments.Members, Deployment{ID: strconv.Itoa(i)})
+			}
+			if start+2 < total {
+				deployments.NextPageURI = utils.Nstring("/rest/deployments?start=" + strconv.Itoa(start+2) + "&count=2")
+			}
+			json.NewEncoder(w).Encode(deployments)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestForEachUserFollowsStartAndCount(t *testing.T) {
+	ts := newPagedServer(t, 8)
+	defer ts.Close()
+
+	c := &Client{Auth: &Auth{HostURL: ts.URL, Token: "token"}}
+
+	var ids []string
+	err := c.ForEachUser(UserListOptions{UserQuery: "user"}, func(user User) error {
+		ids = append(ids, user.ID)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 8 || ids[0] != "0" || ids[7] != "7" {
+		t.Errorf("TestForEachUserFollowsStartAndCount expected users 0 to 7, got %v", ids)
+	}
+}
+
+func TestForEachDeploymentFollowsNextPageURI(t *testing.T) {
+	ts := newPagedServer(t, 5)
+	defer ts.Close()
+
+	c := &Client{Auth: &Auth{HostURL: ts.URL, Token: "token"}}
+
+	var ids []string
+	err := c.ForEachDeployment(DeploymentListOptions{}, func(deployment Deployment) error {
+		ids = append(ids, deployment.ID)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 5 || ids[4] != "4" {
+		t.Errorf("TestForEachDeploymentFollowsNextPageURI expected deployments 0 to 4, got %v", ids)
+	}
+
+	visited := 0
+	err = c.ForEachDeployment(DeploymentListOptions{}, func(deployment Deployment) error {
+		visited++
+		if visited == 3 {
+			return StopIteration
+		}
+		return nil
+	})
+	if err != nil || visited != 3 {
+		t.Errorf("TestForEachDeploymentFollowsNextPageURI StopIteration should end after 3 deployments without error, got %d and %v", visited, err)
+	}
+}
+
+// the ForEach methods take the options of their List counterpart, changing
+// one of them breaks the callers and must fail here
+var (
+	_ func(DeploymentListOptions, func(Deployment) error) error = (*Client)(nil).ForEachDeployment
+	_ func(EventListOptions, func(Event) error) error           = (*Client)(nil).ForEachEvent
+	_ func(MetricsQuery, func(Metric) error) error              = (*Client)(nil).ForEachMetric
+	_ func(NetworkListOptions, func(Network) error) error       = (*Client)(nil).ForEachNetwork
+	_ func(ProjectListOptions, func(Project) error) error       = (*Client)(nil).ForEachProject
+	_ func(RateListOptions, func(Rate) error) error             = (*Client)(nil).ForEachRate
+	_ func(UserListOptions, func(User) error) error             = (*Client)(nil).ForEachUser
+	_ func(ZoneListOptions, func(Zone) error) error             = (*Client)(nil).ForEachZone
+)
@@ -21,6 +21,7 @@
 package onesphere
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/HewlettPackard/hpe-onesphere-go/rest"
@@ -101,16 +102,31 @@ type ProjectList struct {
 // example userQuery: "zoneUri EQ /rest/zones/xxxx"
 // example view: "full"
 func (c *Client) GetProjects(userQuery, view string) (ProjectList, error) {
+	return c.GetProjectsCtx(context.Background(), userQuery, view)
+}
+
+// GetProjectsCtx is like GetProjects but uses ctx for the request
+func (c *Client) GetProjectsCtx(ctx context.Context, userQuery, view string) (ProjectList, error) {
+	return c.ListProjectsCtx(ctx, ProjectListOptions{
+		UserQuery:   userQuery,
+		ListOptions: ListOptions{View: View(view)},
+	})
+}
+
+// ListProjects returns the ProjectList selected by opts
+func (c *Client) ListProjects(opts ProjectListOptions) (ProjectList, error) {
+	return c.ListProjectsCtx(context.Background(), opts)
+}
+
+// ListProjectsCtx is like ListProjects but uses ctx for the request
+func (c *Client) ListProjectsCtx(ctx context.Context, opts ProjectListOptions) (ProjectList, error) {
 	var (
 		uri         = "/rest/projects"
-		queryParams = createQuery(&map[string]string{
-			"userQuery": userQuery,
-			"view":      view,
-		})
-		projects ProjectList
+		queryParams = encodeParams(opts)
+		projects    ProjectList
 	)
 
-	response, err := c.RestAPICall(rest.GET, uri, queryParams, nil)
+	response, err := c.RestAPICallCtx(ctx, rest.GET, uri, queryParams, nil)
 
 	if err != nil {
 		return projects, err
@@ -123,9 +139,38 @@ func (c *Client) GetProjects(userQuery, view string) (ProjectList, error) {
 	return projects, err
 }
 
+// ForEachProject calls fn for every Project selected by opts across all pages,
+// see ForEachDeployment for paging and how fn stops the iteration
+func (c *Client) ForEachProject(opts ProjectListOptions, fn func(Project) error) error {
+	return c.ForEachProjectCtx(context.Background(), opts, fn)
+}
+
+// ForEachProjectCtx is like ForEachProject but uses ctx for the requests
+func (c *Client) ForEachProjectCtx(ctx context.Context, opts ProjectListOptions, fn func(Project) error) error {
+	return c.forEachPage(ctx, "/rest/projects", encodeParams(opts), func(response string) (page, error) {
+		var projects ProjectList
+		if err := json.Unmarshal([]byte(response), &projects); err != nil {
+			return page{}, apiResponseError(response, err)
+		}
+
+		for _, project := range projects.Members {
+			if err := fn(project); err != nil {
+				return page{}, err
+			}
+		}
+
+		return page{total: projects.Total, members: len(projects.Members)}, nil
+	})
+}
+
 // GetProjectByID returns an Project by id
 // example view: "full"
 func (c *Client) GetProjectByID(id, view string) (Project, error) {
+	return c.GetProjectByIDCtx(context.Background(), id, view)
+}
+
+// GetProjectByIDCtx is like GetProjectByID but uses ctx for the request
+func (c *Client) GetProjectByIDCtx(ctx context.Context, id, view string) (Project, error) {
 	var (
 		uri         = "/rest/projects/" + id
 		queryParams = createQuery(&map[string]string{
@@ -139,7 +184,7 @@ func (c *Client) GetProjectByID(id, view string) (Project, error) {
 		return project, fmt.Errorf("id must not be empty")
 	}
 
-	response, err := c.RestAPICall(rest.GET, uri, queryParams, nil)
+	response, err := c.RestAPICallCtx(ctx, rest.GET, uri, queryParams, nil)
 
 	if err != nil {
 		return project, err
@@ -153,35 +198,52 @@ func (c *Client) GetProjectByID(id, view string) (Project, error) {
 }
 
 // GetProjectByName returns a Project by name
+// returns a NameNotFoundError or an AmbiguousNameError unless exactly one project has the name
 func (c *Client) GetProjectByName(name string) (Project, error) {
-	var project Project
+	return c.GetProjectByNameCtx(context.Background(), name)
+}
+
+// GetProjectByNameCtx is like GetProjectByName but uses ctx for the request
+func (c *Client) GetProjectByNameCtx(ctx context.Context, name string) (Project, error) {
+	var (
+		project Project
+		matches = newNameMatches("project", name)
+	)
 
 	if name == "" {
 		return project, fmt.Errorf("name must not be empty")
 	}
 
-	projects, err := c.GetProjects("", "")
-
-	if len(projects.Members) > 0 {
-		for i := 0; i < len(projects.Members); i++ {
-			if projects.Members[i].Name == name {
-				project = projects.Members[i]
-				return project, err
-			}
+	err := c.ForEachProjectCtx(ctx, ProjectListOptions{UserQuery: name}, func(candidate Project) error {
+		if matches.add(candidate.Name, candidate.URI) {
+			project = candidate
 		}
+		return nil
+	})
+	if err != nil {
+		return Project{}, err
 	}
 
-	return project, err
+	if err := matches.err(); err != nil {
+		return Project{}, err
+	}
+
+	return project, nil
 }
 
 // CreateProject Creates Project and returns updated Project
 func (c *Client) CreateProject(projectRequest ProjectRequest) (Project, error) {
+	return c.CreateProjectCtx(context.Background(), projectRequest)
+}
+
+// CreateProjectCtx is like CreateProject but uses ctx for the request
+func (c *Client) CreateProjectCtx(ctx context.Context, projectRequest ProjectRequest) (Project, error) {
 	var (
 		uri     = "/rest/projects"
 		project Project
 	)
 
-	response, err := c.RestAPICall(rest.POST, uri, nil, projectRequest)
+	response, err := c.RestAPICallCtx(ctx, rest.POST, uri, nil, projectRequest)
 
 	if err != nil {
 		return project, err
@@ -196,6 +258,11 @@ func (c *Client) CreateProject(projectRequest ProjectRequest) (Project, error) {
 
 // UpdateProject using ProjectRequest returns updated project on success
 func (c *Client) UpdateProject(projectId string, updates ProjectRequest) (Project, error) {
+	return c.UpdateProjectCtx(context.Background(), projectId, updates)
+}
+
+// UpdateProjectCtx is like UpdateProject but uses ctx for the request
+func (c *Client) UpdateProjectCtx(ctx context.Context, projectId string, updates ProjectRequest) (Project, error) {
 	var (
 		uri            = "/rest/projects/" + projectId
 		updatedProject Project
@@ -205,7 +272,7 @@ func (c *Client) UpdateProject(projectId string, updates ProjectRequest) (Projec
 		return updatedProject, fmt.Errorf("projectId must be non-empty")
 	}
 
-	response, err := c.RestAPICallPatch(uri, nil, updates)
+	response, err := c.RestAPICallPatchCtx(ctx, uri, nil, updates)
 
 	if err != nil {
 		return updatedProject, err
@@ -219,6 +286,26 @@ func (c *Client) UpdateProject(projectId string, updates ProjectRequest) (Projec
 }
 
 // DeleteProject Deletes Project
+// A protected project is not deleted and a ProtectedError is returned.
 func (c *Client) DeleteProject(projectId string) error {
-	return c.notImplementedError(rest.DELETE, "/rest/projects/"+projectId, "projects")
+	return c.DeleteProjectCtx(context.Background(), projectId)
+}
+
+// DeleteProjectCtx is like DeleteProject but uses ctx for the requests
+func (c *Client) DeleteProjectCtx(ctx context.Context, projectId string) error {
+	if projectId == "" {
+		return fmt.Errorf("projectId must be non-empty")
+	}
+
+	project, err := c.GetProjectByIDCtx(ctx, projectId, "")
+	if err != nil {
+		return err
+	}
+	if project.Protected {
+		return &ProtectedError{Resource: "project", ID: projectId}
+	}
+
+	_, err = c.RestAPICallCtx(ctx, rest.DELETE, "/rest/projects/"+projectId, nil, nil)
+
+	return err
 }
@@ -1,6 +1,9 @@
 package onesphere
 
-import "testing"
+import (
+	"testing"
+	"time"
+)
 
 func TestGetProjects(t *testing.T) {
 	setup()
@@ -37,10 +40,10 @@ func TestGetProjectByID(t *testing.T) {
 func TestGetProjectByName(t *testing.T) {
 	setup()
 
-	_, err := client.GetProjectByName("2")
-	if err != nil {
-		t.Error(err)
-	}
+	checkByName(t, "TestGetProjectByName", "project", func(name string) (string, error) {
+		project, err := client.GetProjectByName(name)
+		return project.Name, err
+	}, "/rest/projects", Project{ID: "3", Name: "project"})
 }
 
 func TestCreateProject(t *testing.T) {
@@ -67,6 +70,22 @@ func TestDeleteProject(t *testing.T) {
 	setup()
 
 	if err := client.DeleteProject("2"); err != nil {
-		t.Skip(err)
+		t.Error(err)
+	}
+}
+
+func TestDeleteProtectedProject(t *testing.T) {
+	server, c := newWaitClient(t, time.Second)
+	server.Seed("/rest/projects", Project{ID: "p1", Protected: true})
+
+	if err := c.DeleteProject("p1"); !IsProtected(err) {
+		t.Errorf("TestDeleteProtectedProject expected a ProtectedError, got %v", err)
+	}
+	var project Project
+	if ok, _ := server.Get("/rest/projects/p1", &project); !ok {
+		t.Errorf("TestDeleteProtectedProject the protected project must not be deleted")
+	}
+	if err := c.DeleteProject("missing"); !IsNotFound(err) {
+		t.Errorf("TestDeleteProtectedProject expected a not found error, got %v", err)
 	}
 }
@@ -221,10 +221,10 @@ func (c *Client) DeleteProvider(providerId string) error {
 
 	var uri = "/rest/providers/" + providerId
 
-	response, err := c.RestAPICall(rest.DELETE, uri, nil, nil)
+	_, err := c.RestAPICall(rest.DELETE, uri, nil, nil)
 
 	if err != nil {
-		return apiResponseError(response, err)
+		return err
 	}
 
 	return nil
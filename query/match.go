@@ -0,0 +1,90 @@
+// (C) Copyright 2018 Hewlett Packard Enterprise Development LP.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.  IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package query
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Match reports whether the resource whose fields are returned by lookup
+// satisfies q, so that fakes can filter like the OneSphere API does.
+// Values are compared as numbers when both sides are numbers and as strings
+// otherwise, a missing field only satisfies NE. The zero Query matches everything.
+func (q Query) Match(lookup func(field string) (string, bool)) bool {
+	if q.node == nil {
+		return true
+	}
+	return q.node.match(lookup)
+}
+
+func (c comparison) match(lookup func(field string) (string, bool)) bool {
+	value, ok := lookup(c.field)
+	if !ok {
+		return c.op == NE
+	}
+
+	cmp := strings.Compare(value, c.value)
+	if x, err := strconv.ParseFloat(value, 64); err == nil {
+		if y, err := strconv.ParseFloat(c.value, 64); err == nil {
+			cmp = compareFloats(x, y)
+		}
+	}
+
+	switch c.op {
+	case EQ:
+		return cmp == 0
+	case NE:
+		return cmp != 0
+	case LT:
+		return cmp < 0
+	case LE:
+		return cmp <= 0
+	case GT:
+		return cmp > 0
+	case GE:
+		return cmp >= 0
+	}
+	return false
+}
+
+func (l logical) match(lookup func(field string) (string, bool)) bool {
+	for _, term := range l.terms {
+		matched := term.match(lookup)
+		if l.op == "AND" && !matched {
+			return false
+		}
+		if l.op == "OR" && matched {
+			return true
+		}
+	}
+	return l.op == "AND"
+}
+
+func compareFloats(x, y float64) int {
+	switch {
+	case x < y:
+		return -1
+	case x > y:
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,268 @@
+// (C) Copyright 2018 Hewlett Packard Enterprise Development LP.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.  IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package query
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// SyntaxError reports an invalid query and the byte offset where parsing failed
+type SyntaxError struct {
+	Offset int
+	Msg    string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("query: %s at offset %d", e.Msg, e.Offset)
+}
+
+// Parse parses a query such as `name EQ "my zone" AND (state EQ Enabled OR state EQ Enabling)`.
+// Keywords and operators are case insensitive, Parse(q.String()) returns a Query
+// equivalent to q. The empty string parses to the zero Query.
+func Parse(s string) (Query, error) {
+	tokens, err := tokenize(s)
+	if err != nil {
+		return Query{}, err
+	}
+	if len(tokens) == 0 {
+		return Query{}, nil
+	}
+
+	p := &parser{tokens: tokens, end: len(s)}
+	q, err := p.or()
+	if err != nil {
+		return Query{}, err
+	}
+	if t, ok := p.peek(); ok {
+		return Query{}, &SyntaxError{Offset: t.offset, Msg: fmt.Sprintf("unexpected %q", t.text)}
+	}
+	return q, nil
+}
+
+// MustParse is like Parse but panics on an invalid query, for constant queries
+func MustParse(s string) Query {
+	q, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return q
+}
+
+type tokenKind int
+
+const (
+	word tokenKind = iota
+	quoted
+	openParen
+	closeParen
+)
+
+type token struct {
+	kind   tokenKind
+	text   string
+	offset int
+}
+
+func tokenize(s string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\r' || c == '\n':
+			i++
+		case c == '(':
+			tokens = append(tokens, token{kind: openParen, text: "(", offset: i})
+			i++
+		case c == ')':
+			tokens = append(tokens, token{kind: closeParen, text: ")", offset: i})
+			i++
+		case c == '"' || c == '\'':
+			text, n, err := unquote(s[i:])
+			if err != nil {
+				err.Offset += i
+				return nil, err
+			}
+			tokens = append(tokens, token{kind: quoted, text: text, offset: i})
+			i += n
+		default:
+			start := i
+			for i < len(s) && !strings.ContainsRune(" \t\r\n()\"'", rune(s[i])) {
+				i++
+			}
+			tokens = append(tokens, token{kind: word, text: s[start:i], offset: start})
+		}
+	}
+	return tokens, nil
+}
+
+// unquote reads the quoted string at the start of s and returns its value
+// and the number of bytes consumed
+func unquote(s string) (string, int, *SyntaxError) {
+	quote := s[0]
+	var b strings.Builder
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			if i+1 == len(s) {
+				return "", 0, &SyntaxError{Offset: i, Msg: "unterminated escape"}
+			}
+			i++
+			b.WriteByte(s[i])
+		case quote:
+			return b.String(), i + 1, nil
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return "", 0, &SyntaxError{Offset: 0, Msg: "unterminated quoted value"}
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+	end    int
+}
+
+func (p *parser) peek() (token, bool) {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos], true
+	}
+	return token{}, false
+}
+
+func (p *parser) next(what string) (token, error) {
+	t, ok := p.peek()
+	if !ok {
+		return t, &SyntaxError{Offset: p.end, Msg: "expected " + what}
+	}
+	p.pos++
+	return t, nil
+}
+
+// keyword consumes the next token when it is the unquoted keyword kw
+func (p *parser) keyword(kw string) bool {
+	if t, ok := p.peek(); ok && t.kind == word && strings.EqualFold(t.text, kw) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+// or := and { OR and }
+func (p *parser) or() (Query, error) {
+	q, err := p.and()
+	if err != nil {
+		return q, err
+	}
+	for p.keyword("OR") {
+		r, err := p.and()
+		if err != nil {
+			return r, err
+		}
+		q = q.Or(r)
+	}
+	return q, nil
+}
+
+// and := term { AND term }
+func (p *parser) and() (Query, error) {
+	q, err := p.term()
+	if err != nil {
+		return q, err
+	}
+	for p.keyword("AND") {
+		r, err := p.term()
+		if err != nil {
+			return r, err
+		}
+		q = q.And(r)
+	}
+	return q, nil
+}
+
+// term := "(" or ")" | field operator value
+func (p *parser) term() (Query, error) {
+	t, err := p.next("field or \"(\"")
+	if err != nil {
+		return Query{}, err
+	}
+
+	if t.kind == openParen {
+		q, err := p.or()
+		if err != nil {
+			return q, err
+		}
+		if c, err := p.next("\")\""); err != nil {
+			return Query{}, err
+		} else if c.kind != closeParen {
+			return Query{}, &SyntaxError{Offset: c.offset, Msg: fmt.Sprintf("expected \")\", found %q", c.text)}
+		}
+		return q, nil
+	}
+
+	if t.kind != word || !isField(t.text) {
+		return Query{}, &SyntaxError{Offset: t.offset, Msg: fmt.Sprintf("invalid field %q", t.text)}
+	}
+
+	o, err := p.next("operator")
+	if err != nil {
+		return Query{}, err
+	}
+	op, ok := operator(o)
+	if !ok {
+		return Query{}, &SyntaxError{Offset: o.offset, Msg: fmt.Sprintf("unknown operator %q, expected one of %v", o.text, operators)}
+	}
+
+	v, err := p.next("value")
+	if err != nil {
+		return Query{}, err
+	}
+	if v.kind != word && v.kind != quoted {
+		return Query{}, &SyntaxError{Offset: v.offset, Msg: fmt.Sprintf("expected value, found %q", v.text)}
+	}
+
+	return Compare(t.text, op, v.text), nil
+}
+
+func operator(t token) (Operator, bool) {
+	if t.kind != word {
+		return "", false
+	}
+	for _, op := range operators {
+		if strings.EqualFold(t.text, string(op)) {
+			return op, true
+		}
+	}
+	return "", false
+}
+
+// isField reports whether s is a property name such as "zoneUri" or "location.region"
+func isField(s string) bool {
+	for i, r := range s {
+		if r == '_' || unicode.IsLetter(r) || (i > 0 && (r == '.' || unicode.IsDigit(r))) {
+			continue
+		}
+		return false
+	}
+	return s != ""
+}
@@ -0,0 +1,197 @@
+// (C) Copyright 2018 Hewlett Packard Enterprise Development LP.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.  IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+// Package query builds and parses the filter expressions accepted by the
+// query parameter of the OneSphere list endpoints, such as
+//
+//	name EQ "my zone" AND regionUri EQ /rest/regions/1234
+//
+// Values containing spaces, quotes or parentheses are quoted and escaped.
+package query
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Operator compares a field with a value
+type Operator string
+
+// Operators accepted by the OneSphere API
+const (
+	EQ Operator = "EQ"
+	NE Operator = "NE"
+	LT Operator = "LT"
+	LE Operator = "LE"
+	GT Operator = "GT"
+	GE Operator = "GE"
+)
+
+var operators = []Operator{EQ, NE, LT, LE, GT, GE}
+
+// Query is a filter expression. The zero Query matches everything and
+// renders as the empty string, which the list methods treat as no filter.
+type Query struct {
+	node node
+}
+
+type node interface {
+	write(b *strings.Builder, parent string)
+	match(lookup func(field string) (string, bool)) bool
+}
+
+// comparison is a single "field OP value" term
+type comparison struct {
+	field string
+	op    Operator
+	value string
+}
+
+// logical joins terms with AND or OR
+type logical struct {
+	op    string
+	terms []node
+}
+
+// Compare returns the Query "field op value", value is formatted with fmt.Sprint
+func Compare(field string, op Operator, value interface{}) Query {
+	return Query{node: comparison{field: field, op: op, value: fmt.Sprint(value)}}
+}
+
+// Eq returns the Query "field EQ value"
+func Eq(field string, value interface{}) Query { return Compare(field, EQ, value) }
+
+// Ne returns the Query "field NE value"
+func Ne(field string, value interface{}) Query { return Compare(field, NE, value) }
+
+// Lt returns the Query "field LT value"
+func Lt(field string, value interface{}) Query { return Compare(field, LT, value) }
+
+// Le returns the Query "field LE value"
+func Le(field string, value interface{}) Query { return Compare(field, LE, value) }
+
+// Gt returns the Query "field GT value"
+func Gt(field string, value interface{}) Query { return Compare(field, GT, value) }
+
+// Ge returns the Query "field GE value"
+func Ge(field string, value interface{}) Query { return Compare(field, GE, value) }
+
+// And returns a Query matching q and all of others, zero queries are skipped
+func (q Query) And(others ...Query) Query {
+	return join("AND", append([]Query{q}, others...))
+}
+
+// Or returns a Query matching q or any of others, zero queries are skipped
+func (q Query) Or(others ...Query) Query {
+	return join("OR", append([]Query{q}, others...))
+}
+
+// All returns a Query matching all of queries
+func All(queries ...Query) Query { return join("AND", queries) }
+
+// Any returns a Query matching any of queries
+func Any(queries ...Query) Query { return join("OR", queries) }
+
+func join(op string, queries []Query) Query {
+	var terms []node
+	for _, q := range queries {
+		switch n := q.node.(type) {
+		case nil:
+		case logical:
+			// flatten "a AND (b AND c)" into "a AND b AND c"
+			if n.op == op {
+				terms = append(terms, n.terms...)
+			} else {
+				terms = append(terms, n)
+			}
+		default:
+			terms = append(terms, n)
+		}
+	}
+
+	switch len(terms) {
+	case 0:
+		return Query{}
+	case 1:
+		return Query{node: terms[0]}
+	}
+	return Query{node: logical{op: op, terms: terms}}
+}
+
+// IsZero reports whether q is the empty Query
+func (q Query) IsZero() bool {
+	return q.node == nil
+}
+
+// String renders q in the syntax of the query parameter
+func (q Query) String() string {
+	if q.node == nil {
+		return ""
+	}
+	var b strings.Builder
+	q.node.write(&b, "")
+	return b.String()
+}
+
+func (c comparison) write(b *strings.Builder, parent string) {
+	b.WriteString(c.field)
+	b.WriteByte(' ')
+	b.WriteString(string(c.op))
+	b.WriteByte(' ')
+	b.WriteString(Quote(c.value))
+}
+
+func (l logical) write(b *strings.Builder, parent string) {
+	// AND binds tighter than OR, so only an OR inside an AND needs parentheses
+	nested := parent == "AND" && l.op == "OR"
+	if nested {
+		b.WriteByte('(')
+	}
+	for i, term := range l.terms {
+		if i > 0 {
+			b.WriteString(" " + l.op + " ")
+		}
+		term.write(b, l.op)
+	}
+	if nested {
+		b.WriteByte(')')
+	}
+}
+
+// Quote returns value as it must appear in a query. Values made only of
+// characters other than spaces, quotes, backslashes and parentheses are
+// returned as they are, others are enclosed in double quotes with quotes and
+// backslashes escaped by a backslash.
+func Quote(value string) string {
+	if value != "" && !strings.ContainsAny(value, " \t\r\n\"'\\()") {
+		return value
+	}
+
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range value {
+		if r == '"' || r == '\\' {
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+	b.WriteByte('"')
+	return b.String()
+}
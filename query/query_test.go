@@ -0,0 +1,85 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuilder(t *testing.T) {
+	assert.Equal(t, "", Query{}.String(), "zero Query should render empty")
+	assert.Equal(t, "zoneUri EQ /rest/zones/1", Eq("zoneUri", "/rest/zones/1").String())
+	assert.Equal(t, `name EQ "my zone" AND regionUri EQ /rest/regions/2`,
+		Eq("name", "my zone").And(Eq("regionUri", "/rest/regions/2")).String())
+	assert.Equal(t, "count GE 3 AND (state EQ Enabled OR state EQ Enabling)",
+		Ge("count", 3).And(Eq("state", "Enabled").Or(Eq("state", "Enabling"))).String())
+	assert.Equal(t, "a EQ 1 AND b EQ 2 AND c EQ 3",
+		Eq("a", 1).And(Eq("b", 2)).And(Eq("c", 3)).String(), "nested AND should be flattened")
+	assert.Equal(t, "a EQ 1", All(Query{}, Eq("a", 1), Query{}).String(), "zero queries should be skipped")
+}
+
+func TestQuote(t *testing.T) {
+	assert.Equal(t, "/rest/zones/1", Quote("/rest/zones/1"))
+	assert.Equal(t, `""`, Quote(""))
+	assert.Equal(t, `"say \"hi\""`, Quote(`say "hi"`))
+	assert.Equal(t, `"C:\\temp (old)"`, Quote(`C:\temp (old)`))
+	assert.Equal(t, `"o'brien"`, Quote("o'brien"))
+}
+
+func TestParse(t *testing.T) {
+	queries := []Query{
+		Eq("name", `my "zone"`),
+		Eq("name", "a").Or(Ne("name", "b")).And(Lt("size", 10)),
+		Eq("a", "1").Or(Eq("b", "2").And(Gt("c", "(3)"))),
+	}
+	for _, q := range queries {
+		parsed, err := Parse(q.String())
+		if assert.NoError(t, err, q.String()) {
+			assert.Equal(t, q.String(), parsed.String(), "Parse should round trip")
+		}
+	}
+
+	q, err := Parse(`name eq 'my zone' and (state EQ Enabled or state EQ Enabling)`)
+	assert.NoError(t, err)
+	assert.Equal(t, `name EQ "my zone" AND (state EQ Enabled OR state EQ Enabling)`, q.String())
+
+	q, err = Parse("   ")
+	assert.NoError(t, err)
+	assert.True(t, q.IsZero())
+}
+
+func TestParseErrors(t *testing.T) {
+	invalid := map[string]int{
+		"name":                  4,
+		"name LIKE x":           5,
+		"name EQ":               7,
+		`name EQ "unterminated`: 8,
+		"name EQ x AND":         13,
+		"(name EQ x":            10,
+		"name EQ x)":            9,
+		"1name EQ x":            0,
+		"name EQ x y":           10,
+	}
+	for s, offset := range invalid {
+		_, err := Parse(s)
+		if assert.Error(t, err, s) {
+			assert.Equal(t, offset, err.(*SyntaxError).Offset, s)
+		}
+	}
+}
+
+func TestMatch(t *testing.T) {
+	fields := map[string]string{"name": "my zone", "state": "Enabled", "cpu": "8"}
+	lookup := func(field string) (string, bool) {
+		value, ok := fields[field]
+		return value, ok
+	}
+
+	assert.True(t, Query{}.Match(lookup))
+	assert.True(t, MustParse(`name EQ "my zone" AND (state EQ Enabled OR state EQ Enabling)`).Match(lookup))
+	assert.False(t, Eq("name", "other").Or(Eq("state", "Disabled")).Match(lookup))
+	assert.True(t, Lt("cpu", 10).Match(lookup), "numbers compare as numbers")
+	assert.False(t, Gt("cpu", 10).Match(lookup))
+	assert.True(t, Ne("regionUri", "/rest/regions/1").Match(lookup))
+	assert.False(t, Eq("regionUri", "/rest/regions/1").Match(lookup))
+}
@@ -0,0 +1,159 @@
+// (C) Copyright 2018 Hewlett Packard Enterprise Development LP.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.  IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package onesphere
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/HewlettPackard/hpe-onesphere-go/rest"
+)
+
+type Rate struct {
+	ID            string    `json:"id"`
+	Name          string    `json:"name"`
+	URI           string    `json:"uri"`
+	ResourceURI   string    `json:"resourceUri"`
+	MetricName    string    `json:"metricName"`
+	UnitOfMeasure string    `json:"unitOfMeasure"`
+	UnitPrice     float64   `json:"unitPrice"`
+	EffectiveDate time.Time `json:"effectiveDate"`
+	Created       time.Time `json:"created"`
+	Modified      time.Time `json:"modified"`
+}
+
+type RateList struct {
+	Total   int    `json:"total"`
+	Start   int    `json:"start"`
+	Count   int    `json:"count"`
+	Members []Rate `json:"members"`
+}
+
+// GetRates returns RateList for resourceUri
+// example effectiveForDate: "2018-01-01T00:00:00Z"
+func (c *Client) GetRates(resourceUri, effectiveForDate, effectiveDate, metricName string,
+	active bool, start, count int) (RateList, error) {
+	return c.GetRatesCtx(context.Background(), resourceUri, effectiveForDate, effectiveDate, metricName, active, start, count)
+}
+
+// GetRatesCtx is like GetRates but uses ctx for the request
+func (c *Client) GetRatesCtx(ctx context.Context, resourceUri, effectiveForDate, effectiveDate, metricName string,
+	active bool, start, count int) (RateList, error) {
+	queryParams := encodeParams(RateListOptions{
+		ResourceURI: resourceUri,
+		MetricName:  metricName,
+		Active:      &active,
+		ListOptions: ListOptions{Start: start, Count: count},
+	})
+	// the dates are sent as given, like they always were
+	if effectiveForDate != "" {
+		queryParams["effectiveForDate"] = effectiveForDate
+	}
+	if effectiveDate != "" {
+		queryParams["effectiveDate"] = effectiveDate
+	}
+
+	return c.listRates(ctx, queryParams)
+}
+
+// ListRates returns the RateList selected by opts
+func (c *Client) ListRates(opts RateListOptions) (RateList, error) {
+	return c.ListRatesCtx(context.Background(), opts)
+}
+
+// ListRatesCtx is like ListRates but uses ctx for the request
+func (c *Client) ListRatesCtx(ctx context.Context, opts RateListOptions) (RateList, error) {
+	return c.listRates(ctx, encodeParams(opts))
+}
+
+func (c *Client) listRates(ctx context.Context, queryParams map[string]string) (RateList, error) {
+	var (
+		uri   = "/rest/rates"
+		rates RateList
+	)
+
+	response, err := c.RestAPICallCtx(ctx, rest.GET, uri, queryParams, nil)
+
+	if err != nil {
+		return rates, err
+	}
+
+	if err := json.Unmarshal([]byte(response), &rates); err != nil {
+		return rates, apiResponseError(response, err)
+	}
+
+	return rates, err
+}
+
+// ForEachRate calls fn for every Rate selected by opts across all pages,
+// see ForEachDeployment for paging and how fn stops the iteration
+func (c *Client) ForEachRate(opts RateListOptions, fn func(Rate) error) error {
+	return c.ForEachRateCtx(context.Background(), opts, fn)
+}
+
+// ForEachRateCtx is like ForEachRate but uses ctx for the requests
+func (c *Client) ForEachRateCtx(ctx context.Context, opts RateListOptions, fn func(Rate) error) error {
+	return c.forEachPage(ctx, "/rest/rates", encodeParams(opts), func(response string) (page, error) {
+		var rates RateList
+		if err := json.Unmarshal([]byte(response), &rates); err != nil {
+			return page{}, apiResponseError(response, err)
+		}
+
+		for _, rate := range rates.Members {
+			if err := fn(rate); err != nil {
+				return page{}, err
+			}
+		}
+
+		return page{total: rates.Total, members: len(rates.Members)}, nil
+	})
+}
+
+// GetRate returns a Rate by id
+func (c *Client) GetRate(rateID string) (Rate, error) {
+	return c.GetRateCtx(context.Background(), rateID)
+}
+
+// GetRateCtx is like GetRate but uses ctx for the request
+func (c *Client) GetRateCtx(ctx context.Context, rateID string) (Rate, error) {
+	var (
+		uri  = "/rest/rates/" + rateID
+		rate Rate
+	)
+
+	if rateID == "" {
+		return rate, fmt.Errorf("rateID must not be empty")
+	}
+
+	response, err := c.RestAPICallCtx(ctx, rest.GET, uri, nil, nil)
+
+	if err != nil {
+		return rate, err
+	}
+
+	if err := json.Unmarshal([]byte(response), &rate); err != nil {
+		return rate, apiResponseError(response, err)
+	}
+
+	return rate, err
+}
@@ -0,0 +1,168 @@
+// (C) Copyright 2018 Hewlett Packard Enterprise Development LP.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.  IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+// Package redact scrubs secrets from what the SDK logs, records and returns
+// in errors: the values of JSON keys and parameters such as "password",
+// "secretKey" or "token", the Authorization and cookie headers, and PEM
+// encoded certificates and keys. Each of them is replaced with Redacted.
+package redact
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// Redacted replaces the secrets
+const Redacted = "<REDACTED>"
+
+// secretHeaders are the canonical names of the headers whose values are secret
+var secretHeaders = map[string]bool{
+	"Authorization":       true,
+	"Cookie":              true,
+	"Proxy-Authorization": true,
+	"Set-Cookie":          true,
+	"X-Api-Key":           true,
+	"X-Auth-Token":        true,
+}
+
+// secretKeys are the substrings of the keys whose values are secret,
+// compared in lower case without "-" and "_"
+var secretKeys = []string{"password", "passwd", "token", "secret", "accesskey", "apikey", "privatekey", "authorization"}
+
+var (
+	keyNormalizer = strings.NewReplacer("-", "", "_", "")
+
+	// (?s) enables '.' to match '\n' -- see https://golang.org/pkg/regexp/syntax/
+	pemRegex = regexp.MustCompile(`(?s)-----BEGIN ([A-Z0-9 ]+)-----.*?-----END ([A-Z0-9 ]+)-----`)
+	// a "key": value member of a JSON document embedded in text, whose value
+	// is a string, a number or a boolean
+	jsonFieldRegex = regexp.MustCompile(`"([^"\\]+)"(\s*:\s*)(?:"(?:[^"\\]|\\.)*"|-?[0-9][0-9.eE+-]*|true|false)`)
+	// a key=value pair of a query string or a log entry
+	paramRegex = regexp.MustCompile(`([A-Za-z][A-Za-z0-9_-]*)=([^\s&"]+)`)
+)
+
+// IsSecretKey reports whether the value of the JSON key or parameter is secret
+func IsSecretKey(key string) bool {
+	key = keyNormalizer.Replace(strings.ToLower(key))
+	for _, secret := range secretKeys {
+		if strings.Contains(key, secret) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsSecretHeader reports whether the value of the header is secret
+func IsSecretHeader(name string) bool {
+	return secretHeaders[http.CanonicalHeaderKey(name)]
+}
+
+// Header returns a copy of header with the values of the secret headers redacted
+func Header(header http.Header) http.Header {
+	if header == nil {
+		return nil
+	}
+	redacted := header.Clone()
+	for name := range redacted {
+		if IsSecretHeader(name) {
+			redacted[name] = []string{Redacted}
+		}
+	}
+	return redacted
+}
+
+// String redacts the PEM blocks, and the values of the secret JSON members
+// and key=value pairs found in s. JSON values that are objects or arrays are
+// left to JSON, which parses the whole document, as are null values.
+func String(s string) string {
+	s = pemRegex.ReplaceAllString(s, Redacted)
+	s = jsonFieldRegex.ReplaceAllStringFunc(s, func(field string) string {
+		m := jsonFieldRegex.FindStringSubmatch(field)
+		if !IsSecretKey(m[1]) {
+			return field
+		}
+		return `"` + m[1] + `"` + m[2] + `"` + Redacted + `"`
+	})
+	return paramRegex.ReplaceAllStringFunc(s, func(param string) string {
+		m := paramRegex.FindStringSubmatch(param)
+		if !IsSecretKey(m[1]) {
+			return param
+		}
+		return m[1] + "=" + Redacted
+	})
+}
+
+// JSON redacts a JSON document, other content is redacted with String
+func JSON(body []byte) []byte {
+	var doc interface{}
+	if len(body) == 0 || json.Unmarshal(body, &doc) != nil {
+		return []byte(String(string(body)))
+	}
+
+	var b bytes.Buffer
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(Value(doc)); err != nil {
+		return []byte(String(string(body)))
+	}
+	return bytes.TrimSuffix(b.Bytes(), []byte("\n"))
+}
+
+// Value redacts a decoded JSON document in place and returns it: the non-null
+// values of the secret keys are replaced and the strings redacted with String
+func Value(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			if IsSecretKey(key) {
+				if value != nil {
+					v[key] = Redacted
+				}
+				continue
+			}
+			v[key] = Value(value)
+		}
+	case []interface{}:
+		for i, value := range v {
+			v[i] = Value(value)
+		}
+	case string:
+		return String(v)
+	}
+	return v
+}
+
+// KeyValues returns a copy of the alternating keys and values with the
+// values of the secret keys redacted
+func KeyValues(keyvals []interface{}) []interface{} {
+	redacted := make([]interface{}, len(keyvals))
+	copy(redacted, keyvals)
+	for i := 0; i+1 < len(redacted); i += 2 {
+		if key, ok := redacted[i].(string); ok && IsSecretKey(key) {
+			redacted[i+1] = Redacted
+		} else if s, ok := redacted[i+1].(string); ok {
+			redacted[i+1] = String(s)
+		}
+	}
+	return redacted
+}
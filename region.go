@@ -21,8 +21,10 @@
 package onesphere
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"github.com/HewlettPackard/hpe-onesphere-go/query"
 	"github.com/HewlettPackard/hpe-onesphere-go/rest"
 	"strconv"
 	"time"
@@ -90,16 +92,31 @@ type RegionConnection struct {
 // example query: "providerUri EQ /rest/providers/xxxx"
 // example view: "full"
 func (c *Client) GetRegions(query, view string) (RegionList, error) {
+	return c.GetRegionsCtx(context.Background(), query, view)
+}
+
+// GetRegionsCtx is like GetRegions but uses ctx for the request
+func (c *Client) GetRegionsCtx(ctx context.Context, query, view string) (RegionList, error) {
+	return c.ListRegionsCtx(ctx, RegionListOptions{
+		Query:       query,
+		ListOptions: ListOptions{View: View(view)},
+	})
+}
+
+// ListRegions returns the RegionList selected by opts
+func (c *Client) ListRegions(opts RegionListOptions) (RegionList, error) {
+	return c.ListRegionsCtx(context.Background(), opts)
+}
+
+// ListRegionsCtx is like ListRegions but uses ctx for the request
+func (c *Client) ListRegionsCtx(ctx context.Context, opts RegionListOptions) (RegionList, error) {
 	var (
 		uri         = "/rest/regions"
-		queryParams = createQuery(&map[string]string{
-			"query": query,
-			"view":  view,
-		})
-		regions RegionList
+		queryParams = encodeParams(opts)
+		regions     RegionList
 	)
 
-	response, err := c.RestAPICall(rest.GET, uri, queryParams, nil)
+	response, err := c.RestAPICallCtx(ctx, rest.GET, uri, queryParams, nil)
 
 	if err != nil {
 		return regions, err
@@ -116,6 +133,11 @@ func (c *Client) GetRegions(query, view string) (RegionList, error) {
 // example view: "full"
 // discover: Will return child providers from aws.
 func (c *Client) GetRegionByID(id, view string, discover bool) (Region, error) {
+	return c.GetRegionByIDCtx(context.Background(), id, view, discover)
+}
+
+// GetRegionByIDCtx is like GetRegionByID but uses ctx for the request
+func (c *Client) GetRegionByIDCtx(ctx context.Context, id, view string, discover bool) (Region, error) {
 	var (
 		uri         = "/rest/regions/" + id
 		queryParams = createQuery(&map[string]string{
@@ -129,7 +151,7 @@ func (c *Client) GetRegionByID(id, view string, discover bool) (Region, error) {
 		return region, fmt.Errorf("id must not be empty")
 	}
 
-	response, err := c.RestAPICall(rest.GET, uri, queryParams, nil)
+	response, err := c.RestAPICallCtx(ctx, rest.GET, uri, queryParams, nil)
 
 	if err != nil {
 		return region, err
@@ -142,36 +164,77 @@ func (c *Client) GetRegionByID(id, view string, discover bool) (Region, error) {
 	return region, err
 }
 
+// ForEachRegion calls fn for every Region selected by opts across all pages,
+// see ForEachDeployment for paging and how fn stops the iteration
+func (c *Client) ForEachRegion(opts RegionListOptions, fn func(Region) error) error {
+	return c.ForEachRegionCtx(context.Background(), opts, fn)
+}
+
+// ForEachRegionCtx is like ForEachRegion but uses ctx for the requests
+func (c *Client) ForEachRegionCtx(ctx context.Context, opts RegionListOptions, fn func(Region) error) error {
+	return c.forEachPage(ctx, "/rest/regions", encodeParams(opts), func(response string) (page, error) {
+		var regions RegionList
+		if err := json.Unmarshal([]byte(response), &regions); err != nil {
+			return page{}, apiResponseError(response, err)
+		}
+
+		for _, region := range regions.Members {
+			if err := fn(region); err != nil {
+				return page{}, err
+			}
+		}
+
+		return page{total: regions.Total, members: len(regions.Members)}, nil
+	})
+}
+
 // GetRegionByName Retrieve Region by Name
+// returns a NameNotFoundError or an AmbiguousNameError unless exactly one region has the name
 func (c *Client) GetRegionByName(name string) (Region, error) {
-	var region Region
+	return c.GetRegionByNameCtx(context.Background(), name)
+}
+
+// GetRegionByNameCtx is like GetRegionByName but uses ctx for the request
+func (c *Client) GetRegionByNameCtx(ctx context.Context, name string) (Region, error) {
+	var (
+		region  Region
+		matches = newNameMatches("region", name)
+	)
 
 	if name == "" {
 		return region, fmt.Errorf("name must not be empty")
 	}
 
-	regions, err := c.GetRegions("", "")
-
-	if len(regions.Members) > 0 {
-		for i := 0; i < len(regions.Members); i++ {
-			if regions.Members[i].Name == name {
-				region = regions.Members[i]
-				return region, err
-			}
+	err := c.ForEachRegionCtx(ctx, RegionListOptions{Query: query.Eq("name", name).String()}, func(candidate Region) error {
+		if matches.add(candidate.Name, candidate.URI) {
+			region = candidate
 		}
+		return nil
+	})
+	if err != nil {
+		return Region{}, err
 	}
 
-	return region, err
+	if err := matches.err(); err != nil {
+		return Region{}, err
+	}
+
+	return region, nil
 }
 
 // CreateRegion Creates Region and returns updated Region
 func (c *Client) CreateRegion(regionRequest RegionRequest) (Region, error) {
+	return c.CreateRegionCtx(context.Background(), regionRequest)
+}
+
+// CreateRegionCtx is like CreateRegion but uses ctx for the request
+func (c *Client) CreateRegionCtx(ctx context.Context, regionRequest RegionRequest) (Region, error) {
 	var (
 		uri    = "/rest/regions"
 		region Region
 	)
 
-	response, err := c.RestAPICall(rest.POST, uri, nil, regionRequest)
+	response, err := c.RestAPICallCtx(ctx, rest.POST, uri, nil, regionRequest)
 
 	if err != nil {
 		return region, err
@@ -197,6 +260,11 @@ Path: /location
 Op: replace
 */
 func (c *Client) UpdateRegion(regionId string, updates []*PatchOp) (Region, error) {
+	return c.UpdateRegionCtx(context.Background(), regionId, updates)
+}
+
+// UpdateRegionCtx is like UpdateRegion but uses ctx for the request
+func (c *Client) UpdateRegionCtx(ctx context.Context, regionId string, updates []*PatchOp) (Region, error) {
 	var (
 		uri           = "/rest/regions/" + regionId
 		updatedRegion Region
@@ -226,7 +294,7 @@ func (c *Client) UpdateRegion(regionId string, updates []*PatchOp) (Region, erro
 		}
 	}
 
-	response, err := c.RestAPICallPatch(uri, nil, updates)
+	response, err := c.RestAPICallPatchCtx(ctx, uri, nil, updates)
 
 	if err != nil {
 		return updatedRegion, err
@@ -241,22 +309,32 @@ func (c *Client) UpdateRegion(regionId string, updates []*PatchOp) (Region, erro
 
 // DeleteRegion Deletes Region
 func (c *Client) DeleteRegion(regionId string) error {
+	return c.DeleteRegionCtx(context.Background(), regionId)
+}
+
+// DeleteRegionCtx is like DeleteRegion but uses ctx for the request
+func (c *Client) DeleteRegionCtx(ctx context.Context, regionId string) error {
 	if regionId == "" {
 		return fmt.Errorf("regionId must be non-empty")
 	}
 
 	var uri = "/rest/regions/" + regionId
 
-	response, err := c.RestAPICall(rest.DELETE, uri, nil, nil)
+	_, err := c.RestAPICallCtx(ctx, rest.DELETE, uri, nil, nil)
 
 	if err != nil {
-		return apiResponseError(response, err)
+		return err
 	}
 
 	return nil
 }
 
 func (c *Client) GetRegionConnection(regionId string) (RegionConnection, error) {
+	return c.GetRegionConnectionCtx(context.Background(), regionId)
+}
+
+// GetRegionConnectionCtx is like GetRegionConnection but uses ctx for the request
+func (c *Client) GetRegionConnectionCtx(ctx context.Context, regionId string) (RegionConnection, error) {
 	var (
 		uri        = "/rest/regions/" + regionId + "/connection"
 		regionConn RegionConnection
@@ -266,7 +344,7 @@ func (c *Client) GetRegionConnection(regionId string) (RegionConnection, error)
 		return regionConn, fmt.Errorf("regionId must not be empty")
 	}
 
-	response, err := c.RestAPICall(rest.GET, uri, nil, nil)
+	response, err := c.RestAPICallCtx(ctx, rest.GET, uri, nil, nil)
 
 	if err != nil {
 		return regionConn, err
@@ -281,6 +359,11 @@ func (c *Client) GetRegionConnection(regionId string) (RegionConnection, error)
 
 // CreateRegionConnection Creates RegionConnection and returns updated RegionConnection
 func (c *Client) CreateRegionConnection(regionId string, regionConnectionRequest RegionConnectionRequest) (RegionConnection, error) {
+	return c.CreateRegionConnectionCtx(context.Background(), regionId, regionConnectionRequest)
+}
+
+// CreateRegionConnectionCtx is like CreateRegionConnection but uses ctx for the request
+func (c *Client) CreateRegionConnectionCtx(ctx context.Context, regionId string, regionConnectionRequest RegionConnectionRequest) (RegionConnection, error) {
 	var (
 		uri        = "/rest/regions/" + regionId + "/connection"
 		regionConn RegionConnection
@@ -290,7 +373,7 @@ func (c *Client) CreateRegionConnection(regionId string, regionConnectionRequest
 		return regionConn, fmt.Errorf("regionId must not be empty")
 	}
 
-	response, err := c.RestAPICall(rest.POST, uri, nil, regionConnectionRequest)
+	response, err := c.RestAPICallCtx(ctx, rest.POST, uri, nil, regionConnectionRequest)
 
 	if err != nil {
 		return regionConn, err
@@ -305,16 +388,21 @@ func (c *Client) CreateRegionConnection(regionId string, regionConnectionRequest
 
 // DeleteRegionConnection Deletes RegionConnection
 func (c *Client) DeleteRegionConnection(regionId string) error {
+	return c.DeleteRegionConnectionCtx(context.Background(), regionId)
+}
+
+// DeleteRegionConnectionCtx is like DeleteRegionConnection but uses ctx for the request
+func (c *Client) DeleteRegionConnectionCtx(ctx context.Context, regionId string) error {
 	if regionId == "" {
 		return fmt.Errorf("regionId must not be empty")
 	}
 
 	var uri = "/rest/regions/" + regionId + "/connection"
 
-	response, err := c.RestAPICall(rest.DELETE, uri, nil, nil)
+	_, err := c.RestAPICallCtx(ctx, rest.DELETE, uri, nil, nil)
 
 	if err != nil {
-		return apiResponseError(response, err)
+		return err
 	}
 
 	return nil
@@ -322,6 +410,11 @@ func (c *Client) DeleteRegionConnection(regionId string) error {
 
 // GetRegionConnectorImage returns generated connector-image url
 func (c *Client) GetRegionConnectorImage(regionId string) (string, error) {
+	return c.GetRegionConnectorImageCtx(context.Background(), regionId)
+}
+
+// GetRegionConnectorImageCtx is like GetRegionConnectorImage but uses ctx for the request
+func (c *Client) GetRegionConnectorImageCtx(ctx context.Context, regionId string) (string, error) {
 	var (
 		uri               = "/rest/regions/" + regionId + "/connection"
 		connectorImageURL string
@@ -331,5 +424,5 @@ func (c *Client) GetRegionConnectorImage(regionId string) (string, error) {
 		return connectorImageURL, fmt.Errorf("regionId must not be empty")
 	}
 
-	return c.RestAPICall(rest.GET, uri, nil, nil)
+	return c.RestAPICallCtx(ctx, rest.GET, uri, nil, nil)
 }
@@ -39,10 +39,10 @@ func TestGetRegionByID(t *testing.T) {
 func TestGetRegionByName(t *testing.T) {
 	setup()
 
-	_, err := client.GetRegionByName("2")
-	if err != nil {
-		t.Error(err)
-	}
+	checkByName(t, "TestGetRegionByName", "region", func(name string) (string, error) {
+		region, err := client.GetRegionByName(name)
+		return region.Name, err
+	}, "/rest/regions", Region{ID: "3", Name: "region"})
 }
 
 func TestCreateRegion(t *testing.T) {
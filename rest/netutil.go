@@ -2,15 +2,16 @@ package rest
 
 import (
 	"bytes"
-	"crypto/tls"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/url"
-	"os"
+	"sort"
 
 	"github.com/HewlettPackard/hpe-onesphere-go/log"
+	"github.com/HewlettPackard/hpe-onesphere-go/redact"
 	"github.com/HewlettPackard/hpe-onesphere-go/utils"
 )
 
@@ -27,13 +28,8 @@ var (
 		http.StatusInternalServerError: false,
 	}
 
-	// TODO: this should have a real cert
-	tr = &http.Transport{
-		TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
-	}
-
-	// get a client
-	client = &http.Client{Transport: tr}
+	// defaultClient verifies server certificates and uses the proxy from the environment
+	defaultClient = &http.Client{Transport: http.DefaultTransport}
 )
 
 // Options for REST call
@@ -50,6 +46,28 @@ type Client struct {
 	Endpoint string
 	Option   Options
 	logger   log.Logger
+
+	// HTTPClient sends the requests, defaultClient is used when nil
+	HTTPClient *http.Client
+}
+
+// Request is a single call sent by Client.Do
+type Request struct {
+	Method string
+	// Path is appended to the Endpoint and may carry a query string
+	Path string
+	// Header and Query are added to the Option headers and query, replacing
+	// the values of the same keys
+	Header map[string]string
+	Query  map[string]string
+	Body   []byte
+}
+
+// Response is the status, header and body of a Request
+type Response struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
 }
 
 // NewClient - get a new network client
@@ -57,6 +75,31 @@ func (c *Client) NewClient(user, key, endpoint string, logger log.Logger) *Clien
 	return &Client{User: user, APIKey: key, Endpoint: endpoint, Option: Options{}, logger: logger}
 }
 
+// SetLogger - set the logger of the requests, nil disables logging
+func (c *Client) SetLogger(logger log.Logger) {
+	c.logger = logger
+}
+
+func (c *Client) debug(args ...interface{}) {
+	if c.logger != nil {
+		c.logger.Debug(args...)
+	}
+}
+
+func (c *Client) debugf(fmtString string, args ...interface{}) {
+	if c.logger != nil {
+		c.logger.Debugf(fmtString, args...)
+	}
+}
+
+// httpClient - get the http.Client used to send requests
+func (c *Client) httpClient() *http.Client {
+	if c.HTTPClient != nil {
+		return c.HTTPClient
+	}
+	return defaultClient
+}
+
 // isOkStatus - check the return status of the response
 func (c *Client) isOkStatus(code int) bool {
 	return codes[code]
@@ -64,16 +107,21 @@ func (c *Client) isOkStatus(code int) bool {
 
 // SetQueryString - set the query strings to use
 func (c *Client) SetQueryString(query map[string]interface{}) {
-	// TODO: uuencode the query String
 	c.Option.Query = query
-	f, err := os.OpenFile("testlogfile", os.O_RDWR|os.O_CREATE|os.O_APPEND, 0666)
-	if err != nil {
-		//t.Fatalf("error opening file: %v", err)
-	}
-	defer f.Close()
+	c.debug("query", redactQuery(query))
+}
 
-	c.logger.SetOutWriter(f)
-	c.logger.Debug("query", query)
+// redactQuery returns a copy of query with the values of the secret keys redacted
+func redactQuery(query map[string]interface{}) map[string]interface{} {
+	redacted := make(map[string]interface{}, len(query))
+	for k, v := range query {
+		if redact.IsSecretKey(k) {
+			redacted[k] = redact.Redacted
+		} else {
+			redacted[k] = v
+		}
+	}
+	return redacted
 }
 
 // GetQueryString - get a query string for url
@@ -81,130 +129,111 @@ func (c *Client) GetQueryString(u *url.URL) {
 	if len(c.Option.Query) == 0 {
 		return
 	}
-	parameters := url.Values{}
+	parameters := u.Query()
 	for k, v := range c.Option.Query {
 		if val, ok := v.([]string); ok {
 			for _, va := range val {
 				parameters.Add(k, va)
 			}
 		} else {
-			parameters.Add(k, v.(string))
+			parameters.Add(k, fmt.Sprint(v))
 		}
-		u.RawQuery = parameters.Encode()
 	}
-	return
+	u.RawQuery = parameters.Encode()
 }
 
-// SetAuthHeaderOptins - set the Headers Options
+// SetAuthHeaderOptions - set the Headers Options
 func (c *Client) SetAuthHeaderOptions(headers map[string]string) {
 	c.Option.Headers = headers
-	f, err := os.OpenFile("testlogfile", os.O_RDWR|os.O_CREATE|os.O_APPEND, 0666)
-	if err != nil {
-		//t.Fatalf("error opening file: %v", err)
-	}
-	defer f.Close()
-
-	c.logger.SetOutWriter(f)
-	c.logger.Debug("header sent", headers)
+	c.debug("headers set", headerNames(headers))
 }
 
-// RestAPICall - general rest method caller
-func (c *Client) RestAPICall(method Method, path string, options interface{}) ([]byte, error) {
-	c.logger.Debugf("RestAPICall %s - %s%s", method, utils.Sanitize(c.Endpoint), path)
-
-	var (
-		Url *url.URL
-		err error
-		req *http.Request
-	)
-	f, err := os.OpenFile("testlogfile", os.O_RDWR|os.O_CREATE|os.O_APPEND, 0666)
-	if err != nil {
-		//t.Fatalf("error opening file: %v", err)
+// headerNames returns the sorted keys of headers, the values are not logged
+// as they usually hold credentials
+func headerNames(headers map[string]string) []string {
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
 	}
-	defer f.Close()
+	sort.Strings(names)
+	return names
+}
 
-	c.logger.SetOutWriter(f)
-	Url, err = url.Parse(utils.Sanitize(c.Endpoint))
+// Do sends req once and returns the response whatever its status.
+// The request is aborted when ctx is cancelled or its deadline expires.
+func (c *Client) Do(ctx context.Context, req Request) (*Response, error) {
+	u, err := url.Parse(utils.Sanitize(c.Endpoint) + req.Path)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("Error with request: %s - %q", req.Path, err)
 	}
-	Url.Path += path
 
 	// Manage the query string
-	c.GetQueryString(Url)
-
-	c.logger.Debugf("*** url => %s", Url.String())
-	c.logger.Debugf("*** method => %s", method.String())
+	c.GetQueryString(u)
+	if len(req.Query) > 0 {
+		parameters := u.Query()
+		for k, v := range req.Query {
+			parameters.Set(k, v)
+		}
+		u.RawQuery = parameters.Encode()
+	}
 
-	// parse url
-	reqUrl, err := url.Parse(Url.String())
+	httpReq, err := http.NewRequestWithContext(ctx, req.Method, u.String(), bytes.NewReader(req.Body))
 	if err != nil {
-		return nil, fmt.Errorf("Error with request: %v - %q", Url, err)
+		return nil, fmt.Errorf("Error with request: %v - %q", u, err)
 	}
 
-	// handle options
-	if options != nil {
-		OptionsJSON, err := json.Marshal(options)
-		if err != nil {
-			return nil, err
-		}
-		c.logger.Debugf("*** options => %+v", bytes.NewBuffer(OptionsJSON))
-		req, err = http.NewRequest(method.String(), reqUrl.String(), bytes.NewBuffer(OptionsJSON))
-	} else {
-		req, err = http.NewRequest(method.String(), reqUrl.String(), nil)
+	for k, v := range c.Option.Headers {
+		httpReq.Header.Set(k, v)
+	}
+	for k, v := range req.Header {
+		httpReq.Header.Set(k, v)
 	}
 
+	// the query string may carry credentials
+	logURL := redact.String(u.String())
+	c.debugf("%s %s", req.Method, logURL)
+	resp, err := c.httpClient().Do(httpReq)
 	if err != nil {
-		return nil, fmt.Errorf("Error with request: %v - %q", Url, err)
+		c.debugf("%s %s failed: %v", req.Method, logURL, redact.String(err.Error()))
+		return nil, err
 	}
+	defer resp.Body.Close()
 
-	// setup proxy
-	proxyUrl, err := http.ProxyFromEnvironment(req)
+	data, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("Error with proxy: %v - %q", proxyUrl, err)
-	}
-	if proxyUrl != nil {
-		tr.Proxy = http.ProxyURL(proxyUrl)
-		c.logger.Debugf("*** proxy => %+v", tr.Proxy)
+		return nil, err
 	}
+	c.debugf("%s %s returned %d with %d bytes", req.Method, logURL, resp.StatusCode, len(data))
 
-	// build the auth headerU
-	for k, v := range c.Option.Headers {
-		c.logger.Debugf("Headers -> %s -> %+v\n", k, v)
-		req.Header.Add(k, v)
-	}
+	return &Response{StatusCode: resp.StatusCode, Header: resp.Header, Body: data}, nil
+}
 
-	// req.SetBasicAuth(c.User, c.APIKey)
-	req.Method = fmt.Sprintf("%s", method.String())
+// RestAPICall - general rest method caller
+func (c *Client) RestAPICall(method Method, path string, options interface{}) ([]byte, error) {
+	var body []byte
 
-	c.logger.Debug("final req", req)
-	resp, err := client.Do(req)
+	// handle options
+	if options != nil {
+		OptionsJSON, err := json.Marshal(options)
+		if err != nil {
+			return nil, err
+		}
+		body = OptionsJSON
+	}
+
+	resp, err := c.Do(context.Background(), Request{Method: method.String(), Path: path, Body: body})
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-
-	// TODO: CLeanup Later
-	c.logger.Debugf("REQ    --> %+v\n", req)
-	c.logger.Debugf("RESP   --> %+v\n", resp)
-	c.logger.Debugf("ERROR  --> %+v\n", err)
-
-	data, err := ioutil.ReadAll(resp.Body)
-	c.logger.Debug("final response body", string(data))
 
 	if !c.isOkStatus(resp.StatusCode) {
 		type apiErr struct {
 			Err string `json:"details"`
 		}
 		var outErr apiErr
-		json.Unmarshal(data, &outErr)
-		return nil, fmt.Errorf("Error in response: %s\n Response Status: %s", outErr.Err, resp.Status)
+		json.Unmarshal(resp.Body, &outErr)
+		return nil, fmt.Errorf("Error in response: %s\n Response Status: %d %s", redact.String(outErr.Err), resp.StatusCode, http.StatusText(resp.StatusCode))
 	}
 
-	if err != nil {
-		c.logger.Debug("ERRRRRRRRRRRR in netutil end")
-		return nil, err
-	}
-	c.logger.Debug("response return from netutil end ", data)
-	return data, nil
+	return resp.Body, nil
 }
@@ -1,6 +1,8 @@
 package rest
 
 import (
+	"bytes"
+	"context"
 	"fmt"
 	"github.com/HewlettPackard/hpe-onesphere-go/log"
 	"net/http"
@@ -121,3 +123,64 @@ func getEndpointAndPath(ts *httptest.Server) (string, string) {
 	return fmt.Sprintf("%s://%s", u.Scheme, u.Host),
 		fmt.Sprintf("%s?%s", u.Path, u.RawQuery)
 }
+
+func TestDoMergesOptions(t *testing.T) {
+	var got *http.Request
+	ts, endpoint, _ := getServer(func(w http.ResponseWriter, r *http.Request) {
+		got = r
+		w.WriteHeader(http.StatusNotFound)
+	})
+	defer ts.Close()
+
+	// a nil logger disables logging
+	c := empty.NewClient("", "", endpoint, nil)
+	c.SetAuthHeaderOptions(map[string]string{"X-Tenant": "lab", "Accept": "text/plain"})
+	c.SetQueryString(map[string]interface{}{"trace": "on", "tag": []string{"a", "b"}})
+
+	resp, err := c.Do(context.Background(), Request{
+		Method: "GET",
+		Path:   "/rest/zones?start=2",
+		Header: map[string]string{"Accept": "application/json"},
+		Query:  map[string]string{"trace": "off"},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("Expected the status to be returned, got %d", resp.StatusCode)
+	}
+	if got.Header.Get("X-Tenant") != "lab" || got.Header.Get("Accept") != "application/json" {
+		t.Errorf("Unexpected headers %v", got.Header)
+	}
+	if q := got.URL.RawQuery; q != "start=2&tag=a&tag=b&trace=off" {
+		t.Errorf("Unexpected query %q", q)
+	}
+}
+
+func TestQueryStringSecretsAreNotLogged(t *testing.T) {
+	ts, endpoint, _ := getServer(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("password") != "hunter2" {
+			t.Errorf("The secret should still be sent, got %q", r.URL.RawQuery)
+		}
+	})
+	defer ts.Close()
+
+	var out bytes.Buffer
+	logger := &log.FmtLogger{}
+	logger.SetOutWriter(&out)
+	logger.SetDebug(true)
+
+	c := empty.NewClient("", "", endpoint, logger)
+	c.SetQueryString(map[string]interface{}{"password": "hunter2", "view": "full"})
+	if _, err := c.Do(context.Background(), Request{Method: "GET", Path: "/rest/zones"}); err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	if strings.Contains(out.String(), "hunter2") {
+		t.Errorf("The secret query parameter should be redacted from the log:\n%s", out.String())
+	}
+	if !strings.Contains(out.String(), "view:full") {
+		t.Errorf("The other query parameters should be logged:\n%s", out.String())
+	}
+}
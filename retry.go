@@ -0,0 +1,172 @@
+// (C) Copyright 2018 Hewlett Packard Enterprise Development LP.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.  IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package onesphere
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/HewlettPackard/hpe-onesphere-go/rest"
+)
+
+// RetryPolicy controls how requests failing with a transient error are retried.
+// Transient errors are connection failures and the 429, 502, 503 and 504 statuses.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first one
+	MaxAttempts int
+	// MinBackoff is the upper bound of the jittered wait before the first retry,
+	// it doubles with every further attempt up to MaxBackoff
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+	// Methods are retried on transient errors, GET, PUT and DELETE when empty.
+	// POST and PATCH are not idempotent and are only retried when listed here.
+	Methods []rest.Method
+}
+
+var idempotentMethods = []rest.Method{rest.GET, rest.PUT, rest.DELETE}
+
+var retryableStatus = map[int]bool{
+	http.StatusTooManyRequests:    true,
+	http.StatusBadGateway:         true,
+	http.StatusServiceUnavailable: true,
+	http.StatusGatewayTimeout:     true,
+}
+
+// DefaultRetryPolicy makes up to 4 attempts of idempotent requests,
+// waiting between 500ms and 30s before each retry
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 4,
+		MinBackoff:  500 * time.Millisecond,
+		MaxBackoff:  30 * time.Second,
+	}
+}
+
+// WithRetryPolicy retries transient failures of every call according to policy.
+// A Retry-After header sent with a 429 or 503 response takes precedence over the backoff,
+// it is capped at MaxBackoff.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(o *clientOptions) error {
+		if policy.MaxAttempts < 1 {
+			return fmt.Errorf("RetryPolicy.MaxAttempts must be at least 1")
+		}
+		if policy.MinBackoff < 0 || policy.MaxBackoff < policy.MinBackoff {
+			return fmt.Errorf("RetryPolicy backoff must satisfy 0 <= MinBackoff <= MaxBackoff")
+		}
+		o.retryPolicy = &policy
+		return nil
+	}
+}
+
+// backoff reports whether the attempt that produced resp and err should be
+// retried and how long to wait before doing so. A nil policy never retries.
+func (p *RetryPolicy) backoff(ctx context.Context, method string, attempt int, resp *rest.Response, err error) (time.Duration, bool) {
+	if p == nil || attempt >= p.MaxAttempts || !p.retries(method) {
+		return 0, false
+	}
+
+	if err != nil {
+		// the caller gave up, anything else is a connection level failure
+		if ctx.Err() != nil {
+			return 0, false
+		}
+		return p.jitter(attempt), true
+	}
+
+	if !retryableStatus[resp.StatusCode] {
+		return 0, false
+	}
+
+	if wait, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+		// a far away Retry-After must not block the call for hours
+		if wait > p.MaxBackoff {
+			wait = p.MaxBackoff
+		}
+		return wait, true
+	}
+
+	return p.jitter(attempt), true
+}
+
+func (p *RetryPolicy) retries(method string) bool {
+	methods := p.Methods
+	if len(methods) == 0 {
+		methods = idempotentMethods
+	}
+	for _, m := range methods {
+		if m.String() == method {
+			return true
+		}
+	}
+	return false
+}
+
+// jitter returns a random wait in [0, min(MaxBackoff, MinBackoff*2^(attempt-1))]
+func (p *RetryPolicy) jitter(attempt int) time.Duration {
+	ceiling := p.MinBackoff
+	for i := 1; i < attempt && ceiling < p.MaxBackoff; i++ {
+		ceiling *= 2
+	}
+	if ceiling > p.MaxBackoff {
+		ceiling = p.MaxBackoff
+	}
+	if ceiling <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(ceiling) + 1))
+}
+
+// parseRetryAfter reads a Retry-After value given in seconds or as an HTTP date
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		if wait := date.Sub(now); wait > 0 {
+			return wait, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+// sleepContext waits for d or until ctx is done
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
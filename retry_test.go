@@ -0,0 +1,143 @@
+package onesphere
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/HewlettPackard/hpe-onesphere-go/rest"
+)
+
+func newFlakyServer(failures int32, status int, retryAfter string) (*httptest.Server, *int32) {
+	var calls int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) <= failures {
+			if retryAfter != "" {
+				w.Header().Set("Retry-After", retryAfter)
+			}
+			w.WriteHeader(status)
+			return
+		}
+		w.Write([]byte(`{"id":"1"}`))
+	}))
+	return ts, &calls
+}
+
+func testRetryPolicy(methods ...rest.Method) Option {
+	return WithRetryPolicy(RetryPolicy{
+		MaxAttempts: 3,
+		MinBackoff:  time.Millisecond,
+		MaxBackoff:  5 * time.Millisecond,
+		Methods:     methods,
+	})
+}
+
+func TestRetryIdempotentRequest(t *testing.T) {
+	ts, calls := newFlakyServer(2, http.StatusServiceUnavailable, "")
+	defer ts.Close()
+
+	c, err := NewClient(ts.URL, testRetryPolicy())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.GetZoneByID("1"); err != nil {
+		t.Errorf("TestRetryIdempotentRequest GetZoneByID should succeed on the third attempt: %v", err)
+	}
+	if n := atomic.LoadInt32(calls); n != 3 {
+		t.Errorf("TestRetryIdempotentRequest expected 3 attempts, got %d", n)
+	}
+}
+
+func TestRetryGivesUpAfterMaxAttempts(t *testing.T) {
+	ts, calls := newFlakyServer(5, http.StatusBadGateway, "")
+	defer ts.Close()
+
+	c, err := NewClient(ts.URL, testRetryPolicy())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = c.GetZoneByID("1")
+	if apiErr, ok := err.(*APIError); !ok || apiErr.StatusCode != http.StatusBadGateway {
+		t.Errorf("TestRetryGivesUpAfterMaxAttempts expected the last 502 APIError, got %v", err)
+	}
+	if n := atomic.LoadInt32(calls); n != 3 {
+		t.Errorf("TestRetryGivesUpAfterMaxAttempts expected 3 attempts, got %d", n)
+	}
+}
+
+func TestRetrySkipsPostUnlessEnabled(t *testing.T) {
+	ts, calls := newFlakyServer(1, http.StatusTooManyRequests, "0")
+	defer ts.Close()
+
+	c, err := NewClient(ts.URL, testRetryPolicy())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.CreateZone(ZoneRequest{}); err == nil {
+		t.Errorf("TestRetrySkipsPostUnlessEnabled POST should not be retried by default")
+	}
+	if n := atomic.LoadInt32(calls); n != 1 {
+		t.Errorf("TestRetrySkipsPostUnlessEnabled expected 1 attempt, got %d", n)
+	}
+
+	atomic.StoreInt32(calls, 0)
+	c, err = NewClient(ts.URL, testRetryPolicy(rest.POST))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.CreateZone(ZoneRequest{}); err != nil {
+		t.Errorf("TestRetrySkipsPostUnlessEnabled POST should be retried when enabled: %v", err)
+	}
+	if n := atomic.LoadInt32(calls); n != 2 {
+		t.Errorf("TestRetrySkipsPostUnlessEnabled expected 2 attempts, got %d", n)
+	}
+}
+
+func TestRetryAfterCappedAtMaxBackoff(t *testing.T) {
+	ts, calls := newFlakyServer(1, http.StatusServiceUnavailable, "86400")
+	defer ts.Close()
+
+	c, err := NewClient(ts.URL, testRetryPolicy())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Now()
+	if _, err := c.GetZoneByID("1"); err != nil {
+		t.Errorf("TestRetryAfterCappedAtMaxBackoff GetZoneByID should succeed on the second attempt: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("TestRetryAfterCappedAtMaxBackoff waited %v, Retry-After should be capped at MaxBackoff", elapsed)
+	}
+	if n := atomic.LoadInt32(calls); n != 2 {
+		t.Errorf("TestRetryAfterCappedAtMaxBackoff expected 2 attempts, got %d", n)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2018, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		value    string
+		expected time.Duration
+		ok       bool
+	}{
+		{"", 0, false},
+		{"120", 2 * time.Minute, true},
+		{"-1", 0, false},
+		{"Fri, 01 Jun 2018 12:00:30 GMT", 30 * time.Second, true},
+		{"Fri, 01 Jun 2018 11:00:00 GMT", 0, true},
+		{"soon", 0, false},
+	}
+
+	for _, tc := range testCases {
+		wait, ok := parseRetryAfter(tc.value, now)
+		if wait != tc.expected || ok != tc.ok {
+			t.Errorf("parseRetryAfter(%q) = %v, %v; expected %v, %v", tc.value, wait, ok, tc.expected, tc.ok)
+		}
+	}
+}
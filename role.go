@@ -0,0 +1,64 @@
+// (C) Copyright 2018 Hewlett Packard Enterprise Development LP.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.  IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package onesphere
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/HewlettPackard/hpe-onesphere-go/rest"
+)
+
+type Role struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	DisplayName string `json:"displayName"`
+	URI         string `json:"uri"`
+}
+
+type RoleList struct {
+	Total   int    `json:"total"`
+	Start   int    `json:"start"`
+	Count   int    `json:"count"`
+	Members []Role `json:"members"`
+}
+
+// GetRoles returns the RoleList of roles that can be assigned to users
+func (c *Client) GetRoles() (RoleList, error) {
+	return c.GetRolesCtx(context.Background())
+}
+
+// GetRolesCtx is like GetRoles but uses ctx for the request
+func (c *Client) GetRolesCtx(ctx context.Context) (RoleList, error) {
+	var roles RoleList
+
+	response, err := c.RestAPICallCtx(ctx, rest.GET, "/rest/roles", nil, nil)
+
+	if err != nil {
+		return roles, err
+	}
+
+	if err := json.Unmarshal([]byte(response), &roles); err != nil {
+		return roles, apiResponseError(response, err)
+	}
+
+	return roles, err
+}
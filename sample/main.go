@@ -29,9 +29,10 @@ import (
 )
 
 type onesphereConfig struct {
-	HostURL  string
-	User     string
-	Password string
+	HostURL         string
+	User            string
+	Password        string
+	CredentialsFile string
 }
 
 func setConfig(configPtr *string, flagName string, defaultVal string, help string) {
@@ -46,14 +47,26 @@ func main() {
 
 	config := &onesphereConfig{}
 	setConfig(&config.HostURL, "host", "https://onesphere-host-url", "Specify the OneSphere host URL to connect to.")
-	setConfig(&config.User, "user", "username", "Specify the OneSphere username to authenticate as.")
-	setConfig(&config.Password, "password", "password", "Specify the OneSphere password to authenticate with.")
+	setConfig(&config.User, "user", "", "Specify the OneSphere username to authenticate as.")
+	setConfig(&config.Password, "password", "", "Specify the OneSphere password to authenticate with.")
+	setConfig(&config.CredentialsFile, "credentials-file", "", "Specify a JSON file holding the OneSphere userName and password.")
 	flag.Parse()
 
-	osClient, err := onesphere.Connect(config.HostURL, config.User, config.Password)
+	// prefer a credentials file, then the flags, then ONESPHERE_USER/ONESPHERE_PASSWORD/ONESPHERE_TOKEN
+	var credentials onesphere.CredentialProvider
+	switch {
+	case config.CredentialsFile != "":
+		credentials = onesphere.FileCredentials(config.CredentialsFile)
+	case config.User != "":
+		credentials = onesphere.StaticCredentials(config.User, config.Password)
+	default:
+		credentials = onesphere.EnvCredentials()
+	}
+
+	osClient, err := onesphere.ConnectWithCredentials(config.HostURL, credentials)
 	if err != nil {
 		fmt.Println("onesphere.Connect failed.")
-		fmt.Printf("onesphere.Connect config: %+v\n", config)
+		fmt.Printf("onesphere.Connect host: %s\n", config.HostURL)
 		fmt.Printf("onesphere.Connect error: %v\n", err)
 		return
 	}
@@ -74,5 +87,7 @@ func main() {
 		fmt.Println("")
 	}
 
-	osClient.Disconnect()
+	if err := osClient.Disconnect(); err != nil {
+		fmt.Println(err)
+	}
 }
@@ -20,22 +20,196 @@
 
 package onesphere
 
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/HewlettPackard/hpe-onesphere-go/rest"
+)
+
 type ServerExternalId struct {
 	Type  string `json:"type"`
 	Value string `json:"value"`
 }
 type Server struct {
-	ApplianceUri   string `json:"applianceUri"`
-	CoreCount      int    `json:"coreCount"`
-	CoreSpeedMHz   int    `json:"coreSpeedMHz"`
-	CpuModel       string `json:"cpuModel"`
-	ExternalIds    []*ServerExternalId
-	MemoryGB       int    `json:"memoryGB"`
-	Name           string `json:"name"`
-	ProcessorCount int    `json:"processorCount"`
-	ProjectUri     string `json:"projectUri"`
-	RegionUri      string `json:"regionUri"`
-	ServerModel    string `json:"serverModel"`
-	State          string `json:"state"` // "Enabling|Enabled|Disabling|Disabled"
-	ZoneUri        string `json:"zoneUri"`
+	ID             string              `json:"id,omitempty"`
+	URI            string              `json:"uri,omitempty"`
+	ApplianceUri   string              `json:"applianceUri"`
+	CoreCount      int                 `json:"coreCount"`
+	CoreSpeedMHz   int                 `json:"coreSpeedMHz"`
+	CpuModel       string              `json:"cpuModel"`
+	ExternalIds    []*ServerExternalId `json:"externalIds"`
+	MemoryGB       int                 `json:"memoryGB"`
+	Name           string              `json:"name"`
+	ProcessorCount int                 `json:"processorCount"`
+	ProjectUri     string              `json:"projectUri"`
+	RegionUri      string              `json:"regionUri"`
+	ServerModel    string              `json:"serverModel"`
+	State          string              `json:"state"` // "Enabling|Enabled|Disabling|Disabled"
+	ZoneUri        string              `json:"zoneUri"`
+}
+
+type ServerList struct {
+	Total   int      `json:"total"`
+	Start   int      `json:"start"`
+	Count   int      `json:"count"`
+	Members []Server `json:"members"`
+}
+
+// GetServers returns ServerList filtered by the non-empty uris
+func (c *Client) GetServers(regionUri, applianceUri, zoneUri string) (ServerList, error) {
+	return c.GetServersCtx(context.Background(), regionUri, applianceUri, zoneUri)
+}
+
+// GetServersCtx is like GetServers but uses ctx for the request
+func (c *Client) GetServersCtx(ctx context.Context, regionUri, applianceUri, zoneUri string) (ServerList, error) {
+	return c.ListServersCtx(ctx, ServerListOptions{
+		RegionURI:    regionUri,
+		ApplianceURI: applianceUri,
+		ZoneURI:      zoneUri,
+	})
+}
+
+// ListServers returns the ServerList selected by opts
+func (c *Client) ListServers(opts ServerListOptions) (ServerList, error) {
+	return c.ListServersCtx(context.Background(), opts)
+}
+
+// ListServersCtx is like ListServers but uses ctx for the request
+func (c *Client) ListServersCtx(ctx context.Context, opts ServerListOptions) (ServerList, error) {
+	var (
+		uri         = "/rest/servers"
+		queryParams = encodeParams(opts)
+		servers     ServerList
+	)
+
+	response, err := c.RestAPICallCtx(ctx, rest.GET, uri, queryParams, nil)
+
+	if err != nil {
+		return servers, err
+	}
+
+	if err := json.Unmarshal([]byte(response), &servers); err != nil {
+		return servers, apiResponseError(response, err)
+	}
+
+	return servers, err
+}
+
+// CreateServer registers server and returns the created Server
+func (c *Client) CreateServer(server *Server) (Server, error) {
+	return c.CreateServerCtx(context.Background(), server)
+}
+
+// CreateServerCtx is like CreateServer but uses ctx for the request
+func (c *Client) CreateServerCtx(ctx context.Context, server *Server) (Server, error) {
+	var (
+		uri    = "/rest/servers"
+		values = map[string]*Server{
+			"server": server,
+		}
+		created Server
+	)
+
+	response, err := c.RestAPICallCtx(ctx, rest.POST, uri, nil, values)
+
+	if err != nil {
+		return created, err
+	}
+
+	if err := json.Unmarshal([]byte(response), &created); err != nil {
+		return created, apiResponseError(response, err)
+	}
+
+	return created, err
+}
+
+// DeleteServer deletes a Server by id, force deletes it even when it is in use
+func (c *Client) DeleteServer(serverID string, force bool) error {
+	return c.DeleteServerCtx(context.Background(), serverID, force)
+}
+
+// DeleteServerCtx is like DeleteServer but uses ctx for the request
+func (c *Client) DeleteServerCtx(ctx context.Context, serverID string, force bool) error {
+	if serverID == "" {
+		return fmt.Errorf("serverID must not be empty")
+	}
+
+	queryParams := map[string]string{}
+	if force {
+		queryParams["force"] = "true"
+	}
+
+	_, err := c.RestAPICallCtx(ctx, rest.DELETE, "/rest/servers/"+serverID, queryParams, nil)
+
+	return err
+}
+
+// GetServer returns a Server by id
+func (c *Client) GetServer(serverID string) (Server, error) {
+	return c.GetServerCtx(context.Background(), serverID)
+}
+
+// GetServerCtx is like GetServer but uses ctx for the request
+func (c *Client) GetServerCtx(ctx context.Context, serverID string) (Server, error) {
+	var (
+		uri    = "/rest/servers/" + serverID
+		server Server
+	)
+
+	if serverID == "" {
+		return server, fmt.Errorf("serverID must not be empty")
+	}
+
+	response, err := c.RestAPICallCtx(ctx, rest.GET, uri, nil, nil)
+
+	if err != nil {
+		return server, err
+	}
+
+	if err := json.Unmarshal([]byte(response), &server); err != nil {
+		return server, apiResponseError(response, err)
+	}
+
+	return server, err
+}
+
+// UpdateServer allowed Ops in patchPayload: "replace|remove"
+func (c *Client) UpdateServer(serverID string, patchPayload []*PatchOp) (Server, error) {
+	return c.UpdateServerCtx(context.Background(), serverID, patchPayload)
+}
+
+// UpdateServerCtx is like UpdateServer but uses ctx for the request
+func (c *Client) UpdateServerCtx(ctx context.Context, serverID string, patchPayload []*PatchOp) (Server, error) {
+	var server Server
+
+	allowedOps := []string{"replace", "remove"}
+
+	for _, pb := range patchPayload {
+		opIsValid := false
+
+		for _, allowedOp := range allowedOps {
+			if pb.Op == allowedOp {
+				opIsValid = true
+			}
+		}
+
+		if !opIsValid {
+			return server, fmt.Errorf("UpdateServer received invalid Op for update.\nReceived Op: %s\nValid Ops: %v\n", pb.Op, allowedOps)
+		}
+	}
+
+	values := map[string][]*PatchOp{"body": patchPayload}
+	response, err := c.RestAPICallCtx(ctx, rest.PATCH, "/rest/servers/"+serverID, nil, values)
+
+	if err != nil {
+		return server, err
+	}
+
+	if err := json.Unmarshal([]byte(response), &server); err != nil {
+		return server, apiResponseError(response, err)
+	}
+
+	return server, err
 }
@@ -39,7 +39,7 @@ func TestGetServiceByID(t *testing.T) {
 func TestGetServiceByName(t *testing.T) {
 	setup()
 
-	_, err := client.GetServiceByName("")
+	_, err := client.GetServiceByName("service")
 	if err != nil {
 		t.Error(err)
 	}
@@ -0,0 +1,206 @@
+// (C) Copyright 2018 Hewlett Packard Enterprise Development LP.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.  IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package onesphere
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/HewlettPackard/hpe-onesphere-go/rest"
+)
+
+// Session is the session of the authenticated user, User is only set with view "full"
+type Session struct {
+	Token   string `json:"token"`
+	UserURI string `json:"userUri"`
+	User    *User  `json:"user"`
+}
+
+// SessionIdp is the identity provider of a user, Type is "local" for the
+// users managed by OneSphere and LoginURI is only set for external providers
+type SessionIdp struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	LoginURI string `json:"loginUri"`
+}
+
+// GetSession returns the current Session
+// example view: "full"
+func (c *Client) GetSession(view string) (Session, error) {
+	return c.GetSessionCtx(context.Background(), view)
+}
+
+// GetSessionCtx is like GetSession but uses ctx for the request
+func (c *Client) GetSessionCtx(ctx context.Context, view string) (Session, error) {
+	var (
+		uri         = "/rest/session"
+		queryParams = createQuery(&map[string]string{
+			"view": view,
+		})
+		session Session
+	)
+
+	response, err := c.RestAPICallCtx(ctx, rest.GET, uri, queryParams, nil)
+
+	if err != nil {
+		return session, err
+	}
+
+	if err := json.Unmarshal([]byte(response), &session); err != nil {
+		return session, apiResponseError(response, err)
+	}
+
+	return session, err
+}
+
+// GetSessionIdp returns the identity provider userName logs in with
+func (c *Client) GetSessionIdp(userName string) (SessionIdp, error) {
+	return c.GetSessionIdpCtx(context.Background(), userName)
+}
+
+// GetSessionIdpCtx is like GetSessionIdp but uses ctx for the request
+func (c *Client) GetSessionIdpCtx(ctx context.Context, userName string) (SessionIdp, error) {
+	var (
+		uri         = "/rest/session/idp"
+		queryParams = createQuery(&map[string]string{
+			"userName": userName,
+		})
+		idp SessionIdp
+	)
+
+	if userName == "" {
+		return idp, fmt.Errorf("userName must not be empty")
+	}
+
+	response, err := c.RestAPICallCtx(ctx, rest.GET, uri, queryParams, nil)
+
+	if err != nil {
+		return idp, err
+	}
+
+	if err := json.Unmarshal([]byte(response), &idp); err != nil {
+		return idp, apiResponseError(response, err)
+	}
+
+	return idp, err
+}
+
+// createSession logs in and returns the session token
+func (c *Client) createSession(ctx context.Context, user, password string) (string, error) {
+	values := map[string]string{"userName": user, "password": password}
+	jsonValue, err := json.Marshal(values)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := c.sendWithRetry(ctx, "POST", jsonHeaders, "/rest/session", nil, jsonValue, "")
+	if err != nil {
+		return "", err
+	}
+
+	if !isSuccessStatus(resp.StatusCode) {
+		return "", newAPIError("POST", "/rest/session", resp.StatusCode, resp.Body)
+	}
+
+	var dat map[string]string
+	if err := json.Unmarshal(resp.Body, &dat); err != nil {
+		return "", apiResponseError(string(resp.Body), err)
+	}
+
+	return dat["token"], nil
+}
+
+// login returns a session token for the Credentials supplied by provider.
+// Credentials holding only a token are used as they are.
+func (c *Client) login(ctx context.Context, provider CredentialProvider) (string, error) {
+	creds, err := provider.Credentials(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	if creds.UserName == "" {
+		if creds.Token == "" {
+			return "", fmt.Errorf("credentials must include a user name or a token")
+		}
+		return creds.Token, nil
+	}
+
+	if c.tokenCache != nil {
+		if token := c.cachedSession(ctx, creds.UserName); token != "" {
+			c.cachedUser = creds.UserName
+			return token, nil
+		}
+	}
+
+	token, err := c.createSession(ctx, creds.UserName, creds.Password)
+	if err != nil {
+		return "", err
+	}
+
+	if c.tokenCache != nil {
+		// the session is usable even when it cannot be cached
+		if err := c.tokenCache.Store(c.Auth.HostURL, creds.UserName, token); err == nil {
+			c.cachedUser = creds.UserName
+		}
+	}
+
+	return token, nil
+}
+
+// token returns the current session token, safe for concurrent use
+func (c *Client) token() string {
+	c.authMu.RLock()
+	defer c.authMu.RUnlock()
+	return c.Auth.Token
+}
+
+// reauthenticate replaces failedToken with a new session token.
+// Concurrent callers holding the same expired token share a single login.
+// failedToken is returned when the credentials only hold that same token.
+func (c *Client) reauthenticate(ctx context.Context, failedToken string) (string, error) {
+	c.authMu.Lock()
+	defer c.authMu.Unlock()
+
+	if c.Auth.Token != failedToken {
+		return c.Auth.Token, nil
+	}
+
+	// the cached token is the one that just expired
+	if c.tokenCache != nil && c.cachedUser != "" {
+		c.tokenCache.Delete(c.Auth.HostURL, c.cachedUser)
+	}
+
+	token, err := c.login(ctx, c.credentials)
+	if err != nil {
+		return "", err
+	}
+
+	c.Auth.Token = token
+	return token, nil
+}
+
+// canReauthenticate reports whether the Client holds credentials to log in again
+func (c *Client) canReauthenticate() bool {
+	c.authMu.RLock()
+	defer c.authMu.RUnlock()
+	return c.credentials != nil
+}
@@ -0,0 +1,133 @@
+package onesphere
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// newExpiringSessionServer issues tokens "token-1", "token-2", ... and
+// rejects every token but the latest with 401 Unauthorized
+func newExpiringSessionServer() (*httptest.Server, *int32, func()) {
+	var logins int32
+	var current atomic.Value
+	current.Store("")
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/rest/session" && r.Method == "POST" {
+			n := atomic.AddInt32(&logins, 1)
+			token := "token-" + strconv.Itoa(int(n))
+			current.Store(token)
+			w.Write([]byte(`{"token":"` + token + `"}`))
+			return
+		}
+		if r.Header.Get("Authorization") != current.Load().(string) {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"message":"Session expired"}`))
+			return
+		}
+		w.Write([]byte(`{"id":"1"}`))
+	}))
+
+	expire := func() { current.Store("expired") }
+	return ts, &logins, expire
+}
+
+func TestReauthenticateOnUnauthorized(t *testing.T) {
+	ts, logins, expire := newExpiringSessionServer()
+	defer ts.Close()
+
+	c, err := Connect(ts.URL, "user", "password")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expire()
+
+	if _, err := c.GetZoneByID("1"); err != nil {
+		t.Errorf("TestReauthenticateOnUnauthorized GetZoneByID should be replayed after login: %v", err)
+	}
+	if n := atomic.LoadInt32(logins); n != 2 {
+		t.Errorf("TestReauthenticateOnUnauthorized expected 2 logins, got %d", n)
+	}
+	if c.Auth.Token != "token-2" {
+		t.Errorf("TestReauthenticateOnUnauthorized expected the new token, got %q", c.Auth.Token)
+	}
+}
+
+func TestReauthenticateOnceForConcurrentCalls(t *testing.T) {
+	ts, logins, expire := newExpiringSessionServer()
+	defer ts.Close()
+
+	c, err := Connect(ts.URL, "user", "password")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expire()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := c.GetZoneByID("1"); err != nil {
+				t.Errorf("TestReauthenticateOnceForConcurrentCalls GetZoneByID failed: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if n := atomic.LoadInt32(logins); n != 2 {
+		t.Errorf("TestReauthenticateOnceForConcurrentCalls expected 2 logins, got %d", n)
+	}
+}
+
+func TestNoReauthenticateWithoutCredentials(t *testing.T) {
+	ts, logins, _ := newExpiringSessionServer()
+	defer ts.Close()
+
+	c := &Client{Auth: &Auth{HostURL: ts.URL, Token: "stale"}}
+
+	if _, err := c.GetZoneByID("1"); !IsUnauthorized(err) {
+		t.Errorf("TestNoReauthenticateWithoutCredentials expected an unauthorized APIError, got %v", err)
+	}
+	if n := atomic.LoadInt32(logins); n != 0 {
+		t.Errorf("TestNoReauthenticateWithoutCredentials expected no login, got %d", n)
+	}
+}
+
+func TestGetSessionIdp(t *testing.T) {
+	setup()
+
+	idp, err := client.GetSessionIdp(config.User)
+	if err != nil {
+		t.Error(err)
+	}
+	if idp.Type == "" {
+		t.Errorf("TestGetSessionIdp Failed to get the identity provider: Type is ''")
+	}
+
+	if _, err := client.GetSessionIdp(""); err == nil {
+		t.Errorf("TestGetSessionIdp expected an error for an empty userName")
+	}
+}
+
+func TestDisconnectReturnsError(t *testing.T) {
+	ts := newSessionServer(false, nil)
+	defer ts.Close()
+
+	c, err := Connect(ts.URL, "user", "password")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts.Close()
+
+	if err := c.Disconnect(); err == nil {
+		t.Errorf("TestDisconnectReturnsError expected the logout failure to be returned")
+	}
+	if c.Auth.Token != "" {
+		t.Errorf("TestDisconnectReturnsError the session should be forgotten, token is %q", c.Auth.Token)
+	}
+}
@@ -0,0 +1,83 @@
+// (C) Copyright 2018 Hewlett Packard Enterprise Development LP.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.  IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package onesphere
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/HewlettPackard/hpe-onesphere-go/rest"
+)
+
+// Status reports the health of the OneSphere service, e.g. {"service":"OK","database":""}
+type Status struct {
+	Service  string `json:"service"`
+	Database string `json:"database"`
+}
+
+// Versions lists the API versions supported by the server, e.g. ["v1"]
+type Versions struct {
+	Versions []string `json:"versions"`
+}
+
+// GetStatus calls the /rest/status endpoint
+func (c *Client) GetStatus() (Status, error) {
+	return c.GetStatusCtx(context.Background())
+}
+
+// GetStatusCtx is like GetStatus but uses ctx for the request
+func (c *Client) GetStatusCtx(ctx context.Context) (Status, error) {
+	var status Status
+
+	response, err := c.RestAPICallCtx(ctx, rest.GET, "/rest/status", nil, nil)
+
+	if err != nil {
+		return status, err
+	}
+
+	if err := json.Unmarshal([]byte(response), &status); err != nil {
+		return status, apiResponseError(response, err)
+	}
+
+	return status, err
+}
+
+// GetVersions calls the /rest/about/versions endpoint
+func (c *Client) GetVersions() (Versions, error) {
+	return c.GetVersionsCtx(context.Background())
+}
+
+// GetVersionsCtx is like GetVersions but uses ctx for the request
+func (c *Client) GetVersionsCtx(ctx context.Context) (Versions, error) {
+	var versions Versions
+
+	response, err := c.RestAPICallCtx(ctx, rest.GET, "/rest/about/versions", nil, nil)
+
+	if err != nil {
+		return versions, err
+	}
+
+	if err := json.Unmarshal([]byte(response), &versions); err != nil {
+		return versions, apiResponseError(response, err)
+	}
+
+	return versions, err
+}
@@ -124,10 +124,10 @@ func (c *Client) DeleteTag(tagId string) error {
 
 	var uri = "/rest/tags/" + tagId
 
-	response, err := c.RestAPICall(rest.DELETE, uri, nil, nil)
+	_, err := c.RestAPICall(rest.DELETE, uri, nil, nil)
 
 	if err != nil {
-		return apiResponseError(response, err)
+		return err
 	}
 
 	return nil
@@ -122,10 +122,10 @@ func (c *Client) DeleteTagKey(tagKeyId string) error {
 
 	var uri = "/rest/tag-keys/" + tagKeyId
 
-	response, err := c.RestAPICall(rest.DELETE, uri, nil, nil)
+	_, err := c.RestAPICall(rest.DELETE, uri, nil, nil)
 
 	if err != nil {
-		return apiResponseError(response, err)
+		return err
 	}
 
 	return nil
@@ -0,0 +1,175 @@
+// (C) Copyright 2018 Hewlett Packard Enterprise Development LP.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.  IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package onesphere
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// TokenCache stores session tokens between runs, keyed by host URL and user name
+type TokenCache interface {
+	// Load returns the cached token, or "" when there is none
+	Load(hostURL, user string) (string, error)
+	Store(hostURL, user, token string) error
+	Delete(hostURL, user string) error
+}
+
+// WithTokenCache makes Connect reuse a token from cache while the server still
+// accepts it, and store the token of every new session in it.
+// Disconnect ends the session and removes it from cache, so tools meant to
+// reuse the session on their next run should call Close instead.
+func WithTokenCache(cache TokenCache) Option {
+	return func(o *clientOptions) error {
+		o.tokenCache = cache
+		return nil
+	}
+}
+
+// DefaultTokenCache returns a FileTokenCache in the user configuration directory,
+// e.g. ~/.config/hpe-onesphere-go/tokens.json on Linux
+func DefaultTokenCache() (TokenCache, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return nil, err
+	}
+	return FileTokenCache(filepath.Join(dir, "hpe-onesphere-go", "tokens.json")), nil
+}
+
+// FileTokenCache stores tokens in the JSON file at path, created with mode 0600.
+// A cache file accessible by group or others is rejected like FileCredentials.
+func FileTokenCache(path string) TokenCache {
+	return &fileTokenCache{path: path}
+}
+
+type fileTokenCache struct {
+	mu   sync.Mutex
+	path string
+}
+
+// tokenCacheKey ignores a trailing slash so both forms of a host URL share a token
+func tokenCacheKey(hostURL, user string) string {
+	return strings.TrimRight(hostURL, "/") + " " + user
+}
+
+func (f *fileTokenCache) Load(hostURL, user string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	tokens, err := f.read()
+	if err != nil {
+		return "", err
+	}
+	return tokens[tokenCacheKey(hostURL, user)], nil
+}
+
+func (f *fileTokenCache) Store(hostURL, user, token string) error {
+	return f.update(func(tokens map[string]string) {
+		tokens[tokenCacheKey(hostURL, user)] = token
+	})
+}
+
+func (f *fileTokenCache) Delete(hostURL, user string) error {
+	return f.update(func(tokens map[string]string) {
+		delete(tokens, tokenCacheKey(hostURL, user))
+	})
+}
+
+func (f *fileTokenCache) update(change func(map[string]string)) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	tokens, err := f.read()
+	if err != nil {
+		return err
+	}
+	change(tokens)
+	return f.write(tokens)
+}
+
+func (f *fileTokenCache) read() (map[string]string, error) {
+	tokens := map[string]string{}
+
+	if err := checkPrivateFile(f.path); err != nil {
+		if os.IsNotExist(err) {
+			return tokens, nil
+		}
+		return nil, err
+	}
+
+	data, err := ioutil.ReadFile(f.path)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &tokens); err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
+
+// write replaces the cache file through a rename so that concurrent runs
+// never read a partially written file
+func (f *fileTokenCache) write(tokens map[string]string) error {
+	data, err := json.MarshalIndent(tokens, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(f.path)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+
+	tmp, err := ioutil.TempFile(dir, ".tokens-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	// TempFile creates the file with mode 0600
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), f.path)
+}
+
+// cachedSession returns the token cached for user if the server still accepts it
+func (c *Client) cachedSession(ctx context.Context, user string) string {
+	token, err := c.tokenCache.Load(c.Auth.HostURL, user)
+	if err != nil || token == "" {
+		return ""
+	}
+
+	resp, err := c.sendWithRetry(ctx, "GET", jsonHeaders, "/rest/session", nil, nil, token)
+	if err != nil || !isSuccessStatus(resp.StatusCode) {
+		return ""
+	}
+	return token
+}
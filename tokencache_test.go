@@ -0,0 +1,73 @@
+package onesphere
+
+import (
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+)
+
+func TestTokenCacheReusesValidToken(t *testing.T) {
+	ts, logins, _ := newExpiringSessionServer()
+	defer ts.Close()
+
+	path := filepath.Join(t.TempDir(), "onesphere", "tokens.json")
+	cache := FileTokenCache(path)
+
+	first, err := Connect(ts.URL, "user", "password", WithTokenCache(cache))
+	if err != nil {
+		t.Fatal(err)
+	}
+	first.Close()
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("TestTokenCacheReusesValidToken token was not cached: %v", err)
+	}
+	if perm := info.Mode().Perm(); perm != 0600 {
+		t.Errorf("TestTokenCacheReusesValidToken expected mode 0600, got %04o", perm)
+	}
+
+	second, err := Connect(ts.URL+"/", "user", "password", WithTokenCache(cache))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := atomic.LoadInt32(logins); n != 1 {
+		t.Errorf("TestTokenCacheReusesValidToken expected 1 login, got %d", n)
+	}
+	if second.Auth.Token != first.Auth.Token {
+		t.Errorf("TestTokenCacheReusesValidToken expected %q, got %q", first.Auth.Token, second.Auth.Token)
+	}
+
+	if token, _ := cache.Load(ts.URL, "other"); token != "" {
+		t.Errorf("TestTokenCacheReusesValidToken token of user should not be used for other, got %q", token)
+	}
+}
+
+func TestTokenCacheFallsBackToLogin(t *testing.T) {
+	ts, logins, expire := newExpiringSessionServer()
+	defer ts.Close()
+
+	cache := FileTokenCache(filepath.Join(t.TempDir(), "tokens.json"))
+
+	if _, err := Connect(ts.URL, "user", "password", WithTokenCache(cache)); err != nil {
+		t.Fatal(err)
+	}
+	expire()
+
+	c, err := Connect(ts.URL, "user", "password", WithTokenCache(cache))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := atomic.LoadInt32(logins); n != 2 {
+		t.Errorf("TestTokenCacheFallsBackToLogin expected 2 logins, got %d", n)
+	}
+	if token, _ := cache.Load(ts.URL, "user"); token != c.Auth.Token || token != "token-2" {
+		t.Errorf("TestTokenCacheFallsBackToLogin expected the new token to be cached, got %q", token)
+	}
+
+	c.Disconnect()
+	if token, _ := cache.Load(ts.URL, "user"); token != "" {
+		t.Errorf("TestTokenCacheFallsBackToLogin Disconnect should remove the cached token, got %q", token)
+	}
+}
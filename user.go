@@ -21,6 +21,7 @@
 package onesphere
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/HewlettPackard/hpe-onesphere-go/rest"
@@ -53,15 +54,23 @@ type UserList struct {
 // leave userQuery blank to get all users
 // example userQuery: "jon"
 func (c *Client) GetUsers(userQuery string) (UserList, error) {
+	return c.ListUsers(UserListOptions{UserQuery: userQuery})
+}
+
+// ListUsers returns the UserList selected by opts
+func (c *Client) ListUsers(opts UserListOptions) (UserList, error) {
+	return c.ListUsersCtx(context.Background(), opts)
+}
+
+// ListUsersCtx is like ListUsers but uses ctx for the request
+func (c *Client) ListUsersCtx(ctx context.Context, opts UserListOptions) (UserList, error) {
 	var (
 		uri         = "/rest/users"
-		queryParams = createQuery(&map[string]string{
-			"userQuery": userQuery,
-		})
-		users UserList
+		queryParams = encodeParams(opts)
+		users       UserList
 	)
 
-	response, err := c.RestAPICall(rest.GET, uri, queryParams, nil)
+	response, err := c.RestAPICallCtx(ctx, rest.GET, uri, queryParams, nil)
 
 	if err != nil {
 		return users, err
@@ -74,6 +83,30 @@ func (c *Client) GetUsers(userQuery string) (UserList, error) {
 	return users, err
 }
 
+// ForEachUser calls fn for every User selected by opts across all pages,
+// see ForEachDeployment for paging and how fn stops the iteration
+func (c *Client) ForEachUser(opts UserListOptions, fn func(User) error) error {
+	return c.ForEachUserCtx(context.Background(), opts, fn)
+}
+
+// ForEachUserCtx is like ForEachUser but uses ctx for the requests
+func (c *Client) ForEachUserCtx(ctx context.Context, opts UserListOptions, fn func(User) error) error {
+	return c.forEachPage(ctx, "/rest/users", encodeParams(opts), func(response string) (page, error) {
+		var users UserList
+		if err := json.Unmarshal([]byte(response), &users); err != nil {
+			return page{}, apiResponseError(response, err)
+		}
+
+		for _, user := range users.Members {
+			if err := fn(user); err != nil {
+				return page{}, err
+			}
+		}
+
+		return page{total: users.Total, members: len(users.Members)}, nil
+	})
+}
+
 // GetUserByID returns a User by id
 func (c *Client) GetUserByID(id string) (User, error) {
 	var (
@@ -101,26 +134,37 @@ func (c *Client) GetUserByID(id string) (User, error) {
 	return user, err
 }
 
-// GetUserByID returns a User by name
+// GetUserByName returns the User named name
 func (c *Client) GetUserByName(name string) (User, error) {
-	var user User
+	return c.GetUserByNameCtx(context.Background(), name)
+}
+
+// GetUserByNameCtx is like GetUserByName but uses ctx for the requests
+func (c *Client) GetUserByNameCtx(ctx context.Context, name string) (User, error) {
+	var (
+		user    User
+		matches = newNameMatches("user", name)
+	)
 
 	if name == "" {
 		return user, fmt.Errorf("name must not be empty")
 	}
 
-	users, err := c.GetUsers(name)
-
-	if len(users.Members) > 0 {
-		for i := 0; i < len(users.Members); i++ {
-			if users.Members[i].Name == name {
-				user = users.Members[i]
-				return user, err
-			}
+	err := c.ForEachUserCtx(ctx, UserListOptions{UserQuery: name}, func(candidate User) error {
+		if matches.add(candidate.Name, candidate.URI) {
+			user = candidate
 		}
+		return nil
+	})
+	if err != nil {
+		return User{}, err
 	}
 
-	return user, err
+	if err := matches.err(); err != nil {
+		return User{}, err
+	}
+
+	return user, nil
 }
 
 // CreateUser Creates User and returns updated User
@@ -175,10 +219,10 @@ func (c *Client) DeleteUser(userId string) error {
 
 	var uri = "/rest/users/" + userId
 
-	response, err := c.RestAPICall(rest.DELETE, uri, nil, nil)
+	_, err := c.RestAPICall(rest.DELETE, uri, nil, nil)
 
 	if err != nil {
-		return apiResponseError(response, err)
+		return err
 	}
 
 	return nil
@@ -37,10 +37,10 @@ func TestGetUserByID(t *testing.T) {
 func TestGetUserByName(t *testing.T) {
 	setup()
 
-	_, err := client.GetUserByName("2")
-	if err != nil {
-		t.Error(err)
-	}
+	checkByName(t, "TestGetUserByName", "user", func(name string) (string, error) {
+		user, err := client.GetUserByName(name)
+		return user.Name, err
+	}, "/rest/users", User{ID: "3", Name: "user"})
 }
 
 func TestCreateUser(t *testing.T) {
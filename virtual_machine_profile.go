@@ -22,6 +22,7 @@ package onesphere
 
 import (
 	"encoding/json"
+	"github.com/HewlettPackard/hpe-onesphere-go/query"
 	"github.com/HewlettPackard/hpe-onesphere-go/rest"
 	"time"
 )
@@ -83,19 +84,19 @@ func (c *Client) GetVirtualMachineProfiles(query string) (VirtualMachineProfileL
 // GetVirtualMachineProfilesByServiceURI returns VirtualMachineProfileList by serviceUri
 // example: client.GetVirtualMachineProfilesByServiceURI("/rest/services/2F8bbc7abe-2ae1-a366-a4dd-f065618063a6")
 func (c *Client) GetVirtualMachineProfilesByServiceURI(serviceURI string) (VirtualMachineProfileList, error) {
-	return c.GetVirtualMachineProfiles("serviceUri EQ "+serviceURI)
+	return c.GetVirtualMachineProfiles(query.Eq("serviceUri", serviceURI).String())
 }
 
 // GetVirtualMachineProfilesByZoneURI returns VirtualMachineProfileList by zoneUri
 // example: client.GetVirtualMachineProfilesByZoneURI("/rest/zones/b1d0b94b-b3e2-459f-95ed-a1b4d4645338")
 func (c *Client) GetVirtualMachineProfilesByZoneURI(zoneURI string) (VirtualMachineProfileList, error) {
-	return c.GetVirtualMachineProfiles("zoneUri EQ "+zoneURI)
+	return c.GetVirtualMachineProfiles(query.Eq("zoneUri", zoneURI).String())
 }
 
 // GetVirtualMachineProfilesByServiceAndZoneURI returns VirtualMachineProfileList by zoneUri
 // example: client.GetVirtualMachineProfilesByServiceAndZoneURI("/rest/services/2F8bbc7abe-2ae1-a366-a4dd-f065618063a6", "/rest/zones/b1d0b94b-b3e2-459f-95ed-a1b4d4645338")
 func (c *Client) GetVirtualMachineProfilesByServiceAndZoneURI(serviceURI, zoneURI string) (VirtualMachineProfileList, error) {
-	return c.GetVirtualMachineProfiles("serviceUri EQ " + serviceURI + " AND zoneUri EQ " + zoneURI)
+	return c.GetVirtualMachineProfiles(query.Eq("serviceUri", serviceURI).And(query.Eq("zoneUri", zoneURI)).String())
 }
 
 // GetVirtualMachineProfileByID returns a VirtualMachineProfile by ID
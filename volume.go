@@ -0,0 +1,200 @@
+// (C) Copyright 2018 Hewlett Packard Enterprise Development LP.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.  IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package onesphere
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/HewlettPackard/hpe-onesphere-go/rest"
+)
+
+type Volume struct {
+	ID         string    `json:"id"`
+	Name       string    `json:"name"`
+	URI        string    `json:"uri"`
+	SizeGiB    int       `json:"sizeGiB"`
+	ZoneURI    string    `json:"zoneUri"`
+	ProjectURI string    `json:"projectUri"`
+	Status     string    `json:"status"`
+	State      string    `json:"state"`
+	Created    time.Time `json:"created"`
+	Modified   time.Time `json:"modified"`
+}
+
+type VolumeList struct {
+	Total   int      `json:"total"`
+	Start   int      `json:"start"`
+	Count   int      `json:"count"`
+	Members []Volume `json:"members"`
+}
+
+// GetVolumes returns VolumeList with optional query and view
+// example view: "full"
+func (c *Client) GetVolumes(query, view string) (VolumeList, error) {
+	return c.GetVolumesCtx(context.Background(), query, view)
+}
+
+// GetVolumesCtx is like GetVolumes but uses ctx for the request
+func (c *Client) GetVolumesCtx(ctx context.Context, query, view string) (VolumeList, error) {
+	return c.ListVolumesCtx(ctx, VolumeListOptions{
+		Query:       query,
+		ListOptions: ListOptions{View: View(view)},
+	})
+}
+
+// ListVolumes returns the VolumeList selected by opts
+func (c *Client) ListVolumes(opts VolumeListOptions) (VolumeList, error) {
+	return c.ListVolumesCtx(context.Background(), opts)
+}
+
+// ListVolumesCtx is like ListVolumes but uses ctx for the request
+func (c *Client) ListVolumesCtx(ctx context.Context, opts VolumeListOptions) (VolumeList, error) {
+	var (
+		uri         = "/rest/volumes"
+		queryParams = encodeParams(opts)
+		volumes     VolumeList
+	)
+
+	response, err := c.RestAPICallCtx(ctx, rest.GET, uri, queryParams, nil)
+
+	if err != nil {
+		return volumes, err
+	}
+
+	if err := json.Unmarshal([]byte(response), &volumes); err != nil {
+		return volumes, apiResponseError(response, err)
+	}
+
+	return volumes, err
+}
+
+// CreateVolume creates a Volume of sizeGiB in zoneUri for projectUri
+func (c *Client) CreateVolume(name string, sizeGiB int, zoneUri, projectUri string) (Volume, error) {
+	return c.CreateVolumeCtx(context.Background(), name, sizeGiB, zoneUri, projectUri)
+}
+
+// CreateVolumeCtx is like CreateVolume but uses ctx for the request
+func (c *Client) CreateVolumeCtx(ctx context.Context, name string, sizeGiB int, zoneUri, projectUri string) (Volume, error) {
+	var (
+		uri    = "/rest/volumes"
+		values = map[string]interface{}{
+			"name":       name,
+			"sizeGiB":    strconv.Itoa(sizeGiB),
+			"zoneUri":    zoneUri,
+			"projectUri": projectUri,
+		}
+		volume Volume
+	)
+
+	response, err := c.RestAPICallCtx(ctx, rest.POST, uri, nil, values)
+
+	if err != nil {
+		return volume, err
+	}
+
+	if err := json.Unmarshal([]byte(response), &volume); err != nil {
+		return volume, apiResponseError(response, err)
+	}
+
+	return volume, err
+}
+
+// GetVolume returns a Volume by id
+func (c *Client) GetVolume(volumeID string) (Volume, error) {
+	return c.GetVolumeCtx(context.Background(), volumeID)
+}
+
+// GetVolumeCtx is like GetVolume but uses ctx for the request
+func (c *Client) GetVolumeCtx(ctx context.Context, volumeID string) (Volume, error) {
+	var (
+		uri    = "/rest/volumes/" + volumeID
+		volume Volume
+	)
+
+	if volumeID == "" {
+		return volume, fmt.Errorf("volumeID must not be empty")
+	}
+
+	response, err := c.RestAPICallCtx(ctx, rest.GET, uri, nil, nil)
+
+	if err != nil {
+		return volume, err
+	}
+
+	if err := json.Unmarshal([]byte(response), &volume); err != nil {
+		return volume, apiResponseError(response, err)
+	}
+
+	return volume, err
+}
+
+// UpdateVolume renames and resizes a Volume
+func (c *Client) UpdateVolume(volumeID, name string, sizeGiB int) (Volume, error) {
+	return c.UpdateVolumeCtx(context.Background(), volumeID, name, sizeGiB)
+}
+
+// UpdateVolumeCtx is like UpdateVolume but uses ctx for the request
+func (c *Client) UpdateVolumeCtx(ctx context.Context, volumeID, name string, sizeGiB int) (Volume, error) {
+	var (
+		uri    = "/rest/volumes/" + volumeID
+		values = map[string]interface{}{
+			"name":    name,
+			"sizeGiB": strconv.Itoa(sizeGiB),
+		}
+		volume Volume
+	)
+
+	if volumeID == "" {
+		return volume, fmt.Errorf("volumeID must not be empty")
+	}
+
+	response, err := c.RestAPICallCtx(ctx, rest.PUT, uri, nil, values)
+
+	if err != nil {
+		return volume, err
+	}
+
+	if err := json.Unmarshal([]byte(response), &volume); err != nil {
+		return volume, apiResponseError(response, err)
+	}
+
+	return volume, err
+}
+
+// DeleteVolume deletes a Volume by id
+func (c *Client) DeleteVolume(volumeID string) error {
+	return c.DeleteVolumeCtx(context.Background(), volumeID)
+}
+
+// DeleteVolumeCtx is like DeleteVolume but uses ctx for the request
+func (c *Client) DeleteVolumeCtx(ctx context.Context, volumeID string) error {
+	if volumeID == "" {
+		return fmt.Errorf("volumeID must not be empty")
+	}
+
+	_, err := c.RestAPICallCtx(ctx, rest.DELETE, "/rest/volumes/"+volumeID, nil, nil)
+
+	return err
+}
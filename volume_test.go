@@ -0,0 +1,33 @@
+package onesphere
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetVolumeDecodesVolume(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/volumes/vol-1" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"id":"vol-1","name":"data","uri":"/rest/volumes/vol-1","sizeGiB":20,
+			"zoneUri":"/rest/zones/z1","projectUri":"/rest/projects/p1","created":"2018-05-01T10:00:00Z"}`))
+	}))
+	defer ts.Close()
+
+	c := &Client{Auth: &Auth{HostURL: ts.URL, Token: "token"}}
+
+	volume, err := c.GetVolume("vol-1")
+	if err != nil {
+		t.Fatalf("TestGetVolumeDecodesVolume unexpected error: %v", err)
+	}
+	if volume.SizeGiB != 20 || volume.ZoneURI != "/rest/zones/z1" || volume.Created.Year() != 2018 {
+		t.Errorf("TestGetVolumeDecodesVolume unexpected volume: %+v", volume)
+	}
+
+	if _, err := c.GetVolume("missing"); !IsNotFound(err) {
+		t.Errorf("TestGetVolumeDecodesVolume expected a not found error, got %v", err)
+	}
+}
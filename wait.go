@@ -0,0 +1,248 @@
+// (C) Copyright 2018 Hewlett Packard Enterprise Development LP.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.  IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package onesphere
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// WaitPolicy controls how the WaitFor calls poll a resource
+type WaitPolicy struct {
+	// Interval is the wait after the first poll, it is multiplied by
+	// Multiplier after every further poll up to MaxInterval
+	Interval    time.Duration
+	MaxInterval time.Duration
+	Multiplier  float64
+	// Timeout bounds the whole wait, zero leaves it to the context
+	Timeout time.Duration
+}
+
+// DefaultWaitPolicy polls every 2s at first, backing off by half up to
+// every 30s, and gives up after 30 minutes
+func DefaultWaitPolicy() WaitPolicy {
+	return WaitPolicy{
+		Interval:    2 * time.Second,
+		MaxInterval: 30 * time.Second,
+		Multiplier:  1.5,
+		Timeout:     30 * time.Minute,
+	}
+}
+
+// WithWaitPolicy makes the WaitFor calls poll according to policy
+func WithWaitPolicy(policy WaitPolicy) Option {
+	return func(o *clientOptions) error {
+		if policy.Interval <= 0 || policy.MaxInterval < policy.Interval {
+			return fmt.Errorf("WaitPolicy intervals must satisfy 0 < Interval <= MaxInterval")
+		}
+		if policy.Multiplier < 1 {
+			return fmt.Errorf("WaitPolicy.Multiplier must be at least 1")
+		}
+		if policy.Timeout < 0 {
+			return fmt.Errorf("WaitPolicy.Timeout must not be negative")
+		}
+		o.waitPolicy = &policy
+		return nil
+	}
+}
+
+// failedStates are the states, compared ignoring case, in which a resource stops progressing
+var failedStates = []string{"Failed", "Error"}
+
+func isFailedState(state string) bool {
+	for _, failed := range failedStates {
+		if strings.EqualFold(state, failed) {
+			return true
+		}
+	}
+	return false
+}
+
+// wait calls poll until it reports done or an error, sleeping between the
+// calls according to the WaitPolicy of the Client
+func (c *Client) wait(ctx context.Context, poll func(ctx context.Context) (bool, error)) error {
+	policy := DefaultWaitPolicy()
+	if c.waitPolicy != nil {
+		policy = *c.waitPolicy
+	}
+	if policy.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, policy.Timeout)
+		defer cancel()
+	}
+
+	interval := policy.Interval
+	for {
+		done, err := poll(ctx)
+		if err != nil || done {
+			return err
+		}
+
+		if err := sleepContext(ctx, interval); err != nil {
+			return err
+		}
+		if interval = time.Duration(float64(interval) * policy.Multiplier); interval > policy.MaxInterval {
+			interval = policy.MaxInterval
+		}
+	}
+}
+
+// waitDeleted polls get until it fails with a not found error
+func (c *Client) waitDeleted(ctx context.Context, get func(ctx context.Context) error) error {
+	return c.wait(ctx, func(ctx context.Context) (bool, error) {
+		err := get(ctx)
+		if IsNotFound(err) {
+			return true, nil
+		}
+		return false, err
+	})
+}
+
+// WaitForZone polls the zone id until cond reports true and returns the last zone read.
+// It fails with an OperationFailedError once the zone reports an error or a failed state.
+//
+//	zone, err := client.WaitForZone(ctx, id, func(z onesphere.Zone) bool { return z.State == "Enabled" })
+func (c *Client) WaitForZone(ctx context.Context, id string, cond func(Zone) bool) (Zone, error) {
+	var zone Zone
+	if cond == nil {
+		return zone, fmt.Errorf("cond must not be nil")
+	}
+
+	err := c.wait(ctx, func(ctx context.Context) (bool, error) {
+		var err error
+		if zone, err = c.GetZoneByIDCtx(ctx, id); err != nil {
+			return false, err
+		}
+		if (zone.Error != nil && (zone.Error.Message != "" || zone.Error.ErrorCode != "")) || isFailedState(zone.State) {
+			return false, &OperationFailedError{Resource: "zone", ID: id, State: zone.State, Err: zone.Error}
+		}
+		return cond(zone), nil
+	})
+
+	return zone, err
+}
+
+// WaitForZoneDeleted polls the zone id until it no longer exists
+func (c *Client) WaitForZoneDeleted(ctx context.Context, id string) error {
+	return c.waitDeleted(ctx, func(ctx context.Context) error {
+		_, err := c.GetZoneByIDCtx(ctx, id)
+		return err
+	})
+}
+
+// WaitForDeployment polls the deployment id until cond reports true and returns the last deployment read.
+// It fails with an OperationFailedError once the deployment reaches a failed state.
+func (c *Client) WaitForDeployment(ctx context.Context, id string, cond func(Deployment) bool) (Deployment, error) {
+	var deployment Deployment
+	if cond == nil {
+		return deployment, fmt.Errorf("cond must not be nil")
+	}
+
+	err := c.wait(ctx, func(ctx context.Context) (bool, error) {
+		var err error
+		if deployment, err = c.GetDeploymentByIDCtx(ctx, id); err != nil {
+			return false, err
+		}
+		if isFailedState(deployment.State) {
+			return false, &OperationFailedError{Resource: "deployment", ID: id, State: deployment.State}
+		}
+		return cond(deployment), nil
+	})
+
+	return deployment, err
+}
+
+// WaitForDeploymentDeleted polls the deployment id until it no longer exists
+func (c *Client) WaitForDeploymentDeleted(ctx context.Context, id string) error {
+	return c.waitDeleted(ctx, func(ctx context.Context) error {
+		_, err := c.GetDeploymentByIDCtx(ctx, id)
+		return err
+	})
+}
+
+// WaitForCatalog polls the catalog id until cond reports true and returns the last catalog read.
+// It fails with an OperationFailedError holding the catalog message once it reaches a failed state.
+func (c *Client) WaitForCatalog(ctx context.Context, id string, cond func(Catalog) bool) (Catalog, error) {
+	var catalog Catalog
+	if cond == nil {
+		return catalog, fmt.Errorf("cond must not be nil")
+	}
+
+	err := c.wait(ctx, func(ctx context.Context) (bool, error) {
+		var err error
+		if catalog, err = c.GetCatalogByIDCtx(ctx, id, ""); err != nil {
+			return false, err
+		}
+		if isFailedState(catalog.State) {
+			return false, &OperationFailedError{Resource: "catalog", ID: id, State: catalog.State, Err: &Error{Message: catalog.Message}}
+		}
+		return cond(catalog), nil
+	})
+
+	return catalog, err
+}
+
+// WaitForRegion polls the region id until cond reports true and returns the last region read.
+// It fails with an OperationFailedError once the region reaches a failed state.
+func (c *Client) WaitForRegion(ctx context.Context, id string, cond func(Region) bool) (Region, error) {
+	var region Region
+	if cond == nil {
+		return region, fmt.Errorf("cond must not be nil")
+	}
+
+	err := c.wait(ctx, func(ctx context.Context) (bool, error) {
+		var err error
+		if region, err = c.GetRegionByIDCtx(ctx, id, "", false); err != nil {
+			return false, err
+		}
+		if isFailedState(region.State) {
+			return false, &OperationFailedError{Resource: "region", ID: id, State: region.State}
+		}
+		return cond(region), nil
+	})
+
+	return region, err
+}
+
+// WaitForRegionConnection polls the connection of the region regionId until cond
+// reports true and returns the last connection read.
+// It fails with an OperationFailedError once the connection reaches a failed state.
+func (c *Client) WaitForRegionConnection(ctx context.Context, regionId string, cond func(RegionConnection) bool) (RegionConnection, error) {
+	var connection RegionConnection
+	if cond == nil {
+		return connection, fmt.Errorf("cond must not be nil")
+	}
+
+	err := c.wait(ctx, func(ctx context.Context) (bool, error) {
+		var err error
+		if connection, err = c.GetRegionConnectionCtx(ctx, regionId); err != nil {
+			return false, err
+		}
+		if isFailedState(connection.State) {
+			return false, &OperationFailedError{Resource: "region connection", ID: regionId, State: connection.State}
+		}
+		return cond(connection), nil
+	})
+
+	return connection, err
+}
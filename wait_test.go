@@ -0,0 +1,91 @@
+package onesphere
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/HewlettPackard/hpe-onesphere-go/onespheretest"
+)
+
+// newWaitClient connects to a new fake server with a fast WaitPolicy
+func newWaitClient(t *testing.T, timeout time.Duration) (*onespheretest.Server, *Client) {
+	server := onespheretest.NewServer()
+	t.Cleanup(server.Close)
+
+	policy := WaitPolicy{Interval: 5 * time.Millisecond, MaxInterval: 20 * time.Millisecond, Multiplier: 2, Timeout: timeout}
+	c, err := Connect(server.URL, onespheretest.DefaultUser, onespheretest.DefaultPassword, WithWaitPolicy(policy))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return server, c
+}
+
+func TestWaitForZone(t *testing.T) {
+	server, c := newWaitClient(t, time.Second)
+	server.Seed("/rest/zones", Zone{ID: "z1", State: "Creating"})
+
+	go func() {
+		time.Sleep(30 * time.Millisecond)
+		server.Seed("/rest/zones", Zone{ID: "z1", State: "Enabled"})
+	}()
+
+	zone, err := c.WaitForZone(context.Background(), "z1", func(z Zone) bool { return z.State == "Enabled" })
+	if err != nil || zone.State != "Enabled" {
+		t.Errorf("TestWaitForZone expected the enabled zone, got %+v, %v", zone, err)
+	}
+}
+
+func TestWaitForZoneFailure(t *testing.T) {
+	server, c := newWaitClient(t, time.Second)
+	server.Seed("/rest/zones", Zone{ID: "z1", State: "Creating", Error: &Error{ErrorCode: "DEPLOY_FAILED", Message: "out of capacity"}})
+
+	_, err := c.WaitForZone(context.Background(), "z1", func(z Zone) bool { return z.State == "Enabled" })
+	var failed *OperationFailedError
+	if !IsOperationFailed(err) || !errors.As(err, &failed) || failed.Err.Message != "out of capacity" {
+		t.Errorf("TestWaitForZoneFailure expected an OperationFailedError, got %v", err)
+	}
+
+	server.Seed("/rest/deployments", Deployment{ID: "d1", State: "Failed"})
+	if _, err := c.WaitForDeployment(context.Background(), "d1", func(Deployment) bool { return true }); !IsOperationFailed(err) {
+		t.Errorf("TestWaitForZoneFailure expected a failed deployment, got %v", err)
+	}
+}
+
+func TestWaitForTimeout(t *testing.T) {
+	server, c := newWaitClient(t, 50*time.Millisecond)
+	server.Seed("/rest/catalogs", Catalog{ID: "c1", State: "Refreshing"})
+
+	catalog, err := c.WaitForCatalog(context.Background(), "c1", func(c Catalog) bool { return c.State == "Enabled" })
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("TestWaitForTimeout expected context.DeadlineExceeded, got %v", err)
+	}
+	if catalog.ID != "c1" {
+		t.Errorf("TestWaitForTimeout expected the last catalog read, got %+v", catalog)
+	}
+
+	if _, err := c.WaitForRegion(context.Background(), "missing", func(Region) bool { return true }); !IsNotFound(err) {
+		t.Errorf("TestWaitForTimeout expected a not found error, got %v", err)
+	}
+}
+
+func TestWaitForDeploymentDeleted(t *testing.T) {
+	server, c := newWaitClient(t, time.Second)
+	server.Seed("/rest/deployments", Deployment{ID: "d1", State: "Deleting"})
+
+	go func() {
+		time.Sleep(30 * time.Millisecond)
+		c.DeleteDeployment("d1")
+	}()
+
+	if err := c.WaitForDeploymentDeleted(context.Background(), "d1"); err != nil {
+		t.Errorf("TestWaitForDeploymentDeleted unexpected error: %v", err)
+	}
+}
+
+func TestWithWaitPolicyValidation(t *testing.T) {
+	if _, err := NewClient("https://onesphere-host-url", WithWaitPolicy(WaitPolicy{Interval: time.Second, MaxInterval: time.Millisecond, Multiplier: 1})); err == nil {
+		t.Errorf("TestWithWaitPolicyValidation MaxInterval below Interval should be rejected")
+	}
+}
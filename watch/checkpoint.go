@@ -0,0 +1,131 @@
+// (C) Copyright 2018 Hewlett Packard Enterprise Development LP.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.  IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package watch
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/HewlettPackard/hpe-onesphere-go"
+)
+
+// Checkpoint is the position of a Watcher: the creation time of the last
+// dispatched event and the ids of the events dispatched at that time
+type Checkpoint struct {
+	Time time.Time `json:"time"`
+	IDs  []string  `json:"ids,omitempty"`
+}
+
+func (c Checkpoint) seen(event onesphere.Event) bool {
+	if event.Created.Before(c.Time) {
+		return true
+	}
+	if !event.Created.Equal(c.Time) {
+		return false
+	}
+	for _, id := range c.IDs {
+		if id == event.ID {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Checkpoint) advance(event onesphere.Event) {
+	if !event.Created.Equal(c.Time) {
+		c.Time, c.IDs = event.Created, nil
+	}
+	c.IDs = append(c.IDs, event.ID)
+}
+
+// CheckpointStore persists the Checkpoint of a Watcher
+type CheckpointStore interface {
+	// Load returns the saved Checkpoint, or the zero Checkpoint if none was saved
+	Load() (Checkpoint, error)
+	Save(checkpoint Checkpoint) error
+}
+
+// MemoryStore keeps the Checkpoint in memory, for watchers that start over
+// from the beginning on every run
+type MemoryStore struct {
+	mu         sync.Mutex
+	checkpoint Checkpoint
+}
+
+func (s *MemoryStore) Load() (Checkpoint, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.checkpoint, nil
+}
+
+func (s *MemoryStore) Save(checkpoint Checkpoint) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.checkpoint = checkpoint
+	return nil
+}
+
+// FileStore saves the Checkpoint as JSON in the file at path.
+// The file is replaced atomically so a crash never leaves it half written.
+type FileStore string
+
+func (path FileStore) Load() (Checkpoint, error) {
+	var checkpoint Checkpoint
+	data, err := ioutil.ReadFile(string(path))
+	if os.IsNotExist(err) {
+		return checkpoint, nil
+	}
+	if err != nil {
+		return checkpoint, err
+	}
+	err = json.Unmarshal(data, &checkpoint)
+	return checkpoint, err
+}
+
+func (path FileStore) Save(checkpoint Checkpoint) error {
+	data, err := json.Marshal(checkpoint)
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(string(path))
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(dir, filepath.Base(string(path))+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), string(path))
+}
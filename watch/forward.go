@@ -0,0 +1,142 @@
+// (C) Copyright 2018 Hewlett Packard Enterprise Development LP.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.  IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package watch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/HewlettPackard/hpe-onesphere-go"
+)
+
+// Webhook is a Handler posting each event as JSON to URL
+type Webhook struct {
+	URL string
+	// Header is added to the requests, e.g. an Authorization expected by the receiver
+	Header http.Header
+	// Client sends the requests, http.DefaultClient when nil
+	Client *http.Client
+}
+
+// Handle posts event to the webhook, any status other than 2xx is an error
+func (h Webhook) Handle(ctx context.Context, event onesphere.Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	for key, values := range h.Header {
+		req.Header[key] = values
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := h.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(ioutil.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook %s returned %s", h.URL, resp.Status)
+	}
+	return nil
+}
+
+// Command is a Handler running a command for each event. The event is written
+// as JSON to its standard input and its fields are set in the environment as
+// ONESPHERE_EVENT_ID, ONESPHERE_EVENT_NAME, ONESPHERE_EVENT_RESOURCE_URI,
+// ONESPHERE_EVENT_RESOURCE_TYPE and ONESPHERE_EVENT_USER_ID.
+type Command struct {
+	Path string
+	Args []string
+	// Dir is the working directory of the command, the current one when empty
+	Dir string
+	// Env is the environment of the command besides the event variables.
+	// When nil the command gets the environment of the process without the
+	// onesphere.EnvPassword and onesphere.EnvToken credentials.
+	Env []string
+}
+
+// credentialVars are removed from the environment inherited by a Command
+var credentialVars = []string{onesphere.EnvPassword, onesphere.EnvToken}
+
+// inheritedEnv returns the environment of the process without credentialVars
+func inheritedEnv() []string {
+	var env []string
+	for _, kv := range os.Environ() {
+		name := strings.SplitN(kv, "=", 2)[0]
+		secret := false
+		for _, v := range credentialVars {
+			if name == v {
+				secret = true
+			}
+		}
+		if !secret {
+			env = append(env, kv)
+		}
+	}
+	return env
+}
+
+// Handle runs the command, a non-zero exit status is an error holding its output
+func (h Command) Handle(ctx context.Context, event onesphere.Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	cmd := exec.CommandContext(ctx, h.Path, h.Args...)
+	cmd.Dir = h.Dir
+	cmd.Stdin = bytes.NewReader(body)
+	env := h.Env
+	if env == nil {
+		env = inheritedEnv()
+	}
+	cmd.Env = append(env[:len(env):len(env)],
+		"ONESPHERE_EVENT_ID="+event.ID,
+		"ONESPHERE_EVENT_NAME="+event.Name,
+		"ONESPHERE_EVENT_RESOURCE_URI="+event.ResourceURI,
+		"ONESPHERE_EVENT_RESOURCE_TYPE="+ResourceType(event.ResourceURI),
+		"ONESPHERE_EVENT_USER_ID="+event.UserID,
+	)
+
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("command %s: %v: %s", h.Path, err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
@@ -0,0 +1,205 @@
+// (C) Copyright 2018 Hewlett Packard Enterprise Development LP.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.  IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+// Package watch polls the OneSphere events and dispatches them to handlers
+// registered by resource type and event name. The position of the Watcher is
+// saved as a Checkpoint after every event, so a restarted process resumes
+// where it stopped:
+//
+//	w := watch.New(client, watch.FileStore("/var/lib/mydaemon/checkpoint.json"))
+//	w.Handle("projects", "", watch.Webhook{URL: "http://localhost:8080/hook"})
+//	w.Handle("", "deleted", watch.Command{Path: "/usr/local/bin/on-delete"})
+//	err := w.Run(ctx)
+//
+// Events are delivered at least once: an event whose handler fails is
+// dispatched again by the next Poll. Run keeps polling after such failures
+// and reports them to OnError.
+package watch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/HewlettPackard/hpe-onesphere-go"
+)
+
+// DefaultInterval is the time Run waits between two polls
+const DefaultInterval = 30 * time.Second
+
+// Handler is called with the events matching its registration
+type Handler interface {
+	Handle(ctx context.Context, event onesphere.Event) error
+}
+
+// HandlerFunc adapts a function to a Handler
+type HandlerFunc func(ctx context.Context, event onesphere.Event) error
+
+// Handle calls f(ctx, event)
+func (f HandlerFunc) Handle(ctx context.Context, event onesphere.Event) error {
+	return f(ctx, event)
+}
+
+type route struct {
+	resourceType string
+	eventName    string
+	handler      Handler
+}
+
+// Watcher polls the events of a OneSphere client from a Checkpoint.
+// Handlers must be registered before Poll or Run are called.
+type Watcher struct {
+	// Interval is the time Run waits between two polls, DefaultInterval when zero
+	Interval time.Duration
+
+	// OnError is called by Run with the errors of Poll before it retries
+	// on the next tick, they are dropped when nil
+	OnError func(error)
+
+	events     onesphere.EventsAPI
+	store      CheckpointStore
+	routes     []route
+	checkpoint Checkpoint
+	loaded     bool
+}
+
+// CheckpointError is returned by Poll when the checkpoint can't be loaded or saved
+type CheckpointError struct {
+	Op  string
+	Err error
+}
+
+func (e *CheckpointError) Error() string {
+	return fmt.Sprintf("watch: %s checkpoint: %v", e.Op, e.Err)
+}
+
+// Unwrap returns the error of the CheckpointStore
+func (e *CheckpointError) Unwrap() error {
+	return e.Err
+}
+
+// New returns a Watcher reading events from events and saving its position in store
+func New(events onesphere.EventsAPI, store CheckpointStore) *Watcher {
+	return &Watcher{events: events, store: store}
+}
+
+// Handle registers h for the events of resourceType, the collection of the
+// resource uri such as "projects" or "zones", and of eventName.
+// An empty resourceType or eventName matches any.
+func (w *Watcher) Handle(resourceType, eventName string, h Handler) {
+	w.routes = append(w.routes, route{resourceType: resourceType, eventName: eventName, handler: h})
+}
+
+// Checkpoint returns the position of the Watcher
+func (w *Watcher) Checkpoint() Checkpoint {
+	return w.checkpoint
+}
+
+// Poll dispatches the events created since the checkpoint, oldest first,
+// and returns how many were dispatched. It stops at the first handler error.
+func (w *Watcher) Poll(ctx context.Context) (int, error) {
+	if !w.loaded {
+		checkpoint, err := w.store.Load()
+		if err != nil {
+			return 0, &CheckpointError{Op: "loading", Err: err}
+		}
+		w.checkpoint, w.loaded = checkpoint, true
+	}
+
+	opts := onesphere.EventListOptions{
+		Since:       w.checkpoint.Time,
+		ListOptions: onesphere.ListOptions{Sort: "created:asc"},
+	}
+
+	dispatched := 0
+	err := w.events.ForEachEventCtx(ctx, opts, func(event onesphere.Event) error {
+		if w.checkpoint.seen(event) {
+			return nil
+		}
+		if err := w.dispatch(ctx, event); err != nil {
+			return err
+		}
+		w.checkpoint.advance(event)
+		if err := w.store.Save(w.checkpoint); err != nil {
+			return &CheckpointError{Op: "saving", Err: err}
+		}
+		dispatched++
+		return nil
+	})
+	return dispatched, err
+}
+
+// Run polls every Interval until ctx is done or the checkpoint can't be
+// loaded or saved. Other Poll errors are passed to OnError and the events
+// not yet dispatched are retried on the next tick.
+func (w *Watcher) Run(ctx context.Context) error {
+	interval := w.Interval
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := w.Poll(ctx); err != nil {
+			var checkpointErr *CheckpointError
+			if errors.As(err, &checkpointErr) {
+				return err
+			}
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if w.OnError != nil {
+				w.OnError(err)
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w *Watcher) dispatch(ctx context.Context, event onesphere.Event) error {
+	resourceType := ResourceType(event.ResourceURI)
+	for _, r := range w.routes {
+		if (r.resourceType != "" && r.resourceType != resourceType) || (r.eventName != "" && r.eventName != event.Name) {
+			continue
+		}
+		if err := r.handler.Handle(ctx, event); err != nil {
+			return fmt.Errorf("watch: handling event %s of %s: %w", event.ID, event.ResourceURI, err)
+		}
+	}
+	return nil
+}
+
+// ResourceType returns the collection of a resource uri,
+// e.g. "zones" for "/rest/zones/1234/connections/5678"
+func ResourceType(resourceURI string) string {
+	path := strings.TrimPrefix(resourceURI, "/rest/")
+	if i := strings.IndexByte(path, '/'); i >= 0 {
+		path = path[:i]
+	}
+	return path
+}
@@ -0,0 +1,184 @@
+package watch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/HewlettPackard/hpe-onesphere-go"
+	"github.com/HewlettPackard/hpe-onesphere-go/onespherefake"
+	"github.com/stretchr/testify/assert"
+)
+
+var noon = time.Date(2018, 5, 1, 12, 0, 0, 0, time.UTC)
+
+func newEvents(t *testing.T) *onespherefake.Client {
+	c := onespherefake.New()
+	assert.NoError(t, c.Seed(
+		onesphere.Event{ID: "e1", Name: "created", ResourceURI: "/rest/projects/p1", Created: noon},
+		onesphere.Event{ID: "e2", Name: "updated", ResourceURI: "/rest/zones/z1/connections/c1", Created: noon},
+		onesphere.Event{ID: "e3", Name: "deleted", ResourceURI: "/rest/projects/p1", Created: noon.Add(time.Minute)},
+	))
+	return c
+}
+
+// recordIDs is a Handler appending the ids of the events to ids
+func recordIDs(ids *[]string) Handler {
+	return HandlerFunc(func(ctx context.Context, event onesphere.Event) error {
+		*ids = append(*ids, event.ID)
+		return nil
+	})
+}
+
+func TestDispatch(t *testing.T) {
+	w := New(newEvents(t), &MemoryStore{})
+	var all, projects, deleted, zones []string
+	w.Handle("", "", recordIDs(&all))
+	w.Handle("projects", "", recordIDs(&projects))
+	w.Handle("", "deleted", recordIDs(&deleted))
+	w.Handle("zones", "updated", recordIDs(&zones))
+
+	n, err := w.Poll(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 3, n)
+	assert.Equal(t, []string{"e1", "e2", "e3"}, all)
+	assert.Equal(t, []string{"e1", "e3"}, projects)
+	assert.Equal(t, []string{"e3"}, deleted)
+	assert.Equal(t, []string{"e2"}, zones)
+
+	n, err = w.Poll(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 0, n, "events already dispatched must be skipped")
+	assert.Equal(t, Checkpoint{Time: noon.Add(time.Minute), IDs: []string{"e3"}}, w.Checkpoint())
+}
+
+func TestResumeFromFileStore(t *testing.T) {
+	events := newEvents(t)
+	store := FileStore(filepath.Join(t.TempDir(), "state", "checkpoint.json"))
+
+	var ids []string
+	w := New(events, store)
+	w.Handle("", "", HandlerFunc(func(ctx context.Context, event onesphere.Event) error {
+		if event.ID == "e2" {
+			return errors.New("receiver down")
+		}
+		ids = append(ids, event.ID)
+		return nil
+	}))
+	_, err := w.Poll(context.Background())
+	assert.EqualError(t, err, "watch: handling event e2 of /rest/zones/z1/connections/c1: receiver down")
+
+	assert.NoError(t, events.Seed(onesphere.Event{ID: "e4", Name: "updated", ResourceURI: "/rest/projects/p1", Created: noon.Add(time.Hour)}))
+
+	restarted := New(events, store)
+	restarted.Handle("", "", recordIDs(&ids))
+	n, err := restarted.Poll(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 3, n)
+	assert.Equal(t, []string{"e1", "e2", "e3", "e4"}, ids)
+}
+
+// failingStore is a MemoryStore whose Save fails
+type failingStore struct{ MemoryStore }
+
+func (s *failingStore) Save(checkpoint Checkpoint) error {
+	return errors.New("disk full")
+}
+
+func TestRunRetriesPollErrors(t *testing.T) {
+	events := newEvents(t)
+	events.SetError("ForEachEvent", errors.New("service unavailable"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var ids []string
+	var errs []error
+	w := New(events, &MemoryStore{})
+	w.Interval = time.Millisecond
+	w.OnError = func(err error) {
+		errs = append(errs, err)
+		events.SetError("ForEachEvent", nil)
+	}
+	w.Handle("", "", HandlerFunc(func(ctx context.Context, event onesphere.Event) error {
+		ids = append(ids, event.ID)
+		if len(ids) == 1 {
+			return errors.New("receiver down")
+		}
+		if event.ID == "e3" {
+			cancel()
+		}
+		return nil
+	}))
+
+	assert.Equal(t, context.Canceled, w.Run(ctx))
+	if assert.Len(t, errs, 2) {
+		assert.EqualError(t, errs[0], "service unavailable")
+		assert.EqualError(t, errs[1], "watch: handling event e1 of /rest/projects/p1: receiver down")
+	}
+	assert.Equal(t, []string{"e1", "e1", "e2", "e3"}, ids)
+	assert.Equal(t, Checkpoint{Time: noon.Add(time.Minute), IDs: []string{"e3"}}, w.Checkpoint())
+}
+
+func TestRunStopsOnCheckpointErrors(t *testing.T) {
+	w := New(newEvents(t), &failingStore{})
+	w.Interval = time.Millisecond
+	w.OnError = func(err error) { t.Errorf("unexpected OnError(%v)", err) }
+
+	err := w.Run(context.Background())
+	assert.EqualError(t, err, "watch: saving checkpoint: disk full")
+	var checkpointErr *CheckpointError
+	assert.True(t, errors.As(err, &checkpointErr))
+}
+
+func TestWebhook(t *testing.T) {
+	var received onesphere.Event
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Token") != "t" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		json.NewDecoder(r.Body).Decode(&received)
+	}))
+	defer ts.Close()
+
+	event := onesphere.Event{ID: "e1", ResourceURI: "/rest/projects/p1", UserID: "u1", Created: noon}
+	assert.NoError(t, Webhook{URL: ts.URL, Header: http.Header{"X-Token": {"t"}}}.Handle(context.Background(), event))
+	assert.Equal(t, event, received)
+
+	assert.EqualError(t, Webhook{URL: ts.URL}.Handle(context.Background(), event),
+		"webhook "+ts.URL+" returned 401 Unauthorized")
+}
+
+func TestCommand(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "out")
+	h := Command{Path: "sh", Args: []string{"-c", `echo "$ONESPHERE_EVENT_RESOURCE_TYPE" > ` + out + `; cat >> ` + out}}
+
+	assert.NoError(t, h.Handle(context.Background(), onesphere.Event{ID: "e1", ResourceURI: "/rest/zones/z1"}))
+	data, err := ioutil.ReadFile(out)
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), "zones\n{\"id\":\"e1\"")
+
+	err = Command{Path: "sh", Args: []string{"-c", "echo failed; exit 3"}}.Handle(context.Background(), onesphere.Event{})
+	assert.EqualError(t, err, "command sh: exit status 3: failed")
+}
+
+func TestCommandEnvironment(t *testing.T) {
+	t.Setenv(onesphere.EnvPassword, "s3cr3t")
+	t.Setenv(onesphere.EnvToken, "t0ken")
+	t.Setenv("WATCH_TEST", "kept")
+	printEnv := []string{"-c", `echo "[$WATCH_TEST][$` + onesphere.EnvPassword + `][$` + onesphere.EnvToken + `][$ONESPHERE_EVENT_ID]" >&2; exit 1`}
+
+	err := Command{Path: "sh", Args: printEnv}.Handle(context.Background(), onesphere.Event{ID: "e1"})
+	assert.EqualError(t, err, "command sh: exit status 1: [kept][][][e1]")
+
+	err = Command{Path: "sh", Args: printEnv, Env: []string{"PATH=" + os.Getenv("PATH"), "WATCH_TEST=explicit"}}.Handle(context.Background(), onesphere.Event{ID: "e1"})
+	assert.EqualError(t, err, "command sh: exit status 1: [explicit][][][e1]")
+}
@@ -21,8 +21,10 @@
 package onesphere
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"github.com/HewlettPackard/hpe-onesphere-go/query"
 	"github.com/HewlettPackard/hpe-onesphere-go/rest"
 	"time"
 )
@@ -159,22 +161,40 @@ using a "name1 EQ value1 AND name2 EQ value2" syntax.
 
 example query: "providerUri EQ /rest/providers/xxxx"
 
+use the query package to build queries with quoted values:
+query.Eq("name", "my zone").And(query.Eq("regionUri", regionUri)).String()
+
 example view: "full"
 */
 func (c *Client) GetZones(query, regionUri, providerUri, applianceUri, view string) (ZoneList, error) {
+	return c.GetZonesCtx(context.Background(), query, regionUri, providerUri, applianceUri, view)
+}
+
+// GetZonesCtx is like GetZones but uses ctx for the request
+func (c *Client) GetZonesCtx(ctx context.Context, query, regionUri, providerUri, applianceUri, view string) (ZoneList, error) {
+	return c.ListZonesCtx(ctx, ZoneListOptions{
+		Query:        query,
+		RegionURI:    regionUri,
+		ProviderURI:  providerUri,
+		ApplianceURI: applianceUri,
+		ListOptions:  ListOptions{View: View(view)},
+	})
+}
+
+// ListZones returns the ZoneList selected by opts
+func (c *Client) ListZones(opts ZoneListOptions) (ZoneList, error) {
+	return c.ListZonesCtx(context.Background(), opts)
+}
+
+// ListZonesCtx is like ListZones but uses ctx for the request
+func (c *Client) ListZonesCtx(ctx context.Context, opts ZoneListOptions) (ZoneList, error) {
 	var (
 		uri         = "/rest/zones"
-		queryParams = createQuery(&map[string]string{
-			query:        "query",
-			regionUri:    "regionUri",
-			providerUri:  "providerUri",
-			applianceUri: "applianceUri",
-			view:         "view",
-		})
-		zones ZoneList
+		queryParams = encodeParams(opts)
+		zones       ZoneList
 	)
 
-	response, err := c.RestAPICall(rest.GET, uri, queryParams, nil)
+	response, err := c.RestAPICallCtx(ctx, rest.GET, uri, queryParams, nil)
 
 	if err != nil {
 		return zones, err
@@ -187,8 +207,37 @@ func (c *Client) GetZones(query, regionUri, providerUri, applianceUri, view stri
 	return zones, nil
 }
 
+// ForEachZone calls fn for every Zone selected by opts across all pages,
+// see ForEachDeployment for paging and how fn stops the iteration
+func (c *Client) ForEachZone(opts ZoneListOptions, fn func(Zone) error) error {
+	return c.ForEachZoneCtx(context.Background(), opts, fn)
+}
+
+// ForEachZoneCtx is like ForEachZone but uses ctx for the requests
+func (c *Client) ForEachZoneCtx(ctx context.Context, opts ZoneListOptions, fn func(Zone) error) error {
+	return c.forEachPage(ctx, "/rest/zones", encodeParams(opts), func(response string) (page, error) {
+		var zones ZoneList
+		if err := json.Unmarshal([]byte(response), &zones); err != nil {
+			return page{}, apiResponseError(response, err)
+		}
+
+		for _, zone := range zones.Members {
+			if err := fn(zone); err != nil {
+				return page{}, err
+			}
+		}
+
+		return page{total: zones.Total, members: len(zones.Members)}, nil
+	})
+}
+
 // GetZoneByID Retrieve Zone by ID
 func (c *Client) GetZoneByID(id string) (Zone, error) {
+	return c.GetZoneByIDCtx(context.Background(), id)
+}
+
+// GetZoneByIDCtx is like GetZoneByID but uses ctx for the request
+func (c *Client) GetZoneByIDCtx(ctx context.Context, id string) (Zone, error) {
 	var (
 		uri  = "/rest/zones/" + id
 		zone Zone
@@ -198,7 +247,7 @@ func (c *Client) GetZoneByID(id string) (Zone, error) {
 		return zone, fmt.Errorf("id must not be empty")
 	}
 
-	response, err := c.RestAPICall(rest.GET, uri, nil, nil)
+	response, err := c.RestAPICallCtx(ctx, rest.GET, uri, nil, nil)
 
 	if err != nil {
 		return zone, err
@@ -211,22 +260,47 @@ func (c *Client) GetZoneByID(id string) (Zone, error) {
 	return zone, err
 }
 
-// GetZoneByID Retrieve Zone by Name
+// GetZoneByName Retrieve Zone by Name
+// returns a NameNotFoundError or an AmbiguousNameError unless exactly one zone has the name
 func (c *Client) GetZoneByName(name string) (Zone, error) {
-	var zone Zone
+	return c.GetZoneByNameCtx(context.Background(), name)
+}
 
-	zones, err := c.GetZones("name EQ "+name, "", "", "", "")
+// GetZoneByNameCtx is like GetZoneByName but uses ctx for the request
+func (c *Client) GetZoneByNameCtx(ctx context.Context, name string) (Zone, error) {
+	var (
+		zone    Zone
+		matches = newNameMatches("zone", name)
+	)
 
-	if len(zones.Members) == 0 {
-		return zone, err
+	if name == "" {
+		return zone, fmt.Errorf("name must not be empty")
 	}
-	zone = zones.Members[0]
 
-	return zone, err
+	err := c.ForEachZoneCtx(ctx, ZoneListOptions{Query: query.Eq("name", name).String()}, func(candidate Zone) error {
+		if matches.add(candidate.Name, candidate.URI) {
+			zone = candidate
+		}
+		return nil
+	})
+	if err != nil {
+		return Zone{}, err
+	}
+
+	if err := matches.err(); err != nil {
+		return Zone{}, err
+	}
+
+	return zone, nil
 }
 
 // GetZoneApplianceImage Retrieve Zone Appliance Image URI by Zone.ID
 func (c *Client) GetZoneApplianceImage(id string) (string, error) {
+	return c.GetZoneApplianceImageCtx(context.Background(), id)
+}
+
+// GetZoneApplianceImageCtx is like GetZoneApplianceImage but uses ctx for the request
+func (c *Client) GetZoneApplianceImageCtx(ctx context.Context, id string) (string, error) {
 	var (
 		uri               = "/rest/zones/" + id + "/appliance-image"
 		applianceImageURI string
@@ -236,13 +310,18 @@ func (c *Client) GetZoneApplianceImage(id string) (string, error) {
 		return applianceImageURI, fmt.Errorf("id must not be empty")
 	}
 
-	applianceImageURI, err := c.RestAPICall(rest.GET, uri, nil, nil)
+	applianceImageURI, err := c.RestAPICallCtx(ctx, rest.GET, uri, nil, nil)
 
 	return applianceImageURI, err
 }
 
 // GetZoneTaskStatus Retrieve Zone Appliance Image URI by Zone.ID
 func (c *Client) GetZoneTaskStatus(id string) (string, error) {
+	return c.GetZoneTaskStatusCtx(context.Background(), id)
+}
+
+// GetZoneTaskStatusCtx is like GetZoneTaskStatus but uses ctx for the request
+func (c *Client) GetZoneTaskStatusCtx(ctx context.Context, id string) (string, error) {
 	var (
 		uri        = "/rest/zones/" + id + "/task-status"
 		taskStatus string
@@ -252,7 +331,7 @@ func (c *Client) GetZoneTaskStatus(id string) (string, error) {
 		return taskStatus, fmt.Errorf("id must not be empty")
 	}
 
-	taskStatus, err := c.RestAPICall(rest.GET, uri, nil, nil)
+	taskStatus, err := c.RestAPICallCtx(ctx, rest.GET, uri, nil, nil)
 
 	return taskStatus, err
 }
@@ -260,6 +339,11 @@ func (c *Client) GetZoneTaskStatus(id string) (string, error) {
 // GetZoneConnections with optional uuid filter
 // leave uuid blank to get all connections
 func (c *Client) GetZoneConnections(id, uuid string) (ConnectionList, error) {
+	return c.GetZoneConnectionsCtx(context.Background(), id, uuid)
+}
+
+// GetZoneConnectionsCtx is like GetZoneConnections but uses ctx for the request
+func (c *Client) GetZoneConnectionsCtx(ctx context.Context, id, uuid string) (ConnectionList, error) {
 	var (
 		uri         = "/rest/zones/" + id + "/connections"
 		queryParams = createQuery(&map[string]string{
@@ -268,7 +352,7 @@ func (c *Client) GetZoneConnections(id, uuid string) (ConnectionList, error) {
 		connections ConnectionList
 	)
 
-	response, err := c.RestAPICall(rest.GET, uri, queryParams, nil)
+	response, err := c.RestAPICallCtx(ctx, rest.GET, uri, queryParams, nil)
 
 	if err != nil {
 		return connections, err
@@ -283,12 +367,17 @@ func (c *Client) GetZoneConnections(id, uuid string) (ConnectionList, error) {
 
 // CreateZone Creates Zone and returns updated zone
 func (c *Client) CreateZone(zoneRequest ZoneRequest) (Zone, error) {
+	return c.CreateZoneCtx(context.Background(), zoneRequest)
+}
+
+// CreateZoneCtx is like CreateZone but uses ctx for the request
+func (c *Client) CreateZoneCtx(ctx context.Context, zoneRequest ZoneRequest) (Zone, error) {
 	var (
 		uri  = "/rest/zones/"
 		zone Zone
 	)
 
-	response, err := c.RestAPICall(rest.POST, uri, nil, zoneRequest)
+	response, err := c.RestAPICallCtx(ctx, rest.POST, uri, nil, zoneRequest)
 
 	if err != nil {
 		return zone, err
@@ -303,12 +392,17 @@ func (c *Client) CreateZone(zoneRequest ZoneRequest) (Zone, error) {
 
 // CreateZoneConnection Creates Connection and returns updated connection
 func (c *Client) CreateZoneConnection(id string, connectionRequest ConnectionRequest) (Connection, error) {
+	return c.CreateZoneConnectionCtx(context.Background(), id, connectionRequest)
+}
+
+// CreateZoneConnectionCtx is like CreateZoneConnection but uses ctx for the request
+func (c *Client) CreateZoneConnectionCtx(ctx context.Context, id string, connectionRequest ConnectionRequest) (Connection, error) {
 	var (
 		uri        = "/rest/zones/" + id + "/connections"
 		connection Connection
 	)
 
-	response, err := c.RestAPICall(rest.POST, uri, nil, connectionRequest)
+	response, err := c.RestAPICallCtx(ctx, rest.POST, uri, nil, connectionRequest)
 
 	if err != nil {
 		return connection, err
@@ -375,6 +469,11 @@ Value: []struct {
 
 */
 func (c *Client) UpdateZone(zoneId string, updates []*PatchOp) (Zone, error) {
+	return c.UpdateZoneCtx(context.Background(), zoneId, updates)
+}
+
+// UpdateZoneCtx is like UpdateZone but uses ctx for the request
+func (c *Client) UpdateZoneCtx(ctx context.Context, zoneId string, updates []*PatchOp) (Zone, error) {
 	var (
 		uri         = "/rest/zones/" + zoneId
 		updatedZone Zone
@@ -384,7 +483,7 @@ func (c *Client) UpdateZone(zoneId string, updates []*PatchOp) (Zone, error) {
 		return updatedZone, fmt.Errorf("zoneId must be non-empty")
 	}
 
-	response, err := c.RestAPICallPatch(uri, nil, updates)
+	response, err := c.RestAPICallPatchCtx(ctx, uri, nil, updates)
 
 	if err != nil {
 		return updatedZone, err
@@ -402,6 +501,11 @@ func (c *Client) UpdateZone(zoneId string, updates []*PatchOp) (Zone, error) {
 Allowed Ops for PATCH of networks: add | replace | remove
 */
 func (c *Client) UpdateZoneConnection(zoneId, connectionUuid string, updates []*PatchOp) (Connection, error) {
+	return c.UpdateZoneConnectionCtx(context.Background(), zoneId, connectionUuid, updates)
+}
+
+// UpdateZoneConnectionCtx is like UpdateZoneConnection but uses ctx for the request
+func (c *Client) UpdateZoneConnectionCtx(ctx context.Context, zoneId, connectionUuid string, updates []*PatchOp) (Connection, error) {
 	var (
 		uri               = "/rest/zones/" + zoneId + "/connections/" + connectionUuid
 		updatedConnection Connection
@@ -415,7 +519,7 @@ func (c *Client) UpdateZoneConnection(zoneId, connectionUuid string, updates []*
 		return updatedConnection, fmt.Errorf("connectionUuid must be non-empty")
 	}
 
-	response, err := c.RestAPICallPatch(uri, nil, updates)
+	response, err := c.RestAPICallPatchCtx(ctx, uri, nil, updates)
 
 	if err != nil {
 		return updatedConnection, err
@@ -430,16 +534,21 @@ func (c *Client) UpdateZoneConnection(zoneId, connectionUuid string, updates []*
 
 // DeleteZone Deletes Zone
 func (c *Client) DeleteZone(zoneId string) error {
+	return c.DeleteZoneCtx(context.Background(), zoneId)
+}
+
+// DeleteZoneCtx is like DeleteZone but uses ctx for the request
+func (c *Client) DeleteZoneCtx(ctx context.Context, zoneId string) error {
 	if zoneId == "" {
 		return fmt.Errorf("zoneId must be non-empty")
 	}
 
 	var uri = "/rest/zones/" + zoneId
 
-	response, err := c.RestAPICall(rest.DELETE, uri, nil, nil)
+	_, err := c.RestAPICallCtx(ctx, rest.DELETE, uri, nil, nil)
 
 	if err != nil {
-		return apiResponseError(response, err)
+		return err
 	}
 
 	return nil
@@ -447,6 +556,11 @@ func (c *Client) DeleteZone(zoneId string) error {
 
 // DeleteZoneConnection Deletes Zone Connection
 func (c *Client) DeleteZoneConnection(zoneId, connectionUuid string) error {
+	return c.DeleteZoneConnectionCtx(context.Background(), zoneId, connectionUuid)
+}
+
+// DeleteZoneConnectionCtx is like DeleteZoneConnection but uses ctx for the request
+func (c *Client) DeleteZoneConnectionCtx(ctx context.Context, zoneId, connectionUuid string) error {
 	if zoneId == "" {
 		return fmt.Errorf("zoneId must be non-empty")
 	}
@@ -457,10 +571,10 @@ func (c *Client) DeleteZoneConnection(zoneId, connectionUuid string) error {
 
 	var uri = "/rest/zones/" + zoneId + "/connections/" + connectionUuid
 
-	response, err := c.RestAPICall(rest.DELETE, uri, nil, nil)
+	_, err := c.RestAPICallCtx(ctx, rest.DELETE, uri, nil, nil)
 
 	if err != nil {
-		return apiResponseError(response, err)
+		return err
 	}
 
 	return nil
@@ -481,6 +595,11 @@ ZoneAction{
 }
 */
 func (c *Client) ActionZone(zoneId string, action ZoneAction) error {
+	return c.ActionZoneCtx(context.Background(), zoneId, action)
+}
+
+// ActionZoneCtx is like ActionZone but uses ctx for the request
+func (c *Client) ActionZoneCtx(ctx context.Context, zoneId string, action ZoneAction) error {
 
 	if zoneId == "" {
 		return fmt.Errorf("zoneId must be non-empty")
@@ -488,10 +607,10 @@ func (c *Client) ActionZone(zoneId string, action ZoneAction) error {
 
 	var uri = "/rest/zones/" + zoneId + "/actions"
 
-	response, err := c.RestAPICall(rest.POST, uri, nil, action)
+	_, err := c.RestAPICallCtx(ctx, rest.POST, uri, nil, action)
 
 	if err != nil {
-		return apiResponseError(response, err)
+		return err
 	}
 
 	return nil
@@ -1,6 +1,13 @@
 package onesphere
 
-import "testing"
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
 
 func TestGetZones(t *testing.T) {
 	setup()
@@ -42,10 +49,10 @@ func TestGetZoneByID(t *testing.T) {
 func TestGetZoneByName(t *testing.T) {
 	setup()
 
-	_, err := client.GetZoneByName("2")
-	if err != nil {
-		t.Error(err)
-	}
+	checkByName(t, "TestGetZoneByName", "zone", func(name string) (string, error) {
+		zone, err := client.GetZoneByName(name)
+		return zone.Name, err
+	}, "/rest/zones", Zone{ID: "3", Name: "zone"})
 }
 
 func TestGetZoneApplianceImage(t *testing.T) {
@@ -158,3 +165,21 @@ func TestActionZone(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+func TestGetZonesCtxDeadline(t *testing.T) {
+	done := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-done
+	}))
+	defer ts.Close()
+	defer close(done)
+
+	c := &Client{Auth: &Auth{HostURL: ts.URL}}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	if _, err := c.GetZonesCtx(ctx, "", "", "", "", ""); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("TestGetZonesCtxDeadline expected context.DeadlineExceeded, got %v", err)
+	}
+}